@@ -257,70 +257,85 @@ func (m EntitlementsMigration) ConvertToEntitledType(
 // ConvertValueToEntitlements converts the input value into a version compatible with the new entitlements feature,
 // with the same members/operations accessible on any references as would have been accessible in the past.
 func (m EntitlementsMigration) ConvertValueToEntitlements(v interpreter.Value) (interpreter.Value, error) {
-	inter := m.Interpreter
+	return convertValueStaticTypes(m.Interpreter, v, m.ConvertToEntitledType)
+}
+
+// staticTypeConverter converts the given static type.
+// It returns nil if the given static type does not need to be converted.
+type staticTypeConverter func(staticType interpreter.StaticType) (interpreter.StaticType, error)
+
+// convertValueStaticTypes converts the static types embedded in the input value,
+// e.g. the element type of a container or the borrow type of a capability,
+// using the given static type converter.
+// It returns nil if the input value does not need to be converted.
+func convertValueStaticTypes(
+	inter *interpreter.Interpreter,
+	v interpreter.Value,
+	convertType staticTypeConverter,
+) (interpreter.Value, error) {
 
 	switch v := v.(type) {
 
 	case *interpreter.ArrayValue:
 		elementType := v.Type
 
-		entitledElementType, err := m.ConvertToEntitledType(elementType)
+		convertedElementType, err := convertType(elementType)
 		if err != nil {
 			return nil, err
 		}
 
-		if entitledElementType == nil {
+		if convertedElementType == nil {
 			return nil, nil
 		}
 
 		v.SetType(
-			entitledElementType.(interpreter.ArrayStaticType),
+			convertedElementType.(interpreter.ArrayStaticType),
 		)
 
 	case *interpreter.DictionaryValue:
 		elementType := v.Type
 
-		entitledElementType, err := m.ConvertToEntitledType(elementType)
+		convertedElementType, err := convertType(elementType)
 		if err != nil {
 			return nil, err
 		}
 
-		if entitledElementType == nil {
+		if convertedElementType == nil {
 			return nil, nil
 		}
 
 		v.SetType(
-			entitledElementType.(*interpreter.DictionaryStaticType),
+			convertedElementType.(*interpreter.DictionaryStaticType),
 		)
 
 	case *interpreter.IDCapabilityValue:
 		borrowType := v.BorrowType
 
-		entitledBorrowType, err := m.ConvertToEntitledType(borrowType)
+		convertedBorrowType, err := convertType(borrowType)
 		if err != nil {
 			return nil, err
 		}
 
-		if entitledBorrowType != nil {
+		if convertedBorrowType != nil {
 			return interpreter.NewCapabilityValue(
 				inter,
 				v.ID,
 				v.Address(),
-				entitledBorrowType,
+				convertedBorrowType,
 			), nil
 		}
 
 	case *interpreter.PathCapabilityValue: //nolint:staticcheck
 		borrowType := v.BorrowType
 
-		entitledBorrowType, err := m.ConvertToEntitledType(borrowType)
+		convertedBorrowType, err := convertType(borrowType)
 		if err != nil {
 			return nil, err
 		}
 
-		if entitledBorrowType != nil {
+		if convertedBorrowType != nil {
 			return interpreter.NewUnmeteredPathCapabilityValue( //nolint:staticcheck
-				entitledBorrowType,
+				convertedBorrowType,
 				v.Address(),
 				v.Path,
 			), nil
@@ -329,27 +344,27 @@ func (m EntitlementsMigration) ConvertValueToEntitlements(v interpreter.Value) (
 	case interpreter.TypeValue:
 		ty := v.Type
 
-		entitledType, err := m.ConvertToEntitledType(ty)
+		convertedType, err := convertType(ty)
 		if err != nil {
 			return nil, err
 		}
 
-		if entitledType != nil {
-			return interpreter.NewTypeValue(inter, entitledType), nil
+		if convertedType != nil {
+			return interpreter.NewTypeValue(inter, convertedType), nil
 		}
 
 	case *interpreter.AccountCapabilityControllerValue:
 		borrowType := v.BorrowType
 
-		entitledBorrowType, err := m.ConvertToEntitledType(borrowType)
+		convertedBorrowType, err := convertType(borrowType)
 		if err != nil {
 			return nil, err
 		}
 
-		if entitledBorrowType != nil {
+		if convertedBorrowType != nil {
 			return interpreter.NewAccountCapabilityControllerValue(
 				inter,
-				entitledBorrowType.(*interpreter.ReferenceStaticType),
+				convertedBorrowType.(*interpreter.ReferenceStaticType),
 				v.CapabilityID,
 			), nil
 		}
@@ -357,15 +372,15 @@ func (m EntitlementsMigration) ConvertValueToEntitlements(v interpreter.Value) (
 	case *interpreter.StorageCapabilityControllerValue:
 		borrowType := v.BorrowType
 
-		entitledBorrowType, err := m.ConvertToEntitledType(borrowType)
+		convertedBorrowType, err := convertType(borrowType)
 		if err != nil {
 			return nil, err
 		}
 
-		if entitledBorrowType != nil {
+		if convertedBorrowType != nil {
 			return interpreter.NewStorageCapabilityControllerValue(
 				inter,
-				entitledBorrowType.(*interpreter.ReferenceStaticType),
+				convertedBorrowType.(*interpreter.ReferenceStaticType),
 				v.CapabilityID,
 				v.TargetPath,
 			), nil
@@ -374,15 +389,15 @@ func (m EntitlementsMigration) ConvertValueToEntitlements(v interpreter.Value) (
 	case interpreter.PathLinkValue: //nolint:staticcheck
 		borrowType := v.Type
 
-		entitledBorrowType, err := m.ConvertToEntitledType(borrowType)
+		convertedBorrowType, err := convertType(borrowType)
 		if err != nil {
 			return nil, err
 		}
 
-		if entitledBorrowType != nil {
+		if convertedBorrowType != nil {
 			return interpreter.PathLinkValue{ //nolint:staticcheck
 				TargetPath: v.TargetPath,
-				Type:       entitledBorrowType,
+				Type:       convertedBorrowType,
 			}, nil
 		}
 	}
@@ -0,0 +1,223 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entitlements
+
+import (
+	"github.com/onflow/cadence/migrations"
+	"github.com/onflow/cadence/migrations/statictypes"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// EntitlementRemovalMigration removes the given entitlements
+// from the authorizations of stored reference types.
+type EntitlementRemovalMigration struct {
+	removedEntitlements map[common.TypeID]struct{}
+}
+
+var _ migrations.ValueMigration = EntitlementRemovalMigration{}
+
+func NewEntitlementRemovalMigration(removedEntitlements []common.TypeID) EntitlementRemovalMigration {
+	removedEntitlementSet := make(map[common.TypeID]struct{}, len(removedEntitlements))
+	for _, entitlement := range removedEntitlements {
+		removedEntitlementSet[entitlement] = struct{}{}
+	}
+
+	return EntitlementRemovalMigration{
+		removedEntitlements: removedEntitlementSet,
+	}
+}
+
+func (EntitlementRemovalMigration) Name() string {
+	return "EntitlementRemovalMigration"
+}
+
+func (EntitlementRemovalMigration) Domains() map[string]struct{} {
+	return nil
+}
+
+// ConvertType removes the entitlements from the given type according to the following rules:
+//   - ConvertType(auth(E) &T)        --> auth(E - Removed) &ConvertType(T), or &ConvertType(T) if no entitlements remain
+//   - ConvertType(Capability<T>)     --> Capability<ConvertType(T)>
+//   - ConvertType(T?)                --> ConvertType(T)?
+//   - ConvertType([T])               --> [ConvertType(T)]
+//   - ConvertType([T; N])            --> [ConvertType(T); N]
+//   - ConvertType({K: V})            --> {ConvertType(K): ConvertType(V)}
+//   - ConvertType(T)                 --> T
+//
+// It returns nil if the given type does not need to be converted.
+func (m EntitlementRemovalMigration) ConvertType(
+	staticType interpreter.StaticType,
+) (
+	interpreter.StaticType,
+	error,
+) {
+	switch t := staticType.(type) {
+	case *interpreter.ReferenceStaticType:
+
+		referencedType := t.ReferencedType
+
+		convertedReferencedType, err := m.ConvertType(referencedType)
+		if err != nil {
+			return nil, err
+		}
+
+		var returnNew bool
+
+		if convertedReferencedType != nil {
+			referencedType = convertedReferencedType
+			returnNew = true
+		}
+
+		auth := t.Authorization
+
+		convertedAuth := m.convertAuthorization(auth)
+		if convertedAuth != nil {
+			auth = convertedAuth
+			returnNew = true
+		}
+
+		if returnNew {
+			return interpreter.NewReferenceStaticType(nil, auth, referencedType), nil
+		}
+
+	case *interpreter.CapabilityStaticType:
+		convertedBorrowType, err := m.ConvertType(t.BorrowType)
+		if err != nil {
+			return nil, err
+		}
+
+		if convertedBorrowType != nil {
+			return interpreter.NewCapabilityStaticType(nil, convertedBorrowType), nil
+		}
+
+	case *interpreter.VariableSizedStaticType:
+		convertedElementType, err := m.ConvertType(t.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if convertedElementType != nil {
+			return interpreter.NewVariableSizedStaticType(nil, convertedElementType), nil
+		}
+
+	case *interpreter.ConstantSizedStaticType:
+		convertedElementType, err := m.ConvertType(t.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if convertedElementType != nil {
+			return interpreter.NewConstantSizedStaticType(nil, convertedElementType, t.Size), nil
+		}
+
+	case *interpreter.DictionaryStaticType:
+		keyType := t.KeyType
+
+		convertedKeyType, err := m.ConvertType(keyType)
+		if err != nil {
+			return nil, err
+		}
+
+		valueType := t.ValueType
+
+		convertedValueType, err := m.ConvertType(valueType)
+		if err != nil {
+			return nil, err
+		}
+
+		if convertedKeyType != nil || convertedValueType != nil {
+			if convertedKeyType != nil {
+				keyType = convertedKeyType
+			}
+			if convertedValueType != nil {
+				valueType = convertedValueType
+			}
+
+			return interpreter.NewDictionaryStaticType(nil, keyType, valueType), nil
+		}
+
+	case *interpreter.OptionalStaticType:
+		convertedInnerType, err := m.ConvertType(t.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if convertedInnerType != nil {
+			return interpreter.NewOptionalStaticType(nil, convertedInnerType), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// convertAuthorization removes the entitlements from the given authorization.
+// If no entitlements remain, the authorization is downgraded to unauthorized.
+// It returns nil if the given authorization does not need to be converted.
+func (m EntitlementRemovalMigration) convertAuthorization(auth interpreter.Authorization) interpreter.Authorization {
+	entitlementSetAuth, ok := auth.(interpreter.EntitlementSetAuthorization)
+	if !ok {
+		return nil
+	}
+
+	var remainingEntitlements []common.TypeID
+	removed := false
+
+	entitlementSetAuth.Entitlements.Foreach(func(entitlement common.TypeID, _ struct{}) {
+		if _, ok := m.removedEntitlements[entitlement]; ok {
+			removed = true
+			return
+		}
+		remainingEntitlements = append(remainingEntitlements, entitlement)
+	})
+
+	if !removed {
+		return nil
+	}
+
+	if len(remainingEntitlements) == 0 {
+		return interpreter.UnauthorizedAccess
+	}
+
+	return interpreter.NewEntitlementSetAuthorization(
+		nil,
+		func() []common.TypeID {
+			return remainingEntitlements
+		},
+		len(remainingEntitlements),
+		entitlementSetAuth.SetKind,
+	)
+}
+
+func (m EntitlementRemovalMigration) Migrate(
+	_ interpreter.StorageKey,
+	_ interpreter.StorageMapKey,
+	value interpreter.Value,
+	inter *interpreter.Interpreter,
+	_ migrations.ValueMigrationPosition,
+) (
+	interpreter.Value,
+	error,
+) {
+	return convertValueStaticTypes(inter, value, m.ConvertType)
+}
+
+func (m EntitlementRemovalMigration) CanSkip(valueType interpreter.StaticType) bool {
+	return statictypes.CanSkipStaticTypeMigration(valueType)
+}
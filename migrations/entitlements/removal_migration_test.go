@@ -0,0 +1,191 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entitlements
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+	. "github.com/onflow/cadence/runtime/tests/runtime_utils"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestEntitlementRemovalMigration(t *testing.T) {
+
+	t.Parallel()
+
+	const (
+		entitlementE common.TypeID = "S.test.E"
+		entitlementF common.TypeID = "S.test.F"
+		entitlementG common.TypeID = "S.test.G"
+	)
+
+	newAuth := func(kind sema.EntitlementSetKind, entitlements ...common.TypeID) interpreter.Authorization {
+		return interpreter.NewEntitlementSetAuthorization(
+			nil,
+			func() []common.TypeID {
+				return entitlements
+			},
+			len(entitlements),
+			kind,
+		)
+	}
+
+	compositeType := interpreter.NewCompositeStaticTypeComputeTypeID(
+		nil,
+		utils.TestLocation,
+		"S",
+	)
+
+	newReferenceType := func(auth interpreter.Authorization) *interpreter.ReferenceStaticType {
+		return interpreter.NewReferenceStaticType(nil, auth, compositeType)
+	}
+
+	migration := NewEntitlementRemovalMigration([]common.TypeID{entitlementE})
+
+	type testCase struct {
+		name     string
+		input    interpreter.StaticType
+		expected interpreter.StaticType
+	}
+
+	testCases := []testCase{
+		{
+			name:     "remove one of several entitlements",
+			input:    newReferenceType(newAuth(sema.Conjunction, entitlementE, entitlementF, entitlementG)),
+			expected: newReferenceType(newAuth(sema.Conjunction, entitlementF, entitlementG)),
+		},
+		{
+			name:     "remove one of several disjunctive entitlements",
+			input:    newReferenceType(newAuth(sema.Disjunction, entitlementE, entitlementF)),
+			expected: newReferenceType(newAuth(sema.Disjunction, entitlementF)),
+		},
+		{
+			name:     "remove only entitlement",
+			input:    newReferenceType(newAuth(sema.Conjunction, entitlementE)),
+			expected: newReferenceType(interpreter.UnauthorizedAccess),
+		},
+		{
+			name:     "no removed entitlement",
+			input:    newReferenceType(newAuth(sema.Conjunction, entitlementF)),
+			expected: nil,
+		},
+		{
+			name:     "unauthorized",
+			input:    newReferenceType(interpreter.UnauthorizedAccess),
+			expected: nil,
+		},
+		{
+			name: "capability",
+			input: interpreter.NewCapabilityStaticType(
+				nil,
+				newReferenceType(newAuth(sema.Conjunction, entitlementE)),
+			),
+			expected: interpreter.NewCapabilityStaticType(
+				nil,
+				newReferenceType(interpreter.UnauthorizedAccess),
+			),
+		},
+		{
+			name: "optional",
+			input: interpreter.NewOptionalStaticType(
+				nil,
+				newReferenceType(newAuth(sema.Conjunction, entitlementE, entitlementF)),
+			),
+			expected: interpreter.NewOptionalStaticType(
+				nil,
+				newReferenceType(newAuth(sema.Conjunction, entitlementF)),
+			),
+		},
+		{
+			name: "dictionary",
+			input: interpreter.NewDictionaryStaticType(
+				nil,
+				interpreter.PrimitiveStaticTypeString,
+				newReferenceType(newAuth(sema.Conjunction, entitlementE)),
+			),
+			expected: interpreter.NewDictionaryStaticType(
+				nil,
+				interpreter.PrimitiveStaticTypeString,
+				newReferenceType(interpreter.UnauthorizedAccess),
+			),
+		},
+		{
+			name:     "composite",
+			input:    compositeType,
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := migration.ConvertType(testCase.input)
+			require.NoError(t, err)
+
+			if testCase.expected == nil {
+				assert.Nil(t, result)
+			} else {
+				require.NotNil(t, result)
+				assert.True(t,
+					testCase.expected.Equal(result),
+					"expected %s, got %s",
+					testCase.expected,
+					result,
+				)
+			}
+		})
+	}
+
+	t.Run("capability value", func(t *testing.T) {
+		t.Parallel()
+
+		inter := NewTestInterpreter(t)
+
+		value := interpreter.NewUnmeteredCapabilityValue(
+			1,
+			interpreter.AddressValue(common.MustBytesToAddress([]byte{0x1})),
+			newReferenceType(newAuth(sema.Conjunction, entitlementE, entitlementF)),
+		)
+
+		result, err := migration.Migrate(
+			interpreter.StorageKey{},
+			interpreter.StorageMapKey(nil),
+			value,
+			inter,
+			0,
+		)
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.IDCapabilityValue{}, result)
+		capabilityValue := result.(*interpreter.IDCapabilityValue)
+
+		assert.Equal(t, value.ID, capabilityValue.ID)
+		assert.True(t,
+			newReferenceType(newAuth(sema.Conjunction, entitlementF)).
+				Equal(capabilityValue.BorrowType),
+		)
+	})
+}
@@ -20,6 +20,7 @@ package common
 
 import (
 	"math/big"
+	"math/bits"
 
 	"github.com/onflow/cadence/runtime/errors"
 )
@@ -49,15 +50,7 @@ const bigIntWordBitSize = BigIntWordSize * 8
 // OverEstimateBigIntFromString is an approximate inverse of `interpreter.OverEstimateBigIntStringLength`.
 // Returns the estimated size in bytes.
 func OverEstimateBigIntFromString(s string, literalKind IntegerLiteralKind) int {
-	leadingZeros := 0
-	for _, char := range s {
-		if char != '0' {
-			break
-		}
-		leadingZeros += 1
-	}
-
-	l := len(s) - leadingZeros
+	l := significantDigitCount(s)
 
 	// An integer `v` requires `ceiling(log_b(v))` digits in base `b`,
 	// and `ceiling(log_2(v))` digits in base `2`.
@@ -86,6 +79,34 @@ func OverEstimateBigIntFromString(s string, literalKind IntegerLiteralKind) int
 		panic(errors.NewUnreachableError())
 	}
 
+	return overEstimateBigIntByteLength(bitLen)
+}
+
+// OverEstimateBigIntFromStringWithRadix is like `OverEstimateBigIntFromString`,
+// but for a string of digits in the given radix, which must be in the range 2 to 36.
+// Returns the estimated size in bytes.
+func OverEstimateBigIntFromStringWithRadix(s string, radix int) int {
+	l := significantDigitCount(s)
+
+	// Each digit requires at most `ceiling(log_2(radix))` bits
+	bitLen := l * bits.Len(uint(radix-1))
+
+	return overEstimateBigIntByteLength(bitLen)
+}
+
+func significantDigitCount(s string) int {
+	leadingZeros := 0
+	for _, char := range s {
+		if char != '0' {
+			break
+		}
+		leadingZeros += 1
+	}
+
+	return len(s) - leadingZeros
+}
+
+func overEstimateBigIntByteLength(bitLen int) int {
 	// Calculate amount of bytes.
 	// First convert to word, and then find the size,
 	// to be consistent with `common.BigIntByteLength`
@@ -27,12 +27,17 @@ type Config struct {
 	Debugger *interpreter.Debugger
 	// StackDepthLimit specifies the maximum depth for call stacks
 	StackDepthLimit uint64
+	// ContainerSizeLimit specifies the maximum number of elements of arrays and dictionaries.
+	// Zero means no limit
+	ContainerSizeLimit uint64
 	// AtreeValidationEnabled configures if atree validation is enabled
 	AtreeValidationEnabled bool
 	// TracingEnabled configures if tracing is enabled
 	TracingEnabled bool
 	// ResourceOwnerChangeCallbackEnabled configures if the resource owner change callback is enabled
 	ResourceOwnerChangeHandlerEnabled bool
+	// AccountAccessHandlerEnabled configures if the account access callback is enabled
+	AccountAccessHandlerEnabled bool
 	// CoverageReport enables and collects coverage reporting metrics
 	CoverageReport *CoverageReport
 	// AttachmentsEnabled specifies if attachments are enabled
@@ -1870,6 +1870,101 @@ func TestRuntimeCoverageWithNoStatements(t *testing.T) {
 	require.JSONEq(t, expected, string(actual))
 }
 
+func TestRuntimeCoverageWithUnreachableBranch(t *testing.T) {
+
+	t.Parallel()
+
+	importedScript := []byte(`
+	  access(all) fun sign(_ n: Int): Int {
+	    if n < 0 {
+	      return -1
+	    }
+	    if n > 0 {
+	      return 1
+	    }
+	    return 0
+	  }
+	`)
+
+	script := []byte(`
+	  import "imported"
+
+	  access(all) fun main(): Int {
+	    return sign(5)
+	  }
+	`)
+
+	coverageReport := NewCoverageReport()
+	runtimeInterface := &TestRuntimeInterface{
+		OnGetCode: func(location Location) (bytes []byte, err error) {
+			switch location {
+			case common.StringLocation("imported"):
+				return importedScript, nil
+			default:
+				return nil, fmt.Errorf("unknown import location: %s", location)
+			}
+		},
+	}
+
+	config := DefaultTestInterpreterConfig
+	config.CoverageReport = coverageReport
+	runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+	scriptLocation := common.ScriptLocation{}
+	coverageReport.ExcludeLocation(scriptLocation)
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface:      runtimeInterface,
+			Location:       scriptLocation,
+			CoverageReport: coverageReport,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.NewInt(1), value)
+
+	// The branches for negative numbers and zero are not reached,
+	// so their return statements are reported as missed
+
+	locationCoverage := coverageReport.Coverage[common.StringLocation("imported")]
+	require.NotNil(t, locationCoverage)
+	assert.Equal(t, []int{4, 9}, locationCoverage.MissedLines())
+
+	actual, err := json.Marshal(coverageReport)
+	require.NoError(t, err)
+
+	expected := `
+	  {
+	    "coverage": {
+	      "S.imported": {
+	        "line_hits": {
+	          "3": 1,
+	          "4": 0,
+	          "6": 1,
+	          "7": 1,
+	          "9": 0
+	        },
+	        "missed_lines": [4, 9],
+	        "statements": 5,
+	        "percentage": "60.0%"
+	      }
+	    },
+	    "excluded_locations": ["s.0000000000000000000000000000000000000000000000000000000000000000"]
+	  }
+	`
+	require.JSONEq(t, expected, string(actual))
+
+	assert.Equal(
+		t,
+		"Coverage: 60.0% of statements",
+		coverageReport.String(),
+	)
+}
+
 func TestRuntimeCoverageReportLCOVFormat(t *testing.T) {
 
 	t.Parallel()
@@ -232,6 +232,13 @@ func (EmptyRuntimeInterface) ResourceOwnerChanged(
 	panic("unexpected call to ResourceOwnerChanged")
 }
 
+func (EmptyRuntimeInterface) AccountAccessed(
+	_ *interpreter.Interpreter,
+	_ common.Address,
+) {
+	panic("unexpected call to AccountAccessed")
+}
+
 func (EmptyRuntimeInterface) GenerateAccountID(_ common.Address) (uint64, error) {
 	panic("unexpected call to GenerateAccountID")
 }
@@ -172,11 +172,13 @@ func (e *interpreterEnvironment) newInterpreterConfig() *interpreter.Config {
 		OnEventEmitted:                 e.newOnEventEmittedHandler(),
 		InjectedCompositeFieldsHandler: e.newInjectedCompositeFieldsHandler(),
 		UUIDHandler:                    e.newUUIDHandler(),
+		RandomHandler:                  e.newRandomHandler(),
 		ContractValueHandler:           e.newContractValueHandler(),
 		ImportLocationHandler:          e.newImportLocationHandler(),
 		AccountHandler:                 e.NewAccountValue,
 		OnRecordTrace:                  e.newOnRecordTraceHandler(),
 		OnResourceOwnerChange:          e.newResourceOwnerChangedHandler(),
+		OnAccountAccessed:              e.newAccountAccessedHandler(),
 		CompositeTypeHandler:           e.newCompositeTypeHandler(),
 		CompositeValueFunctionsHandler: e.newCompositeValueFunctionsHandler(),
 		TracingEnabled:                 e.config.TracingEnabled,
@@ -197,6 +199,7 @@ func (e *interpreterEnvironment) newInterpreterConfig() *interpreter.Config {
 		ContractUpdateTypeRemovalEnabled:          e.config.ContractUpdateTypeRemovalEnabled,
 		ValidateAccountCapabilitiesGetHandler:     e.newValidateAccountCapabilitiesGetHandler(),
 		ValidateAccountCapabilitiesPublishHandler: e.newValidateAccountCapabilitiesPublishHandler(),
+		ContainerSizeLimit:                        e.config.ContainerSizeLimit,
 	}
 }
 
@@ -979,6 +982,18 @@ func (e *interpreterEnvironment) newUUIDHandler() interpreter.UUIDHandlerFunc {
 	}
 }
 
+func (e *interpreterEnvironment) newRandomHandler() interpreter.RandomHandlerFunc {
+	return func(buffer []byte) (err error) {
+		errors.WrapPanic(func() {
+			err = e.runtimeInterface.ReadRandom(buffer)
+		})
+		if err != nil {
+			err = interpreter.WrappedExternalError(err)
+		}
+		return
+	}
+}
+
 func (e *interpreterEnvironment) newOnEventEmittedHandler() interpreter.OnEventEmittedFunc {
 	return func(
 		inter *interpreter.Interpreter,
@@ -1278,6 +1293,24 @@ func (e *interpreterEnvironment) newResourceOwnerChangedHandler() interpreter.On
 	}
 }
 
+func (e *interpreterEnvironment) newAccountAccessedHandler() interpreter.OnAccountAccessedFunc {
+	if !e.config.AccountAccessHandlerEnabled {
+		return nil
+	}
+
+	return func(
+		interpreter *interpreter.Interpreter,
+		address common.Address,
+	) {
+		errors.WrapPanic(func() {
+			e.runtimeInterface.AccountAccessed(
+				interpreter,
+				address,
+			)
+		})
+	}
+}
+
 func (e *interpreterEnvironment) CommitStorage(inter *interpreter.Interpreter) error {
 	const commitContractUpdates = true
 	err := e.storage.Commit(inter, commitContractUpdates)
@@ -143,6 +143,11 @@ type Interface interface {
 		oldOwner common.Address,
 		newOwner common.Address,
 	)
+	// AccountAccessed gets called when an account is accessed (if enabled)
+	AccountAccessed(
+		interpreter *interpreter.Interpreter,
+		address common.Address,
+	)
 	// GenerateAccountID generates a new, *non-zero*, unique ID for the given account.
 	GenerateAccountID(address common.Address) (uint64, error)
 	RecoverProgram(program *ast.Program, location common.Location) ([]byte, error)
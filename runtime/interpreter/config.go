@@ -19,6 +19,8 @@
 package interpreter
 
 import (
+	"context"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -33,6 +35,8 @@ type Config struct {
 	OnRecordTrace OnRecordTraceFunc
 	// OnResourceOwnerChange is triggered when the owner of a resource changes
 	OnResourceOwnerChange OnResourceOwnerChangeFunc
+	// OnAccountAccessed is triggered when an account is accessed
+	OnAccountAccessed OnAccountAccessedFunc
 	// OnMeterComputation is triggered when a computation is about to happen
 	OnMeterComputation OnMeterComputationFunc
 	// InjectedCompositeFieldsHandler is used to initialize new composite values' fields
@@ -47,6 +51,11 @@ type Config struct {
 	AccountHandler AccountHandlerFunc
 	// UUIDHandler is used to handle the generation of UUIDs
 	UUIDHandler UUIDHandlerFunc
+	// RandomHandler is used to read pseudo-random bytes, e.g. for shuffling arrays
+	RandomHandler RandomHandlerFunc
+	// ContainerSizeLimit is the maximum number of elements of arrays and dictionaries.
+	// Exceeding the limit is reported as a ContainerSizeLimitError. Zero means no limit
+	ContainerSizeLimit uint64
 	// CompositeTypeHandler is used to load composite types
 	CompositeTypeHandler CompositeTypeHandlerFunc
 	// InterfaceTypeHandler is used to load interface types
@@ -59,6 +68,13 @@ type Config struct {
 	OnStatement OnStatementFunc
 	// OnLoopIteration is triggered when a loop iteration is about to be executed
 	OnLoopIteration OnLoopIterationFunc
+	// CancellationContext is checked before each loop iteration.
+	// Once it is done, the execution is interrupted with an ExecutionCancelledError.
+	// No cancellation checks are performed if it is nil
+	CancellationContext context.Context
+	// OnResourceLeak is triggered when a function returns while a resource of one of its variables
+	// was neither moved nor destroyed. Resources are only tracked if it is set
+	OnResourceLeak OnResourceLeakFunc
 	// TracingEnabled determines if tracing is enabled.
 	// Tracing reports certain operations, e.g. composite value transfers
 	TracingEnabled bool
@@ -353,13 +353,41 @@ func (e ForceNilError) Error() string {
 	return "unexpectedly found nil while forcing an Optional value"
 }
 
+// UnwrapNilError is reported when an optional is unwrapped
+// using the `unwrap` function, but the optional is nil
+type UnwrapNilError struct {
+	Message string
+	LocationRange
+}
+
+var _ errors.UserError = UnwrapNilError{}
+
+func (UnwrapNilError) IsUserError() {}
+
+func (e UnwrapNilError) Error() string {
+	return fmt.Sprintf(
+		"unexpectedly found nil while unwrapping an Optional value: %s",
+		e.Message,
+	)
+}
+
 // ForceCastTypeMismatchError
 type ForceCastTypeMismatchError struct {
 	ExpectedType sema.Type
 	ActualType   sema.Type
+	// MismatchedElement is the first element of the cast array
+	// which is not of the expected element type, if any
+	MismatchedElement *ArrayElementTypeMismatch
 	LocationRange
 }
 
+// ArrayElementTypeMismatch describes an element of an array
+// which is not of the element type of the expected array type
+type ArrayElementTypeMismatch struct {
+	Index int
+	Type  sema.Type
+}
+
 var _ errors.UserError = ForceCastTypeMismatchError{}
 
 func (ForceCastTypeMismatchError) IsUserError() {}
@@ -370,11 +398,21 @@ func (e ForceCastTypeMismatchError) Error() string {
 		e.ActualType,
 	)
 
-	return fmt.Sprintf(
+	message := fmt.Sprintf(
 		"failed to force-cast value: expected type `%s`, got `%s`",
 		expected,
 		actual,
 	)
+
+	if e.MismatchedElement != nil {
+		message += fmt.Sprintf(
+			": element at index %d has type `%s`",
+			e.MismatchedElement.Index,
+			e.MismatchedElement.Type.QualifiedString(),
+		)
+	}
+
+	return message
 }
 
 // TypeMismatchError
@@ -480,6 +518,9 @@ type ArrayIndexOutOfBoundsError struct {
 	LocationRange
 	Index int
 	Size  int
+	// Insertion is true if the index is the index of an insertion,
+	// for which the size of the array is a valid index
+	Insertion bool
 }
 
 var _ errors.UserError = ArrayIndexOutOfBoundsError{}
@@ -487,6 +528,16 @@ var _ errors.UserError = ArrayIndexOutOfBoundsError{}
 func (ArrayIndexOutOfBoundsError) IsUserError() {}
 
 func (e ArrayIndexOutOfBoundsError) Error() string {
+	if e.Insertion {
+		return fmt.Sprintf(
+			"array index out of bounds for insertion: %d, but length is %d: "+
+				"expected an index in the range 0 to %d",
+			e.Index,
+			e.Size,
+			e.Size,
+		)
+	}
+
 	return fmt.Sprintf(
 		"array index out of bounds: %d, but size is %d",
 		e.Index,
@@ -593,6 +644,19 @@ func (e UUIDUnavailableError) Error() string {
 	return "cannot get UUID: UUID access is unavailable in this configuration of Cadence"
 }
 
+// RandomUnavailableError
+type RandomUnavailableError struct {
+	LocationRange
+}
+
+var _ errors.UserError = RandomUnavailableError{}
+
+func (RandomUnavailableError) IsUserError() {}
+
+func (e RandomUnavailableError) Error() string {
+	return "cannot get random bytes: randomness is unavailable in this configuration of Cadence"
+}
+
 // TypeLoadingError
 type TypeLoadingError struct {
 	TypeID TypeID
@@ -1145,3 +1209,99 @@ func (GetCapabilityError) IsUserError() {}
 func (e GetCapabilityError) Error() string {
 	return "cannot get capability"
 }
+
+// InvalidUnicodeScalarError is reported when a character is created
+// from a value which is not a valid Unicode scalar value
+type InvalidUnicodeScalarError struct {
+	LocationRange
+	Scalar uint32
+}
+
+var _ errors.UserError = InvalidUnicodeScalarError{}
+
+func (InvalidUnicodeScalarError) IsUserError() {}
+
+func (e InvalidUnicodeScalarError) Error() string {
+	return fmt.Sprintf(
+		"invalid Unicode scalar value: 0x%X",
+		e.Scalar,
+	)
+}
+
+// ContainerSizeLimitError is reported when an array or a dictionary
+// would have more elements than the configured container size limit
+type ContainerSizeLimitError struct {
+	LocationRange
+	Limit uint64
+	Size  uint64
+}
+
+var _ errors.UserError = ContainerSizeLimitError{}
+
+func (ContainerSizeLimitError) IsUserError() {}
+
+func (e ContainerSizeLimitError) Error() string {
+	return fmt.Sprintf(
+		"container size limit exceeded: %d elements, but the limit is %d",
+		e.Size,
+		e.Limit,
+	)
+}
+
+// InvalidRadixError is reported when a number is parsed from a string
+// with a radix outside of the range 2 to 36
+type InvalidRadixError struct {
+	LocationRange
+	Radix string
+}
+
+var _ errors.UserError = InvalidRadixError{}
+
+func (InvalidRadixError) IsUserError() {}
+
+func (e InvalidRadixError) Error() string {
+	return fmt.Sprintf(
+		"invalid radix %s: expected a radix in the range 2 to 36",
+		e.Radix,
+	)
+}
+
+// StringFormatArgumentCountError is reported when the number of placeholders
+// in the template of `String.format` does not match the number of arguments
+type StringFormatArgumentCountError struct {
+	LocationRange
+	PlaceholderCount int
+	ArgumentCount    int
+}
+
+var _ errors.UserError = StringFormatArgumentCountError{}
+
+func (StringFormatArgumentCountError) IsUserError() {}
+
+func (e StringFormatArgumentCountError) Error() string {
+	return fmt.Sprintf(
+		"invalid number of format arguments: expected %d, got %d",
+		e.PlaceholderCount,
+		e.ArgumentCount,
+	)
+}
+
+// ExecutionCancelledError is reported when the execution is interrupted
+// because the cancellation context of the interpreter is done,
+// e.g. because a timeout expired
+type ExecutionCancelledError struct {
+	Err error
+	LocationRange
+}
+
+var _ errors.UserError = ExecutionCancelledError{}
+
+func (ExecutionCancelledError) IsUserError() {}
+
+func (e ExecutionCancelledError) Error() string {
+	return fmt.Sprintf("execution cancelled: %s", e.Err)
+}
+
+func (e ExecutionCancelledError) Unwrap() error {
+	return e.Err
+}
@@ -75,6 +75,16 @@ type OnLoopIterationFunc func(
 	line int,
 )
 
+// OnResourceLeakFunc is a function that is triggered when a function returned,
+// but the resource of the variable with the given identifier was neither moved nor destroyed.
+// The location range is the one of the function invocation.
+type OnResourceLeakFunc func(
+	inter *Interpreter,
+	locationRange LocationRange,
+	resource ResourceKindedValue,
+	identifier string,
+)
+
 // OnFunctionInvocationFunc is a function that is triggered when a function is about to be invoked.
 type OnFunctionInvocationFunc func(inter *Interpreter)
 
@@ -97,6 +107,13 @@ type OnResourceOwnerChangeFunc func(
 	newOwner common.Address,
 )
 
+// OnAccountAccessedFunc is a function that is triggered when an account value is created,
+// i.e. when the program accesses the account with the given address.
+type OnAccountAccessedFunc func(
+	inter *Interpreter,
+	address common.Address,
+)
+
 // OnMeterComputationFunc is a function that is called when some computation is about to happen.
 // intensity captures the intensity of the computation and can be set using input sizes
 // complexity of computation given input sizes, or any other factors that could help the upper levels
@@ -177,6 +194,9 @@ type ValidateAccountCapabilitiesPublishHandlerFunc func(
 // UUIDHandlerFunc is a function that handles the generation of UUIDs.
 type UUIDHandlerFunc func() (uint64, error)
 
+// RandomHandlerFunc is a function that reads pseudo-random bytes into the given buffer.
+type RandomHandlerFunc func(buffer []byte) error
+
 // CompositeTypeHandlerFunc is a function that loads composite types.
 type CompositeTypeHandlerFunc func(location common.Location, typeID TypeID) *sema.CompositeType
 
@@ -789,8 +809,14 @@ func (interpreter *Interpreter) visitFunctionBody(
 	interpreter.activations.PushNewWithCurrent()
 	defer interpreter.activations.Pop()
 
+	if interpreter.SharedState.Config.OnResourceLeak != nil {
+		interpreter.pushResourceLeakFrame()
+		defer interpreter.popResourceLeakFrame()
+	}
+
 	result := interpreter.visitStatements(beforeStatements)
 	if result, ok := result.(ReturnResult); ok {
+		interpreter.reportResourceLeaks(declarationLocationRange)
 		return result.Value
 	}
 
@@ -821,9 +847,64 @@ func (interpreter *Interpreter) visitFunctionBody(
 
 	interpreter.visitConditions(postConditions, ast.ConditionKindPost)
 
+	interpreter.reportResourceLeaks(declarationLocationRange)
+
 	return returnValue
 }
 
+// resourceLeakFrame records the resource variables declared during a function invocation,
+// so leaked resources can be reported when the function returns
+type resourceLeakFrame struct {
+	variables []trackedResourceVariable
+}
+
+type trackedResourceVariable struct {
+	resource   ResourceKindedValue
+	variable   Variable
+	identifier string
+}
+
+func (interpreter *Interpreter) pushResourceLeakFrame() {
+	sharedState := interpreter.SharedState
+	sharedState.resourceLeakFrames = append(sharedState.resourceLeakFrames, &resourceLeakFrame{})
+}
+
+func (interpreter *Interpreter) popResourceLeakFrame() {
+	sharedState := interpreter.SharedState
+	sharedState.resourceLeakFrames = sharedState.resourceLeakFrames[:len(sharedState.resourceLeakFrames)-1]
+}
+
+func (interpreter *Interpreter) currentResourceLeakFrame() *resourceLeakFrame {
+	frames := interpreter.SharedState.resourceLeakFrames
+	if len(frames) == 0 {
+		return nil
+	}
+	return frames[len(frames)-1]
+}
+
+// reportResourceLeaks reports the resources of variables declared in the current function invocation
+// which are still associated with their variable, i.e. which were neither moved nor destroyed
+func (interpreter *Interpreter) reportResourceLeaks(locationRange LocationRange) {
+	onResourceLeak := interpreter.SharedState.Config.OnResourceLeak
+	if onResourceLeak == nil {
+		return
+	}
+
+	frame := interpreter.currentResourceLeakFrame()
+	if frame == nil {
+		return
+	}
+
+	for _, tracked := range frame.variables {
+		variable, ok := interpreter.SharedState.resourceVariables[tracked.resource]
+		if !ok || variable != tracked.variable {
+			continue
+		}
+
+		onResourceLeak(interpreter, locationRange, tracked.resource, tracked.identifier)
+	}
+}
+
 // resultValue returns the value for the `result` constant.
 // If the return type is not a resource:
 //   - The constant has the same type as the return type.
@@ -1595,6 +1676,16 @@ func (interpreter *Interpreter) declareEnumConstructor(
 		HasPosition: declaration,
 	}
 
+	// Cases take precedence over the built-in function, like in the checker
+
+	if _, ok := constructorNestedVariables[sema.EnumConstructorFromCaseNameFunctionName]; !ok {
+		constructorNestedVariables[sema.EnumConstructorFromCaseNameFunctionName] =
+			NewVariableWithValue(
+				interpreter,
+				enumFromCaseNameFunction(interpreter, compositeType, caseValues),
+			)
+	}
+
 	value := EnumConstructorFunction(
 		interpreter,
 		locationRange,
@@ -1611,6 +1702,39 @@ func (interpreter *Interpreter) declareEnumConstructor(
 	return lexicalScope, variable
 }
 
+// enumFromCaseNameFunction returns the function which looks up the case of the enum by name.
+// The given cases are in declaration order, like the case names of the enum type
+func enumFromCaseNameFunction(
+	gauge common.MemoryGauge,
+	enumType *sema.CompositeType,
+	cases []EnumCase,
+) *HostFunctionValue {
+
+	lookupTable := make(map[string]Value, len(cases))
+
+	for i, c := range cases {
+		lookupTable[enumType.EnumCases[i]] = c.Value
+	}
+
+	return NewStaticHostFunctionValue(
+		gauge,
+		sema.EnumConstructorFromCaseNameFunctionType(enumType),
+		func(invocation Invocation) Value {
+			name, ok := invocation.Arguments[0].(*StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			caseValue, ok := lookupTable[name.Str]
+			if !ok {
+				return Nil
+			}
+
+			return NewSomeValueNonCopying(invocation.Interpreter, caseValue)
+		},
+	)
+}
+
 func EnumConstructorFunction(
 	gauge common.MemoryGauge,
 	locationRange LocationRange,
@@ -1642,6 +1766,8 @@ func EnumConstructorFunction(
 
 			rawValueArgumentBigEndianBytes := rawValue.ToBigEndianBytes()
 
+			// Raw values which do not match the raw value of any case,
+			// e.g. raw values out of the range of the cases, result in nil
 			caseValue, ok := lookupTable[string(rawValueArgumentBigEndianBytes)]
 			if !ok {
 				return Nil
@@ -2739,6 +2865,67 @@ func newFromStringFunction(ty sema.Type, parser stringValueParser) fromStringFun
 	}
 }
 
+// newIntFromStringFunction returns the function `Int.fromString`,
+// which parses the input in the optionally given radix, instead of always in base 10
+func newIntFromStringFunction() fromStringFunctionValue {
+	functionType := sema.FromStringFunctionType(sema.IntType)
+
+	parseDecimal := bigIntValueParser(func(b *big.Int) (Value, bool) {
+		return NewUnmeteredIntValueFromBigInt(b), true
+	})
+
+	hostFunctionImpl := NewUnmeteredStaticHostFunctionValue(
+		functionType,
+		func(invocation Invocation) Value {
+			argument, ok := invocation.Arguments[0].(*StringValue)
+			if !ok {
+				// expect typechecker to catch a mismatch here
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			input := argument.Str
+
+			if len(invocation.Arguments) < 2 {
+				return parseDecimal(inter, input)
+			}
+
+			radixValue, ok := invocation.Arguments[1].(IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			locationRange := invocation.LocationRange
+
+			if !radixValue.BigInt.IsInt64() ||
+				radixValue.BigInt.Int64() < 2 ||
+				radixValue.BigInt.Int64() > 36 {
+
+				panic(InvalidRadixError{
+					LocationRange: locationRange,
+					Radix:         radixValue.String(),
+				})
+			}
+
+			radix := radixValue.ToInt(locationRange)
+
+			estimatedSize := common.OverEstimateBigIntFromStringWithRadix(input, radix)
+			common.UseMemory(inter, common.NewBigIntMemoryUsage(estimatedSize))
+
+			val, ok := new(big.Int).SetString(input, radix)
+			if !ok {
+				return NilOptionalValue
+			}
+
+			return NewSomeValueNonCopying(inter, NewUnmeteredIntValueFromBigInt(val))
+		},
+	)
+	return fromStringFunctionValue{
+		receiverType: sema.IntType,
+		hostFunction: hostFunctionImpl,
+	}
+}
+
 // default implementation for parsing a given unsigned numeric type from a string.
 // the size provided by sizeInBytes is passed to strconv.ParseUint, ensuring that the parsed value fits in the target type.
 // input strings must not begin with a '+' or '-'.
@@ -2841,9 +3028,7 @@ var fromStringFunctionValues = func() map[string]fromStringFunctionValue {
 			}
 			return
 		})),
-		newFromStringFunction(sema.IntType, bigIntValueParser(func(b *big.Int) (Value, bool) {
-			return NewUnmeteredIntValueFromBigInt(b), true
-		})),
+		newIntFromStringFunction(),
 
 		// unsigned int values from 8 bit -> infinity
 		newFromStringFunction(sema.UInt8Type, unsignedIntValueParser(8, NewUInt8Value, u64_8)),
@@ -3778,6 +3963,7 @@ func defineBaseFunctions(activation *VariableActivation) {
 	defineTypeFunction(activation)
 	defineRuntimeTypeConstructorFunctions(activation)
 	defineStringFunction(activation)
+	defineCharacterFunction(activation)
 }
 
 type converterFunction struct {
@@ -4025,6 +4211,10 @@ func defineStringFunction(activation *VariableActivation) {
 	defineBaseValue(activation, sema.StringType.String(), stringFunction)
 }
 
+func defineCharacterFunction(activation *VariableActivation) {
+	defineBaseValue(activation, sema.CharacterType.String(), characterFunction)
+}
+
 func (interpreter *Interpreter) IsSubType(subType StaticType, superType StaticType) bool {
 	if superType == PrimitiveStaticTypeAny {
 		return true
@@ -4956,6 +5146,29 @@ func (interpreter *Interpreter) reportLoopIteration(pos ast.HasPosition) {
 		line := pos.StartPosition().Line
 		onLoopIteration(interpreter, line)
 	}
+
+	interpreter.checkCancellation(pos)
+}
+
+// checkCancellation interrupts the execution with an ExecutionCancelledError
+// if the cancellation context, if any is configured, is done.
+func (interpreter *Interpreter) checkCancellation(pos ast.HasPosition) {
+	ctx := interpreter.SharedState.Config.CancellationContext
+	if ctx == nil {
+		return
+	}
+
+	select {
+	case <-ctx.Done():
+		panic(ExecutionCancelledError{
+			Err: ctx.Err(),
+			LocationRange: LocationRange{
+				Location:    interpreter.Location,
+				HasPosition: pos,
+			},
+		})
+	default:
+	}
 }
 
 func (interpreter *Interpreter) reportFunctionInvocation() {
@@ -5187,6 +5400,21 @@ func (interpreter *Interpreter) checkContainerMutation(
 	}
 }
 
+// checkContainerSize checks that the given number of elements of an array or dictionary
+// does not exceed the container size limit, if any is configured.
+func (interpreter *Interpreter) checkContainerSize(size uint64, locationRange LocationRange) {
+	limit := interpreter.SharedState.Config.ContainerSizeLimit
+	if limit == 0 || size <= limit {
+		return
+	}
+
+	panic(ContainerSizeLimitError{
+		Limit:         limit,
+		Size:          size,
+		LocationRange: locationRange,
+	})
+}
+
 func (interpreter *Interpreter) RemoveReferencedSlab(storable atree.Storable) {
 	slabIDStorable, ok := storable.(atree.SlabIDStorable)
 	if !ok {
@@ -5475,6 +5703,20 @@ func (interpreter *Interpreter) startResourceTracking(
 	}
 
 	interpreter.SharedState.resourceVariables[resourceKindedValue] = variable
+
+	if interpreter.SharedState.Config.OnResourceLeak != nil {
+		frame := interpreter.currentResourceLeakFrame()
+		if frame != nil {
+			frame.variables = append(
+				frame.variables,
+				trackedResourceVariable{
+					resource:   resourceKindedValue,
+					variable:   variable,
+					identifier: identifier,
+				},
+			)
+		}
+	}
 }
 
 // checkInvalidatedResourceUse checks whether a resource variable is used after invalidation.
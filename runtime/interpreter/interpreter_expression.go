@@ -1374,9 +1374,10 @@ func (interpreter *Interpreter) VisitCastingExpression(expression *ast.CastingEx
 				}
 
 				panic(ForceCastTypeMismatchError{
-					ExpectedType:  expectedType,
-					ActualType:    valueSemaType,
-					LocationRange: locationRange,
+					ExpectedType:      expectedType,
+					ActualType:        valueSemaType,
+					MismatchedElement: interpreter.firstMismatchedArrayElement(value, expectedType),
+					LocationRange:     locationRange,
 				})
 			}
 
@@ -1406,6 +1407,50 @@ func (interpreter *Interpreter) VisitCastingExpression(expression *ast.CastingEx
 	}
 }
 
+// firstMismatchedArrayElement returns the first element of the given value, if it is an array,
+// which is not of the element type of the given type, if it is an array type
+func (interpreter *Interpreter) firstMismatchedArrayElement(
+	value Value,
+	expectedType sema.Type,
+) *ArrayElementTypeMismatch {
+
+	arrayValue, ok := value.(*ArrayValue)
+	if !ok {
+		return nil
+	}
+
+	expectedArrayType, ok := sema.UnwrapOptionalType(expectedType).(sema.ArrayType)
+	if !ok {
+		return nil
+	}
+
+	expectedElementType := expectedArrayType.ElementType(false)
+
+	var mismatch *ArrayElementTypeMismatch
+
+	index := 0
+	arrayValue.Iterate(
+		interpreter,
+		func(element Value) (resume bool) {
+			elementStaticType := element.StaticType(interpreter)
+			if !interpreter.IsSubTypeOfSemaType(elementStaticType, expectedElementType) {
+				mismatch = &ArrayElementTypeMismatch{
+					Index: index,
+					Type:  interpreter.MustConvertStaticToSemaType(elementStaticType),
+				}
+				return false
+			}
+
+			index++
+			return true
+		},
+		false,
+		EmptyLocationRange,
+	)
+
+	return mismatch
+}
+
 func (interpreter *Interpreter) VisitCreateExpression(expression *ast.CreateExpression) Value {
 	return interpreter.evalExpression(expression.InvocationExpression)
 }
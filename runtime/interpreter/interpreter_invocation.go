@@ -224,6 +224,13 @@ func (interpreter *Interpreter) bindParameterArguments(
 	parameterList *ast.ParameterList,
 	arguments []Value,
 ) {
+	if interpreter.SharedState.Config.OnResourceLeak != nil {
+		// The resources of the arguments were not created by the invoked function,
+		// so do not track them for leaks, neither in this function nor in the caller
+		interpreter.pushResourceLeakFrame()
+		defer interpreter.popResourceLeakFrame()
+	}
+
 	for parameterIndex, parameter := range parameterList.Parameters {
 		argument := arguments[parameterIndex]
 		interpreter.declareVariable(parameter.Identifier.Identifier, argument)
@@ -23,6 +23,39 @@ import (
 	"github.com/onflow/cadence/runtime/sema"
 )
 
+// TransactionPhase is the phase of a transaction which is currently executed
+type TransactionPhase uint8
+
+const (
+	TransactionPhaseUnknown TransactionPhase = iota
+	TransactionPhasePrepare
+	TransactionPhasePre
+	TransactionPhaseExecute
+	TransactionPhasePost
+)
+
+func (p TransactionPhase) String() string {
+	switch p {
+	case TransactionPhasePrepare:
+		return "prepare"
+	case TransactionPhasePre:
+		return "pre"
+	case TransactionPhaseExecute:
+		return "execute"
+	case TransactionPhasePost:
+		return "post"
+	default:
+		return "unknown"
+	}
+}
+
+// TransactionPhase returns the phase of the transaction which is currently executed,
+// e.g. to determine in which phase an event is emitted.
+// TransactionPhaseUnknown is returned if no transaction is executed
+func (interpreter *Interpreter) TransactionPhase() TransactionPhase {
+	return interpreter.SharedState.transactionPhase
+}
+
 func (interpreter *Interpreter) VisitTransactionDeclaration(declaration *ast.TransactionDeclaration) StatementResult {
 	interpreter.declareTransactionEntryPoint(declaration)
 
@@ -76,6 +109,11 @@ func (interpreter *Interpreter) declareTransactionEntryPoint(declaration *ast.Tr
 
 	transactionFunction := &HostFunctionValue{
 		Function: func(invocation Invocation) Value {
+			sharedState := interpreter.SharedState
+			defer func(phase TransactionPhase) {
+				sharedState.transactionPhase = phase
+			}(sharedState.transactionPhase)
+
 			interpreter.activations.PushNewWithParent(lexicalScope)
 
 			self := Value(self)
@@ -101,6 +139,8 @@ func (interpreter *Interpreter) declareTransactionEntryPoint(declaration *ast.Tr
 			transactionScope := interpreter.activations.CurrentOrNew()
 
 			if prepareFunction != nil {
+				sharedState.transactionPhase = TransactionPhasePrepare
+
 				prepare := interpreter.functionDeclarationValue(
 					prepareFunction,
 					prepareFunctionType,
@@ -110,23 +150,34 @@ func (interpreter *Interpreter) declareTransactionEntryPoint(declaration *ast.Tr
 				prepare.invoke(invocation)
 			}
 
-			var body func() StatementResult
+			var execute *InterpretedFunctionValue
 			if executeFunction != nil {
-				execute := interpreter.functionDeclarationValue(
+				execute = interpreter.functionDeclarationValue(
 					executeFunction,
 					executeFunctionType,
 					transactionScope,
 				)
+			}
+
+			invocationWithoutArguments := invocation
+			invocationWithoutArguments.Arguments = nil
+
+			// The body is always run, even if there is no execute function,
+			// so the phase is updated before the post-conditions are evaluated
 
-				invocationWithoutArguments := invocation
-				invocationWithoutArguments.Arguments = nil
+			body := func() (result StatementResult) {
+				sharedState.transactionPhase = TransactionPhaseExecute
 
-				body = func() StatementResult {
+				if execute != nil {
 					value := execute.invoke(invocationWithoutArguments)
-					return ReturnResult{
+					result = ReturnResult{
 						Value: value,
 					}
 				}
+
+				sharedState.transactionPhase = TransactionPhasePost
+
+				return
 			}
 
 			var preConditions []ast.Condition
@@ -134,6 +185,8 @@ func (interpreter *Interpreter) declareTransactionEntryPoint(declaration *ast.Tr
 				preConditions = declaration.PreConditions.Conditions
 			}
 
+			sharedState.transactionPhase = TransactionPhasePre
+
 			declarationLocationRange := LocationRange{
 				Location:    interpreter.Location,
 				HasPosition: declaration,
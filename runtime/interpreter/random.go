@@ -0,0 +1,67 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"encoding/binary"
+)
+
+// RandomUint64 returns a uniformly distributed random number in the range [0, modulo),
+// reading random bytes using the given function.
+// The modulo must be greater than zero.
+//
+// The reject-sample method is used to avoid the modulo bias.
+// The function isn't constant-time and may take longer than computing a modular reduction.
+// However, sampling exactly the size of `modulo - 1` in bits makes the loop return fast:
+// the probability of the loop running for (k) iterations is at most (1/2)^k.
+//
+// (a different approach would be to pull 128 bits more bits than the size of `max`
+// from the random generator and use big number reduction by `modulo`)
+func RandomUint64(modulo uint64, readRandom func(buffer []byte)) uint64 {
+
+	// buffer to get random bytes from the generator
+	// 8 is the size of the largest type supported, it is also the size needed for
+	// the `binary.BigEndian.Uint64` call
+	const bufferSize = 8
+	var buffer [bufferSize]byte
+
+	// `max` is the maximum value that can be returned
+	max := modulo - 1
+	// get a bit mask (0b11..11) that covers all `max` bits,
+	// and count the byte size of `max`
+	mask := uint64(0)
+	bitSize := 0
+	for max&mask != max {
+		bitSize++
+		mask = (mask << 1) | 1
+	}
+	byteSize := (bitSize + 7) >> 3
+
+	for {
+		// only generate `byteSize` random bytes
+		readRandom(buffer[bufferSize-byteSize:])
+		// big endianness must be used in this case
+		random := binary.BigEndian.Uint64(buffer[:])
+		// truncate to the bit size of `max`
+		random &= mask
+		if random <= max {
+			return random
+		}
+	}
+}
@@ -39,6 +39,7 @@ type SharedState struct {
 	// TODO: ideally this would be a weak map, but Go has no weak references
 	referencedResourceKindedValues              ReferencedResourceKindedValues
 	resourceVariables                           map[ResourceKindedValue]Variable
+	resourceLeakFrames                          []*resourceLeakFrame
 	inStorageIteration                          bool
 	storageMutatedDuringIteration               bool
 	CapabilityControllerIterations              map[AddressPath]int
@@ -46,6 +47,7 @@ type SharedState struct {
 	containerValueIteration                     map[atree.ValueID]struct{}
 	destroyedResources                          map[atree.ValueID]struct{}
 	currentEntitlementMappedValue               Authorization
+	transactionPhase                            TransactionPhase
 }
 
 func NewSharedState(config *Config) *SharedState {
@@ -20,6 +20,7 @@ package interpreter
 
 import (
 	goerrors "errors"
+	"math/big"
 	"time"
 
 	"github.com/onflow/atree"
@@ -83,6 +84,8 @@ func NewArrayValue(
 	var index int
 	count := len(values)
 
+	interpreter.checkContainerSize(uint64(count), locationRange)
+
 	return NewArrayValueWithIterator(
 		interpreter,
 		arrayType,
@@ -424,11 +427,15 @@ func (v *ArrayValue) Concat(interpreter *Interpreter, locationRange LocationRang
 
 	elementType := v.Type.ElementType()
 
+	count := v.array.Count() + other.array.Count()
+
+	interpreter.checkContainerSize(count, locationRange)
+
 	return NewArrayValueWithIterator(
 		interpreter,
 		v.Type,
 		common.ZeroAddress,
-		v.array.Count()+other.array.Count(),
+		count,
 		func() Value {
 
 			// Meter computation for iterating the two arrays.
@@ -612,6 +619,8 @@ func (v *ArrayValue) Append(interpreter *Interpreter, locationRange LocationRang
 
 	interpreter.validateMutation(v.ValueID(), locationRange)
 
+	interpreter.checkContainerSize(v.array.Count()+1, locationRange)
+
 	// length increases by 1
 	dataSlabs, metaDataSlabs := common.AdditionalAtreeMemoryUsage(
 		v.array.Count(),
@@ -668,17 +677,22 @@ func (v *ArrayValue) InsertWithoutTransfer(
 ) {
 	interpreter.validateMutation(v.ValueID(), locationRange)
 
-	// We only need to check the lower bound before converting from `int` (signed) to `uint64` (unsigned).
-	// atree's Array.Insert function will check the upper bound and report an atree.IndexOutOfBoundsError
+	// Inserting at the count of the array, i.e. appending, is allowed.
+	// Check both bounds, instead of relying on atree's Array.Insert function,
+	// so the error is reported as an insertion error
 
-	if index < 0 {
+	count := v.Count()
+	if index < 0 || index > count {
 		panic(ArrayIndexOutOfBoundsError{
 			Index:         index,
-			Size:          v.Count(),
+			Size:          count,
+			Insertion:     true,
 			LocationRange: locationRange,
 		})
 	}
 
+	interpreter.checkContainerSize(v.array.Count()+1, locationRange)
+
 	// length increases by 1
 	dataSlabs, metaDataSlabs := common.AdditionalAtreeMemoryUsage(
 		v.array.Count(),
@@ -1056,6 +1070,33 @@ func (v *ArrayValue) GetMember(interpreter *Interpreter, _ LocationRange, name s
 			},
 		)
 
+	case sema.ArrayTypeSlicedFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.ArraySlicedFunctionType(
+				v.SemaType(interpreter).ElementType(false),
+			),
+			func(v *ArrayValue, invocation Invocation) Value {
+				from, ok := invocation.Arguments[0].(IntValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				to, ok := invocation.Arguments[1].(IntValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Sliced(
+					invocation.Interpreter,
+					from,
+					to,
+					invocation.LocationRange,
+				)
+			},
+		)
+
 	case sema.ArrayTypeReverseFunctionName:
 		return NewBoundHostFunctionValue(
 			interpreter,
@@ -1071,6 +1112,42 @@ func (v *ArrayValue) GetMember(interpreter *Interpreter, _ LocationRange, name s
 			},
 		)
 
+	case sema.ArrayTypeRotatedFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.ArrayRotatedFunctionType(
+				v.SemaType(interpreter),
+			),
+			func(v *ArrayValue, invocation Invocation) Value {
+				positions, ok := invocation.Arguments[0].(IntValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Rotated(
+					invocation.Interpreter,
+					invocation.LocationRange,
+					positions,
+				)
+			},
+		)
+
+	case sema.ArrayTypeShuffledFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.ArrayShuffledFunctionType(
+				v.SemaType(interpreter),
+			),
+			func(v *ArrayValue, invocation Invocation) Value {
+				return v.Shuffled(
+					invocation.Interpreter,
+					invocation.LocationRange,
+				)
+			},
+		)
+
 	case sema.ArrayTypeFilterFunctionName:
 		return NewBoundHostFunctionValue(
 			interpreter,
@@ -1095,6 +1172,60 @@ func (v *ArrayValue) GetMember(interpreter *Interpreter, _ LocationRange, name s
 			},
 		)
 
+	case sema.ArrayTypePartitionFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.ArrayPartitionFunctionType(
+				interpreter,
+				v.SemaType(interpreter).ElementType(false),
+			),
+			func(v *ArrayValue, invocation Invocation) Value {
+				interpreter := invocation.Interpreter
+
+				funcArgument, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Partition(
+					interpreter,
+					invocation.LocationRange,
+					funcArgument,
+				)
+			},
+		)
+
+	case sema.ArrayTypeMinFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.ArrayMinMaxFunctionType(
+				v.SemaType(interpreter).ElementType(false),
+			),
+			func(v *ArrayValue, invocation Invocation) Value {
+				return v.Min(
+					invocation.Interpreter,
+					invocation.LocationRange,
+				)
+			},
+		)
+
+	case sema.ArrayTypeMaxFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.ArrayMinMaxFunctionType(
+				v.SemaType(interpreter).ElementType(false),
+			),
+			func(v *ArrayValue, invocation Invocation) Value {
+				return v.Max(
+					invocation.Interpreter,
+					invocation.LocationRange,
+				)
+			},
+		)
+
 	case sema.ArrayTypeMapFunctionName:
 		return NewBoundHostFunctionValue(
 			interpreter,
@@ -1119,6 +1250,61 @@ func (v *ArrayValue) GetMember(interpreter *Interpreter, _ LocationRange, name s
 			},
 		)
 
+	case sema.ArrayTypeZipFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.ArrayZipFunctionType(
+				interpreter,
+				v.SemaType(interpreter).ElementType(false),
+			),
+			func(v *ArrayValue, invocation Invocation) Value {
+				interpreter := invocation.Interpreter
+
+				other, ok := invocation.Arguments[0].(*ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				funcArgument, ok := invocation.Arguments[1].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Zip(
+					interpreter,
+					invocation.LocationRange,
+					other,
+					funcArgument,
+				)
+			},
+		)
+
+	case sema.ArrayTypeForEachIndexedFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.ArrayForEachIndexedFunctionType(
+				v.SemaType(interpreter).ElementType(false),
+			),
+			func(v *ArrayValue, invocation Invocation) Value {
+				interpreter := invocation.Interpreter
+
+				funcArgument, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				v.ForEachIndexed(
+					interpreter,
+					invocation.LocationRange,
+					funcArgument,
+				)
+
+				return Void
+			},
+		)
+
 	case sema.ArrayTypeToVariableSizedFunctionName:
 		return NewBoundHostFunctionValue(
 			interpreter,
@@ -1558,6 +1744,50 @@ func (v *ArrayValue) IsResourceKinded(interpreter *Interpreter) bool {
 	return *v.isResourceKinded
 }
 
+// Sliced is like Slice, but negative indices count from the end of the array,
+// e.g. -1 is the index of the last element.
+// Indices which are out of bounds after resolving negative indices are rejected,
+// they are not clamped.
+func (v *ArrayValue) Sliced(
+	interpreter *Interpreter,
+	from IntValue,
+	to IntValue,
+	locationRange LocationRange,
+) Value {
+	count := v.Count()
+
+	fromIndex := from.ToInt(locationRange)
+	toIndex := to.ToInt(locationRange)
+
+	resolveIndex := func(index int) int {
+		if index < 0 {
+			return count + index
+		}
+		return index
+	}
+
+	resolvedFromIndex := resolveIndex(fromIndex)
+	resolvedToIndex := resolveIndex(toIndex)
+
+	if resolvedFromIndex < 0 || resolvedFromIndex > count ||
+		resolvedToIndex < 0 || resolvedToIndex > count {
+
+		panic(ArraySliceIndicesError{
+			FromIndex:     fromIndex,
+			UpToIndex:     toIndex,
+			Size:          count,
+			LocationRange: locationRange,
+		})
+	}
+
+	return v.Slice(
+		interpreter,
+		NewIntValueFromInt64(interpreter, int64(resolvedFromIndex)),
+		NewIntValueFromInt64(interpreter, int64(resolvedToIndex)),
+		locationRange,
+	)
+}
+
 func (v *ArrayValue) Slice(
 	interpreter *Interpreter,
 	from IntValue,
@@ -1678,6 +1908,122 @@ func (v *ArrayValue) Reverse(
 	)
 }
 
+// Rotated returns a copy of the array with the elements rotated to the left
+// by the given number of positions, or to the right if the number is negative.
+func (v *ArrayValue) Rotated(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	positions IntValue,
+) Value {
+	count := v.Count()
+
+	// Normalize the number of positions to a left rotation in the range [0, count).
+	// Mod is the Euclidean modulus, so the result is non-negative for negative positions.
+	var offset int
+	if count > 0 {
+		offset = int(new(big.Int).Mod(positions.BigInt, big.NewInt(int64(count))).Int64())
+	}
+
+	index := 0
+
+	return NewArrayValueWithIterator(
+		interpreter,
+		v.Type,
+		common.ZeroAddress,
+		uint64(count),
+		func() Value {
+			if index >= count {
+				return nil
+			}
+
+			// Meter computation for iterating the array.
+			interpreter.ReportComputation(common.ComputationKindLoop, 1)
+
+			value := v.Get(interpreter, locationRange, (index+offset)%count)
+			index++
+
+			return value.Transfer(
+				interpreter,
+				locationRange,
+				atree.Address{},
+				false,
+				nil,
+				nil,
+				false, // value has a parent container because it is returned by Get().
+			)
+		},
+	)
+}
+
+// Shuffled returns a copy of the array with the elements in a random order,
+// using the random handler of the interpreter's configuration.
+func (v *ArrayValue) Shuffled(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+) Value {
+	count := v.Count()
+
+	randomHandler := interpreter.SharedState.Config.RandomHandler
+	if randomHandler == nil {
+		panic(RandomUnavailableError{
+			LocationRange: locationRange,
+		})
+	}
+
+	// Fisher-Yates shuffle of the element indices
+
+	indices := make([]int, count)
+	for i := range indices {
+		indices[i] = i
+	}
+
+	readRandom := func(buffer []byte) {
+		err := randomHandler(buffer)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	for i := count - 1; i > 0; i-- {
+		// Meter computation for shuffling the array.
+		interpreter.ReportComputation(common.ComputationKindLoop, 1)
+
+		// Use a uniformly distributed index, without modulo bias
+		j := int(RandomUint64(uint64(i+1), readRandom))
+		indices[i], indices[j] = indices[j], indices[i]
+	}
+
+	index := 0
+
+	return NewArrayValueWithIterator(
+		interpreter,
+		v.Type,
+		common.ZeroAddress,
+		uint64(count),
+		func() Value {
+			if index >= count {
+				return nil
+			}
+
+			// Meter computation for iterating the array.
+			interpreter.ReportComputation(common.ComputationKindLoop, 1)
+
+			value := v.Get(interpreter, locationRange, indices[index])
+			index++
+
+			return value.Transfer(
+				interpreter,
+				locationRange,
+				atree.Address{},
+				false,
+				nil,
+				nil,
+				false, // value has a parent container because it is returned by Get().
+			)
+		},
+	)
+}
+
 func (v *ArrayValue) Filter(
 	interpreter *Interpreter,
 	locationRange LocationRange,
@@ -1761,6 +2107,191 @@ func (v *ArrayValue) Filter(
 	)
 }
 
+// Partition returns a constant-sized array of two new arrays:
+// The elements for which the predicate returns true, and the elements for which it returns false.
+// The elements are in the same order as in the original array.
+func (v *ArrayValue) Partition(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	procedure FunctionValue,
+) Value {
+
+	elementType := v.semaType.ElementType(false)
+
+	argumentTypes := []sema.Type{elementType}
+
+	procedureFunctionType := procedure.FunctionType()
+	parameterTypes := procedureFunctionType.ParameterTypes()
+	returnType := procedureFunctionType.ReturnTypeAnnotation.Type
+
+	var matching, notMatching []Value
+
+	v.Iterate(
+		interpreter,
+		func(value Value) (resume bool) {
+			// Meter computation for iterating the array.
+			interpreter.ReportComputation(common.ComputationKindLoop, 1)
+
+			result := interpreter.invokeFunctionValue(
+				procedure,
+				[]Value{value},
+				nil,
+				argumentTypes,
+				parameterTypes,
+				returnType,
+				nil,
+				locationRange,
+			)
+
+			isMatching, ok := result.(BoolValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			if isMatching {
+				matching = append(matching, value)
+			} else {
+				notMatching = append(notMatching, value)
+			}
+
+			return true
+		},
+		true,
+		locationRange,
+	)
+
+	partitionType := NewVariableSizedStaticType(interpreter, v.Type.ElementType())
+
+	return NewArrayValue(
+		interpreter,
+		locationRange,
+		NewConstantSizedStaticType(interpreter, partitionType, 2),
+		common.ZeroAddress,
+		NewArrayValue(
+			interpreter,
+			locationRange,
+			partitionType,
+			common.ZeroAddress,
+			matching...,
+		),
+		NewArrayValue(
+			interpreter,
+			locationRange,
+			partitionType,
+			common.ZeroAddress,
+			notMatching...,
+		),
+	)
+}
+
+func (v *ArrayValue) Min(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+) OptionalValue {
+	return v.extremum(
+		interpreter,
+		locationRange,
+		func(value, current ComparableValue) BoolValue {
+			return value.Less(interpreter, current, locationRange)
+		},
+	)
+}
+
+func (v *ArrayValue) Max(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+) OptionalValue {
+	return v.extremum(
+		interpreter,
+		locationRange,
+		func(value, current ComparableValue) BoolValue {
+			return value.Greater(interpreter, current, locationRange)
+		},
+	)
+}
+
+// extremum returns the first element of the array for which no other element is preferred,
+// i.e. for which isPreferred returns false for all other elements, or nil if the array is empty.
+func (v *ArrayValue) extremum(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	isPreferred func(value, current ComparableValue) BoolValue,
+) OptionalValue {
+
+	var result ComparableValue
+
+	v.Iterate(
+		interpreter,
+		func(element Value) (resume bool) {
+			// Meter computation for iterating the array.
+			interpreter.ReportComputation(common.ComputationKindLoop, 1)
+
+			value, ok := element.(ComparableValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			if result == nil || isPreferred(value, result) {
+				result = value
+			}
+
+			return true
+		},
+		true,
+		locationRange,
+	)
+
+	if result == nil {
+		return NilOptionalValue
+	}
+
+	return NewSomeValueNonCopying(interpreter, result)
+}
+
+func (v *ArrayValue) ForEachIndexed(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	procedure FunctionValue,
+) {
+	elementType := v.semaType.ElementType(false)
+
+	argumentTypes := []sema.Type{sema.IntType, elementType}
+
+	procedureFunctionType := procedure.FunctionType()
+	parameterTypes := procedureFunctionType.ParameterTypes()
+	returnType := procedureFunctionType.ReturnTypeAnnotation.Type
+
+	index := 0
+
+	v.Iterate(
+		interpreter,
+		func(element Value) (resume bool) {
+			// Meter computation for iterating the array.
+			interpreter.ReportComputation(common.ComputationKindLoop, 1)
+
+			interpreter.invokeFunctionValue(
+				procedure,
+				[]Value{
+					NewIntValueFromInt64(interpreter, int64(index)),
+					element,
+				},
+				nil,
+				argumentTypes,
+				parameterTypes,
+				returnType,
+				nil,
+				locationRange,
+			)
+
+			index++
+
+			return true
+		},
+		true,
+		locationRange,
+	)
+}
+
 func (v *ArrayValue) Map(
 	interpreter *Interpreter,
 	locationRange LocationRange,
@@ -1845,6 +2376,102 @@ func (v *ArrayValue) Map(
 	)
 }
 
+func (v *ArrayValue) Zip(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	other *ArrayValue,
+	procedure FunctionValue,
+) Value {
+
+	argumentTypes := []sema.Type{
+		v.semaType.ElementType(false),
+		other.SemaType(interpreter).ElementType(false),
+	}
+
+	procedureFunctionType := procedure.FunctionType()
+	parameterTypes := procedureFunctionType.ParameterTypes()
+	returnType := procedureFunctionType.ReturnTypeAnnotation.Type
+
+	returnArrayStaticType := NewVariableSizedStaticType(
+		interpreter,
+		ConvertSemaToStaticType(interpreter, returnType),
+	)
+
+	// The result has the length of the shorter array
+	count := v.Count()
+	otherCount := other.Count()
+	if otherCount < count {
+		count = otherCount
+	}
+
+	// TODO: Use ReadOnlyIterator here if procedure doesn't change array elements.
+	iterator, err := v.array.Iterator()
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+
+	otherIterator, err := other.array.Iterator()
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+
+	index := 0
+
+	return NewArrayValueWithIterator(
+		interpreter,
+		returnArrayStaticType,
+		common.ZeroAddress,
+		uint64(count),
+		func() Value {
+			if index >= count {
+				return nil
+			}
+			index++
+
+			// Meter computation for iterating the arrays.
+			interpreter.ReportComputation(common.ComputationKindLoop, 1)
+
+			atreeValue, err := iterator.Next()
+			if err != nil {
+				panic(errors.NewExternalError(err))
+			}
+
+			otherAtreeValue, err := otherIterator.Next()
+			if err != nil {
+				panic(errors.NewExternalError(err))
+			}
+
+			if atreeValue == nil || otherAtreeValue == nil {
+				return nil
+			}
+
+			value := MustConvertStoredValue(interpreter, atreeValue)
+			otherValue := MustConvertStoredValue(interpreter, otherAtreeValue)
+
+			result := interpreter.invokeFunctionValue(
+				procedure,
+				[]Value{value, otherValue},
+				nil,
+				argumentTypes,
+				parameterTypes,
+				returnType,
+				nil,
+				locationRange,
+			)
+
+			return result.Transfer(
+				interpreter,
+				locationRange,
+				atree.Address{},
+				false,
+				nil,
+				nil,
+				false, // value has a parent container because it is from iterator.
+			)
+		},
+	)
+}
+
 func (v *ArrayValue) ForEach(
 	interpreter *Interpreter,
 	_ sema.Type,
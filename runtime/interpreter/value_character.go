@@ -19,6 +19,8 @@
 package interpreter
 
 import (
+	"unicode/utf8"
+
 	"golang.org/x/text/unicode/norm"
 
 	"github.com/onflow/atree"
@@ -243,10 +245,41 @@ func (v CharacterValue) GetMember(interpreter *Interpreter, _ LocationRange, nam
 	case sema.CharacterTypeUtf8FieldName:
 		common.UseMemory(interpreter, common.NewBytesMemoryUsage(len(v.Str)))
 		return ByteSliceToByteArrayValue(interpreter, []byte(v.Str))
+
+	case sema.CharacterTypeUnicodeScalarsFieldName:
+		return v.unicodeScalars(interpreter)
 	}
 	return nil
 }
 
+func (v CharacterValue) unicodeScalars(interpreter *Interpreter) *ArrayValue {
+	scalars := []rune(v.Str)
+
+	index := 0
+
+	return NewArrayValueWithIterator(
+		interpreter,
+		NewVariableSizedStaticType(interpreter, PrimitiveStaticTypeUInt32),
+		common.ZeroAddress,
+		uint64(len(scalars)),
+		func() Value {
+			if index >= len(scalars) {
+				return nil
+			}
+
+			scalar := scalars[index]
+			index++
+
+			return NewUInt32Value(
+				interpreter,
+				func() uint32 {
+					return uint32(scalar)
+				},
+			)
+		},
+	)
+}
+
 func (CharacterValue) RemoveMember(_ *Interpreter, _ LocationRange, _ string) Value {
 	// Characters have no removable members (fields / functions)
 	panic(errors.NewUnreachableError())
@@ -256,3 +289,32 @@ func (CharacterValue) SetMember(_ *Interpreter, _ LocationRange, _ string, _ Val
 	// Characters have no settable members (fields / functions)
 	panic(errors.NewUnreachableError())
 }
+
+// characterFunction is the `Character` function. It is stateless, hence it can be re-used across interpreters.
+var characterFunction = NewUnmeteredStaticHostFunctionValue(
+	sema.CharacterFunctionType,
+	func(invocation Invocation) Value {
+		scalar, ok := invocation.Arguments[0].(UInt32Value)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		r := rune(scalar)
+		if !utf8.ValidRune(r) {
+			panic(InvalidUnicodeScalarError{
+				Scalar:        uint32(scalar),
+				LocationRange: invocation.LocationRange,
+			})
+		}
+
+		str := string(r)
+
+		return NewCharacterValue(
+			invocation.Interpreter,
+			common.NewCharacterMemoryUsage(len(str)),
+			func() string {
+				return str
+			},
+		)
+	},
+)
@@ -420,11 +420,45 @@ func (v *CompositeValue) getBuiltinMember(interpreter *Interpreter, locationRang
 		if v.Kind.SupportsAttachments() {
 			return v.forEachAttachmentFunction(interpreter, locationRange)
 		}
+	case sema.EnumCaseNameFieldName:
+		if v.Kind == common.CompositeKindEnum {
+			return v.enumCaseName(interpreter, locationRange)
+		}
 	}
 
 	return nil
 }
 
+// enumCaseName returns the name of the enum case,
+// based on the raw value and the cases of the enum type.
+// It returns nil for enums which have no case names, e.g. native enums
+func (v *CompositeValue) enumCaseName(interpreter *Interpreter, locationRange LocationRange) Value {
+	compositeType := interpreter.getUserCompositeType(v.Location, v.TypeID())
+	if compositeType == nil {
+		return nil
+	}
+
+	rawValue, ok := v.GetField(interpreter, locationRange, sema.EnumRawValueFieldName).(IntegerValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	index := rawValue.ToInt(locationRange)
+	if index < 0 || index >= len(compositeType.EnumCases) {
+		return nil
+	}
+
+	caseName := compositeType.EnumCases[index]
+
+	return NewStringValue(
+		interpreter,
+		common.NewStringMemoryUsage(len(caseName)),
+		func() string {
+			return caseName
+		},
+	)
+}
+
 func (v *CompositeValue) GetMember(interpreter *Interpreter, locationRange LocationRange, name string) Value {
 	config := interpreter.SharedState.Config
 
@@ -20,6 +20,7 @@ package interpreter
 
 import (
 	goerrors "errors"
+	"sort"
 	"time"
 
 	"github.com/onflow/atree"
@@ -572,6 +573,93 @@ func (v *DictionaryValue) ForEachKey(
 	interpreter.withMutationPrevention(v.ValueID(), iterate)
 }
 
+func (v *DictionaryValue) SortedKeys(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	procedure FunctionValue,
+) *ArrayValue {
+	semaType := v.SemaType(interpreter)
+	keyType := semaType.KeyType
+	valueType := semaType.ValueType
+
+	argumentTypes := []sema.Type{keyType, valueType, keyType, valueType}
+
+	procedureFunctionType := procedure.FunctionType()
+	parameterTypes := procedureFunctionType.ParameterTypes()
+	returnType := procedureFunctionType.ReturnTypeAnnotation.Type
+
+	count := v.Count()
+	keys := make([]Value, 0, count)
+	values := make([]Value, 0, count)
+
+	v.IterateReadOnly(
+		interpreter,
+		locationRange,
+		func(key, value Value) (resume bool) {
+			keys = append(keys, key)
+			values = append(values, value)
+			return true
+		},
+	)
+
+	// Sort the indices of the entries, so keys and values stay associated
+
+	indices := make([]int, count)
+	for i := range indices {
+		indices[i] = i
+	}
+
+	sort.SliceStable(indices, func(i, j int) bool {
+		// Meter computation for comparing entries.
+		interpreter.ReportComputation(common.ComputationKindLoop, 1)
+
+		a := indices[i]
+		b := indices[j]
+
+		result := interpreter.invokeFunctionValue(
+			procedure,
+			[]Value{keys[a], values[a], keys[b], values[b]},
+			nil,
+			argumentTypes,
+			parameterTypes,
+			returnType,
+			nil,
+			locationRange,
+		)
+
+		isLess, ok := result.(BoolValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		return bool(isLess)
+	})
+
+	sortedKeys := make([]Value, 0, count)
+	for _, index := range indices {
+		sortedKeys = append(
+			sortedKeys,
+			keys[index].Transfer(
+				interpreter,
+				locationRange,
+				atree.Address{},
+				false,
+				nil,
+				nil,
+				false, // value is an element of parent container.
+			),
+		)
+	}
+
+	return NewArrayValue(
+		interpreter,
+		locationRange,
+		NewVariableSizedStaticType(interpreter, v.Type.KeyType),
+		common.ZeroAddress,
+		sortedKeys...,
+	)
+}
+
 func (v *DictionaryValue) ContainsKey(
 	interpreter *Interpreter,
 	locationRange LocationRange,
@@ -617,6 +705,58 @@ func (v *DictionaryValue) Get(
 	return MustConvertStoredValue(interpreter, storedValue), true
 }
 
+// GetOrInsert returns the value for the given key.
+// If the dictionary does not contain the key, the given default value is inserted first,
+// and the inserted value is returned, like a subsequent lookup would
+func (v *DictionaryValue) GetOrInsert(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	keyValue Value,
+	defaultValue Value,
+) Value {
+	value, ok := v.Get(interpreter, locationRange, keyValue)
+	if ok {
+		return value
+	}
+
+	address := v.dictionary.Address()
+
+	preventTransfer := map[atree.ValueID]struct{}{
+		v.ValueID(): {},
+	}
+
+	keyValue = keyValue.Transfer(
+		interpreter,
+		locationRange,
+		address,
+		true,
+		nil,
+		preventTransfer,
+		true, // keyValue is standalone before it is inserted into parent container.
+	)
+
+	defaultValue = defaultValue.Transfer(
+		interpreter,
+		locationRange,
+		address,
+		true,
+		nil,
+		preventTransfer,
+		true, // defaultValue is standalone before it is inserted into parent container.
+	)
+
+	interpreter.checkContainerMutation(v.Type.KeyType, keyValue, locationRange)
+	interpreter.checkContainerMutation(v.Type.ValueType, defaultValue, locationRange)
+
+	// The key is not in the dictionary, so there is no existing value to clean up
+	existingValueStorable := v.InsertWithoutTransfer(interpreter, locationRange, keyValue, defaultValue)
+	if existingValueStorable != nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	return defaultValue
+}
+
 func (v *DictionaryValue) GetKey(interpreter *Interpreter, locationRange LocationRange, keyValue Value) Value {
 	value, ok := v.Get(interpreter, locationRange, keyValue)
 	if ok {
@@ -889,6 +1029,66 @@ func (v *DictionaryValue) GetMember(
 				return Void
 			},
 		)
+
+	case sema.DictionaryTypeSortedKeysFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.DictionarySortedKeysFunctionType(
+				v.SemaType(interpreter),
+			),
+			func(v *DictionaryValue, invocation Invocation) Value {
+				interpreter := invocation.Interpreter
+
+				funcArgument, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.SortedKeys(
+					interpreter,
+					invocation.LocationRange,
+					funcArgument,
+				)
+			},
+		)
+
+	case sema.DictionaryTypeGetOrDefaultFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.DictionaryGetOrDefaultFunctionType(
+				v.SemaType(interpreter),
+			),
+			func(v *DictionaryValue, invocation Invocation) Value {
+				value, ok := v.Get(
+					invocation.Interpreter,
+					invocation.LocationRange,
+					invocation.Arguments[0],
+				)
+				if !ok {
+					return invocation.Arguments[1]
+				}
+				return value
+			},
+		)
+
+	case sema.DictionaryTypeGetOrInsertFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.DictionaryGetOrInsertFunctionType(
+				v.SemaType(interpreter),
+			),
+			func(v *DictionaryValue, invocation Invocation) Value {
+				return v.GetOrInsert(
+					invocation.Interpreter,
+					invocation.LocationRange,
+					invocation.Arguments[0],
+					invocation.Arguments[1],
+				)
+			},
+		)
 	}
 
 	return nil
@@ -1026,6 +1226,11 @@ func (v *DictionaryValue) InsertWithoutTransfer(
 		panic(errors.NewExternalError(err))
 	}
 
+	// The key is new if there is no existing value
+	if existingValueStorable == nil {
+		interpreter.checkContainerSize(v.dictionary.Count(), locationRange)
+	}
+
 	interpreter.maybeValidateAtreeValue(v.dictionary)
 	interpreter.maybeValidateAtreeStorage()
 
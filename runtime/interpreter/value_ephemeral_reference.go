@@ -135,6 +135,17 @@ func (v *EphemeralReferenceValue) ReferencedValue(
 	return &v.Value
 }
 
+// IsInvalidated returns true if the resource the reference refers to
+// was moved or destroyed, i.e. the reference can no longer be used.
+func (v *EphemeralReferenceValue) IsInvalidated(interpreter *Interpreter) bool {
+	if v.Value == nil {
+		return true
+	}
+
+	resourceKindedValue, ok := v.Value.(ResourceKindedValue)
+	return ok && resourceKindedValue.isInvalidatedResource(interpreter)
+}
+
 func (v *EphemeralReferenceValue) GetMember(
 	interpreter *Interpreter,
 	locationRange LocationRange,
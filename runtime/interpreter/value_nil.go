@@ -98,10 +98,58 @@ var nilValueMapFunction = NewUnmeteredStaticHostFunctionValue(
 	},
 )
 
+// nilValueOrElseFunction is created only once per interpreter.
+// Hence, no need to meter, as it's a constant.
+var nilValueOrElseFunction = NewUnmeteredStaticHostFunctionValue(
+	sema.OptionalTypeOrElseFunctionType(sema.NeverType),
+	func(invocation Invocation) Value {
+		inter := invocation.Interpreter
+
+		defaultFunction, ok := invocation.Arguments[0].(FunctionValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		defaultFunctionType := defaultFunction.FunctionType()
+
+		return inter.invokeFunctionValue(
+			defaultFunction,
+			nil,
+			nil,
+			nil,
+			nil,
+			defaultFunctionType.ReturnTypeAnnotation.Type,
+			nil,
+			invocation.LocationRange,
+		)
+	},
+)
+
+// nilValueUnwrapFunction is created only once per interpreter.
+// Hence, no need to meter, as it's a constant.
+var nilValueUnwrapFunction = NewUnmeteredStaticHostFunctionValue(
+	sema.OptionalTypeUnwrapFunctionType(sema.NeverType),
+	func(invocation Invocation) Value {
+		message, ok := invocation.Arguments[0].(*StringValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		panic(UnwrapNilError{
+			Message:       message.Str,
+			LocationRange: invocation.LocationRange,
+		})
+	},
+)
+
 func (v NilValue) GetMember(_ *Interpreter, _ LocationRange, name string) Value {
 	switch name {
 	case sema.OptionalTypeMapFunctionName:
 		return nilValueMapFunction
+	case sema.OptionalTypeOrElseFunctionName:
+		return nilValueOrElseFunction
+	case sema.OptionalTypeUnwrapFunctionName:
+		return nilValueUnwrapFunction
 	}
 
 	return nil
@@ -20,6 +20,7 @@ package interpreter
 
 import (
 	"math/big"
+	"math/bits"
 
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
@@ -80,6 +81,57 @@ func getNumberValueMember(interpreter *Interpreter, v NumberValue, name string,
 			},
 		)
 
+	case sema.FixedSizeUnsignedIntegerTypePopCountFunctionName:
+		return newBitCountingFunction(interpreter, v, typ, popCount)
+
+	case sema.FixedSizeUnsignedIntegerTypeLeadingZerosFunctionName:
+		return newBitCountingFunction(interpreter, v, typ, leadingZeros)
+
+	case sema.FixedSizeUnsignedIntegerTypeTrailingZerosFunctionName:
+		return newBitCountingFunction(interpreter, v, typ, trailingZeros)
+
+	case sema.SignedNumberTypeAbsFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.NewSimpleFunctionType(
+				sema.FunctionPurityView,
+				nil,
+				sema.NewTypeAnnotation(typ),
+			),
+			func(v NumberValue, invocation Invocation) Value {
+				interpreter := invocation.Interpreter
+				locationRange := invocation.LocationRange
+
+				if numberSign(interpreter, v, locationRange) >= 0 {
+					return v
+				}
+
+				// Negating the minimum value of a fixed-size type overflows
+				return v.Negate(interpreter, locationRange)
+			},
+		)
+
+	case sema.SignedNumberTypeSignumFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.SignumFunctionType,
+			func(v NumberValue, invocation Invocation) Value {
+				sign := numberSign(
+					invocation.Interpreter,
+					v,
+					invocation.LocationRange,
+				)
+				return NewInt8Value(
+					invocation.Interpreter,
+					func() int8 {
+						return sign
+					},
+				)
+			},
+		)
+
 	case sema.NumericTypeSaturatingAddFunctionName:
 		return NewBoundHostFunctionValue(
 			interpreter,
@@ -157,9 +209,140 @@ func getNumberValueMember(interpreter *Interpreter, v NumberValue, name string,
 		)
 	}
 
+	if function, ok := sema.SaturatingConversionFunctions[name]; ok {
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			function.FunctionType,
+			func(v NumberValue, invocation Invocation) Value {
+				return saturatingConvertInteger(
+					invocation.Interpreter,
+					v,
+					function.TargetType,
+					locationRange,
+				)
+			},
+		)
+	}
+
 	return nil
 }
 
+// numberSign returns -1 if the given number is negative, 0 if it is zero, and 1 if it is positive.
+// The number is compared against zero of the same type, obtained by subtracting the number from itself.
+func numberSign(interpreter *Interpreter, v NumberValue, locationRange LocationRange) int8 {
+	zero := v.Minus(interpreter, v, locationRange)
+
+	switch {
+	case bool(v.Less(interpreter, zero, locationRange)):
+		return -1
+	case bool(v.Greater(interpreter, zero, locationRange)):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// newBitCountingFunction returns a function which counts bits of the given fixed-size unsigned integer,
+// using its big-endian byte representation, padded to the size of the integer type.
+func newBitCountingFunction(
+	interpreter *Interpreter,
+	v NumberValue,
+	typ sema.Type,
+	count func(bytes []byte) int,
+) BoundFunctionValue {
+	numericType, ok := typ.(*sema.NumericType)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return NewBoundHostFunctionValue(
+		interpreter,
+		v,
+		sema.BitCountingFunctionType,
+		func(v NumberValue, invocation Invocation) Value {
+			bytes := v.ToBigEndianBytes()
+
+			byteSize := numericType.ByteSize()
+			if len(bytes) < byteSize {
+				paddedBytes := make([]byte, byteSize)
+				copy(paddedBytes[byteSize-len(bytes):], bytes)
+				bytes = paddedBytes
+			}
+
+			return NewIntValueFromInt64(
+				invocation.Interpreter,
+				int64(count(bytes)),
+			)
+		},
+	)
+}
+
+func popCount(bytes []byte) int {
+	count := 0
+	for _, b := range bytes {
+		count += bits.OnesCount8(b)
+	}
+	return count
+}
+
+func leadingZeros(bytes []byte) int {
+	count := 0
+	for _, b := range bytes {
+		count += bits.LeadingZeros8(b)
+		if b != 0 {
+			break
+		}
+	}
+	return count
+}
+
+func trailingZeros(bytes []byte) int {
+	count := 0
+	for i := len(bytes) - 1; i >= 0; i-- {
+		b := bytes[i]
+		count += bits.TrailingZeros8(b)
+		if b != 0 {
+			break
+		}
+	}
+	return count
+}
+
+// saturatingConvertInteger converts the given integer value to the given target type.
+// Values outside the range of the target type are clamped to the bounds of the target type.
+func saturatingConvertInteger(
+	interpreter *Interpreter,
+	value NumberValue,
+	targetType sema.IntegerRangedType,
+	locationRange LocationRange,
+) Value {
+	intValue := ConvertInt(interpreter, value, locationRange)
+
+	minInt := targetType.MinInt()
+	maxInt := targetType.MaxInt()
+
+	if minInt != nil && intValue.BigInt.Cmp(minInt) < 0 {
+		intValue = NewIntValueFromBigInt(
+			interpreter,
+			common.NewBigIntMemoryUsage(common.BigIntByteLength(minInt)),
+			func() *big.Int {
+				return new(big.Int).Set(minInt)
+			},
+		)
+	} else if maxInt != nil && intValue.BigInt.Cmp(maxInt) > 0 {
+		intValue = NewIntValueFromBigInt(
+			interpreter,
+			common.NewBigIntMemoryUsage(common.BigIntByteLength(maxInt)),
+			func() *big.Int {
+				return new(big.Int).Set(maxInt)
+			},
+		)
+	}
+
+	return interpreter.convert(intValue, sema.IntType, targetType, locationRange)
+}
+
 type IntegerValue interface {
 	NumberValue
 	BitwiseOr(interpreter *Interpreter, other IntegerValue, locationRange LocationRange) IntegerValue
@@ -56,3 +56,31 @@ func DereferenceValue(
 		false,
 	)
 }
+
+// ReferencesHaveSameTarget returns true if the given references
+// refer to the same underlying value (identity),
+// as opposed to values which are merely equal.
+func ReferencesHaveSameTarget(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	reference ReferenceValue,
+	otherReference ReferenceValue,
+) bool {
+	referencedValue := *reference.ReferencedValue(interpreter, locationRange, true)
+	otherReferencedValue := *otherReference.ReferencedValue(interpreter, locationRange, true)
+
+	type valueIDValue interface {
+		ValueID() atree.ValueID
+	}
+
+	// Container values (composites, arrays, dictionaries) are identified by their value ID,
+	// which is stable across loads from storage.
+
+	valueWithID, ok := referencedValue.(valueIDValue)
+	if ok {
+		otherValueWithID, ok := otherReferencedValue.(valueIDValue)
+		return ok && valueWithID.ValueID() == otherValueWithID.ValueID()
+	}
+
+	return referencedValue == otherReferencedValue
+}
@@ -162,6 +162,40 @@ func (v *SomeValue) GetMember(interpreter *Interpreter, _ LocationRange, name st
 				)
 			},
 		)
+
+	case sema.OptionalTypeOrElseFunctionName:
+		innerValueType := interpreter.MustConvertStaticToSemaType(
+			v.value.StaticType(interpreter),
+		)
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.OptionalTypeOrElseFunctionType(
+				innerValueType,
+			),
+			func(v *SomeValue, invocation Invocation) Value {
+				// The default function is not called,
+				// as this optional has a value
+				return v.InnerValue(invocation.Interpreter, invocation.LocationRange)
+			},
+		)
+
+	case sema.OptionalTypeUnwrapFunctionName:
+		innerValueType := interpreter.MustConvertStaticToSemaType(
+			v.value.StaticType(interpreter),
+		)
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.OptionalTypeUnwrapFunctionType(
+				innerValueType,
+			),
+			func(v *SomeValue, invocation Invocation) Value {
+				// The message is not used,
+				// as this optional has a value
+				return v.InnerValue(invocation.Interpreter, invocation.LocationRange)
+			},
+		)
 	}
 
 	return nil
@@ -469,6 +469,39 @@ func (v *StringValue) GetMember(interpreter *Interpreter, locationRange Location
 			},
 		)
 
+	case sema.StringTypeToUpperFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.StringTypeToUpperFunctionType,
+			func(v *StringValue, invocation Invocation) Value {
+				return v.ToUpper(invocation.Interpreter)
+			},
+		)
+
+	case sema.StringTypeTrimFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.StringTypeTrimFunctionType,
+			func(v *StringValue, invocation Invocation) Value {
+				return v.Trim(invocation.Interpreter)
+			},
+		)
+
+	case sema.StringTypeToCharactersFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.StringTypeToCharactersFunctionType,
+			func(v *StringValue, invocation Invocation) Value {
+				return v.ToCharacters(
+					invocation.Interpreter,
+					invocation.LocationRange,
+				)
+			},
+		)
+
 	case sema.StringTypeSplitFunctionName:
 		return NewBoundHostFunctionValue(
 			interpreter,
@@ -577,6 +610,51 @@ func (v *StringValue) ToLower(interpreter *Interpreter) *StringValue {
 	)
 }
 
+func (v *StringValue) ToUpper(interpreter *Interpreter) *StringValue {
+
+	// Meter computation as if the string was iterated.
+	interpreter.ReportComputation(common.ComputationKindLoop, uint(len(v.Str)))
+
+	// Over-estimate resulting string length,
+	// as the encoding of an uppercase character may be longer than the lowercase character, e.g. ɐ => Ɐ
+
+	var lengthEstimate int
+	for _, r := range v.Str {
+		if r < unicode.MaxASCII {
+			lengthEstimate += 1
+		} else {
+			lengthEstimate += utf8.UTFMax
+		}
+	}
+
+	memoryUsage := common.NewStringMemoryUsage(lengthEstimate)
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			return strings.ToUpper(v.Str)
+		},
+	)
+}
+
+func (v *StringValue) Trim(interpreter *Interpreter) *StringValue {
+
+	// Meter computation as if the string was iterated.
+	interpreter.ReportComputation(common.ComputationKindLoop, uint(len(v.Str)))
+
+	// The result is a substring of the original string
+	memoryUsage := common.NewStringMemoryUsage(len(v.Str))
+
+	return NewStringValue(
+		interpreter,
+		memoryUsage,
+		func() string {
+			return strings.TrimSpace(v.Str)
+		},
+	)
+}
+
 func (v *StringValue) Split(inter *Interpreter, locationRange LocationRange, separator *StringValue) *ArrayValue {
 
 	if len(separator.Str) == 0 {
@@ -666,6 +744,43 @@ func (v *StringValue) Explode(inter *Interpreter, locationRange LocationRange) *
 	)
 }
 
+var VarSizedArrayOfCharacterType = NewVariableSizedStaticType(nil, PrimitiveStaticTypeCharacter)
+
+// ToCharacters returns a Cadence array of type [Character], where each element is a character of the string.
+// Concatenating the characters, e.g. using String.fromCharacters, results in the original string
+func (v *StringValue) ToCharacters(inter *Interpreter, locationRange LocationRange) *ArrayValue {
+
+	iterator := v.Iterator(inter, locationRange)
+
+	return NewArrayValueWithIterator(
+		inter,
+		VarSizedArrayOfCharacterType,
+		common.ZeroAddress,
+		uint64(v.Length()),
+		func() Value {
+			value := iterator.Next(inter, locationRange)
+			if value == nil {
+				return nil
+			}
+
+			character, ok := value.(CharacterValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			str := character.Str
+
+			return NewCharacterValue(
+				inter,
+				common.NewCharacterMemoryUsage(len(str)),
+				func() string {
+					return str
+				},
+			)
+		},
+	)
+}
+
 func (v *StringValue) ReplaceAll(
 	inter *Interpreter,
 	locationRange LocationRange,
@@ -1228,6 +1343,88 @@ func stringFunctionJoin(invocation Invocation) Value {
 	return NewUnmeteredStringValue(builder.String())
 }
 
+const stringFormatPlaceholder = "{}"
+
+func stringFunctionFormat(invocation Invocation) Value {
+	template, ok := invocation.Arguments[0].(*StringValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	arguments, ok := invocation.Arguments[1].(*ArrayValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	inter := invocation.Interpreter
+	locationRange := invocation.LocationRange
+
+	parts := strings.Split(template.Str, stringFormatPlaceholder)
+	placeholderCount := len(parts) - 1
+	argumentCount := arguments.Count()
+
+	if placeholderCount != argumentCount {
+		panic(StringFormatArgumentCountError{
+			PlaceholderCount: placeholderCount,
+			ArgumentCount:    argumentCount,
+			LocationRange:    locationRange,
+		})
+	}
+
+	if argumentCount == 0 {
+		return template
+	}
+
+	// NewStringMemoryUsage already accounts for empty string.
+	common.UseMemory(inter, common.NewStringMemoryUsage(0))
+	var builder strings.Builder
+
+	writeString := func(str string) {
+		// Construct directly instead of using NewStringMemoryUsage to avoid
+		// having to decrement by 1 due to double counting of empty string.
+		common.UseMemory(inter,
+			common.MemoryUsage{
+				Kind:   common.MemoryKindStringValue,
+				Amount: uint64(len(str)),
+			},
+		)
+		builder.WriteString(str)
+	}
+
+	writeString(parts[0])
+
+	index := 1
+
+	arguments.Iterate(
+		inter,
+		func(element Value) (resume bool) {
+
+			// Meter computation for iterating the array.
+			inter.ReportComputation(common.ComputationKindLoop, 1)
+
+			// Strings and characters are inserted as-is,
+			// all other values use their string representation
+			switch element := element.(type) {
+			case *StringValue:
+				writeString(element.Str)
+			case CharacterValue:
+				writeString(element.Str)
+			default:
+				writeString(element.MeteredString(inter, SeenReferences{}, locationRange))
+			}
+
+			writeString(parts[index])
+			index++
+
+			return true
+		},
+		false,
+		locationRange,
+	)
+
+	return NewUnmeteredStringValue(builder.String())
+}
+
 // stringFunction is the `String` function. It is stateless, hence it can be re-used across interpreters.
 // Type bound functions are static functions.
 var stringFunction = func() Value {
@@ -1279,5 +1476,13 @@ var stringFunction = func() Value {
 		),
 	)
 
+	addMember(
+		sema.StringTypeFormatFunctionName,
+		NewUnmeteredStaticHostFunctionValue(
+			sema.StringTypeFormatFunctionType,
+			stringFunctionFormat,
+		),
+	)
+
 	return functionValue
 }()
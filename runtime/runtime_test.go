@@ -420,6 +420,58 @@ func TestRuntimeTransactionWithAccount(t *testing.T) {
 	assert.Equal(t, "0x000000000000002a", loggedMessage)
 }
 
+func TestRuntimeAccountAccessed(t *testing.T) {
+
+	t.Parallel()
+
+	config := DefaultTestInterpreterConfig
+	config.AccountAccessHandlerEnabled = true
+	runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+	script := []byte(`
+      transaction {
+        prepare(signer: &Account) {
+          log(getAccount(0x2).address)
+        }
+      }
+    `)
+
+	var accessedAccounts []common.Address
+
+	runtimeInterface := &TestRuntimeInterface{
+		OnGetSigningAccounts: func() ([]Address, error) {
+			return []Address{
+				common.MustBytesToAddress([]byte{0x1}),
+			}, nil
+		},
+		OnProgramLog: func(_ string) {},
+		OnAccountAccessed: func(_ *interpreter.Interpreter, address common.Address) {
+			accessedAccounts = append(accessedAccounts, address)
+		},
+	}
+
+	nextTransactionLocation := NewTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]common.Address{
+			common.MustBytesToAddress([]byte{0x1}),
+			common.MustBytesToAddress([]byte{0x2}),
+		},
+		accessedAccounts,
+	)
+}
+
 func TestRuntimeTransactionWithArguments(t *testing.T) {
 
 	t.Parallel()
@@ -31,6 +31,16 @@ const CharacterTypeUtf8FieldDocString = `
 The byte array of the UTF-8 encoding.
 `
 
+const CharacterTypeUnicodeScalarsFieldName = "unicodeScalars"
+
+var CharacterTypeUnicodeScalarsFieldType = &VariableSizedType{
+	Type: UInt32Type,
+}
+
+const CharacterTypeUnicodeScalarsFieldDocString = `
+The Unicode scalar values of the character, in order.
+`
+
 const CharacterTypeToStringFunctionName = "toString"
 
 var CharacterTypeToStringFunctionType = &FunctionType{
@@ -72,6 +82,14 @@ func init() {
 				CharacterTypeUtf8FieldType,
 				CharacterTypeUtf8FieldDocString,
 			),
+			NewUnmeteredFieldMember(
+				t,
+				PrimitiveAccess(ast.AccessAll),
+				ast.VariableKindConstant,
+				CharacterTypeUnicodeScalarsFieldName,
+				CharacterTypeUnicodeScalarsFieldType,
+				CharacterTypeUnicodeScalarsFieldDocString,
+			),
 			NewUnmeteredFunctionMember(
 				t,
 				PrimitiveAccess(ast.AccessAll),
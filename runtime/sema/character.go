@@ -20,10 +20,56 @@ package sema
 
 //go:generate go run ./gen character.cdc character.gen.go
 
-import "github.com/rivo/uniseg"
+import (
+	"github.com/rivo/uniseg"
+
+	"github.com/onflow/cadence/runtime/errors"
+)
 
 func IsValidCharacter(s string) bool {
 	graphemes := uniseg.NewGraphemes(s)
 	// a valid character must have exactly one grapheme cluster
 	return graphemes.Next() && !graphemes.Next()
 }
+
+const characterFunctionDocString = `
+Creates a character from the given Unicode scalar value.
+The function fails if the value is not a valid Unicode scalar value,
+e.g. if it is a surrogate or greater than 0x10FFFF.
+`
+
+var CharacterFunctionType = func() *FunctionType {
+	// Declare a function for the character type.
+	// It creates a character from a single Unicode scalar value
+
+	typeName := CharacterType.String()
+
+	// Check that the function is not accidentally redeclared
+
+	if BaseValueActivation.Find(typeName) != nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	functionType := NewSimpleFunctionType(
+		FunctionPurityView,
+		[]Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "scalar",
+				TypeAnnotation: UInt32TypeAnnotation,
+			},
+		},
+		NewTypeAnnotation(CharacterType),
+	)
+
+	BaseValueActivation.Set(
+		typeName,
+		baseFunctionVariable(
+			typeName,
+			functionType,
+			characterFunctionDocString,
+		),
+	)
+
+	return functionType
+}()
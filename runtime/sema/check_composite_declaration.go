@@ -682,7 +682,11 @@ func (checker *Checker) declareCompositeType(declaration ast.CompositeLikeDeclar
 	// Resolve conformances
 
 	if declaration.Kind() == common.CompositeKindEnum {
-		compositeType.EnumRawType = checker.enumRawType(declaration.(*ast.CompositeDeclaration))
+		compositeDeclaration := declaration.(*ast.CompositeDeclaration)
+		compositeType.EnumRawType = checker.enumRawType(compositeDeclaration)
+		for _, enumCase := range compositeDeclaration.Members.EnumCases() {
+			compositeType.EnumCases = append(compositeType.EnumCases, enumCase.Identifier.Identifier)
+		}
 	} else {
 		compositeType.ExplicitInterfaceConformances =
 			checker.explicitInterfaceConformances(declaration, compositeType)
@@ -1078,6 +1082,20 @@ func (checker *Checker) declareEnumConstructor(
 		}
 	}
 
+	// Cases take precedence over the built-in function
+
+	if !constructorType.Members.Contains(EnumConstructorFromCaseNameFunctionName) {
+		constructorType.Members.Set(
+			EnumConstructorFromCaseNameFunctionName,
+			NewUnmeteredPublicFunctionMember(
+				constructorType,
+				EnumConstructorFromCaseNameFunctionName,
+				EnumConstructorFromCaseNameFunctionType(compositeType),
+				enumConstructorFromCaseNameFunctionDocString,
+			),
+		)
+	}
+
 	if checker.PositionInfo != nil {
 		checker.PositionInfo.recordMemberOrigins(constructorType, constructorOrigins)
 	}
@@ -1115,6 +1133,30 @@ func EnumConstructorType(compositeType *CompositeType) *FunctionType {
 	}
 }
 
+const EnumConstructorFromCaseNameFunctionName = "fromCaseName"
+const enumConstructorFromCaseNameFunctionDocString = `
+Returns the enum case with the given name, if any.
+Returns nil if the enum has no case with the given name
+`
+
+func EnumConstructorFromCaseNameFunctionType(compositeType *CompositeType) *FunctionType {
+	return &FunctionType{
+		Purity: FunctionPurityView,
+		Parameters: []Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "name",
+				TypeAnnotation: StringTypeAnnotation,
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: compositeType,
+			},
+		),
+	}
+}
+
 // checkMemberStorability check that all fields have a type that is storable.
 func (checker *Checker) checkMemberStorability(members *StringMemberOrderedMap) {
 
@@ -1910,6 +1952,11 @@ const enumRawValueFieldDocString = `
 The raw value of the enum case
 `
 
+const EnumCaseNameFieldName = "caseName"
+const enumCaseNameFieldDocString = `
+The name of the enum case
+`
+
 func (checker *Checker) enumMembersAndOrigins(
 	allMembers *ast.Members,
 	containerType *CompositeType,
@@ -1951,7 +1998,7 @@ func (checker *Checker) enumMembersAndOrigins(
 
 	// Members of the enum type are *not* the enum cases!
 	// Each individual enum case is an instance of the enum type,
-	// so only has a single member, the raw value field
+	// so only has a single stored member, the raw value field
 
 	members = &StringMemberOrderedMap{}
 	members.Set(
@@ -1970,7 +2017,7 @@ func (checker *Checker) enumMembersAndOrigins(
 			DocString:       enumRawValueFieldDocString,
 		})
 
-	// No origins available for the only member which was declared above
+	// No origins available for the members declared above
 
 	origins = map[string]*Origin{}
 
@@ -1982,6 +2029,24 @@ func (checker *Checker) enumMembersAndOrigins(
 		}
 	})
 
+	// The case name is computed from the raw value, it is not a stored field
+
+	members.Set(
+		EnumCaseNameFieldName,
+		&Member{
+			ContainerType: containerType,
+			Access:        PrimitiveAccess(ast.AccessAll),
+			Identifier: ast.NewIdentifier(
+				checker.memoryGauge,
+				EnumCaseNameFieldName,
+				ast.EmptyPosition,
+			),
+			DeclarationKind: common.DeclarationKindField,
+			TypeAnnotation:  StringTypeAnnotation,
+			VariableKind:    ast.VariableKindConstant,
+			DocString:       enumCaseNameFieldDocString,
+		})
+
 	return
 }
 
@@ -396,6 +396,27 @@ func (e *NotEquatableTypeError) Error() string {
 	)
 }
 
+// NotComparableTypeError
+
+type NotComparableTypeError struct {
+	Type Type
+	ast.Range
+}
+
+var _ SemanticError = &NotComparableTypeError{}
+var _ errors.UserError = &NotComparableTypeError{}
+
+func (*NotComparableTypeError) isSemanticError() {}
+
+func (*NotComparableTypeError) IsUserError() {}
+
+func (e *NotComparableTypeError) Error() string {
+	return fmt.Sprintf(
+		"cannot order values which have type: `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
 // NotCallableError
 
 type NotCallableError struct {
@@ -102,6 +102,33 @@ const StringTypeJoinFunctionDocString = `
 Returns a string after joining the array of strings with the provided separator.
 `
 
+var StringTypeFormatFunctionType = NewSimpleFunctionType(
+	FunctionPurityView,
+	[]Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "template",
+			TypeAnnotation: StringTypeAnnotation,
+		},
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "arguments",
+			TypeAnnotation: NewTypeAnnotation(&VariableSizedType{
+				Type: AnyStructType,
+			}),
+		},
+	},
+	StringTypeAnnotation,
+)
+
+const StringTypeFormatFunctionName = "format"
+const StringTypeFormatFunctionDocString = `
+Returns the template string with each ` + "`{}`" + ` placeholder replaced by the string representation
+of the corresponding argument. Strings and characters are inserted as-is.
+
+The number of placeholders must match the number of arguments.
+`
+
 var StringTypeSplitFunctionType = NewSimpleFunctionType(
 	FunctionPurityView,
 	[]Parameter{
@@ -186,6 +213,24 @@ func init() {
 				StringTypeToLowerFunctionType,
 				stringTypeToLowerFunctionDocString,
 			),
+			NewUnmeteredPublicFunctionMember(
+				t,
+				StringTypeToUpperFunctionName,
+				StringTypeToUpperFunctionType,
+				stringTypeToUpperFunctionDocString,
+			),
+			NewUnmeteredPublicFunctionMember(
+				t,
+				StringTypeTrimFunctionName,
+				StringTypeTrimFunctionType,
+				stringTypeTrimFunctionDocString,
+			),
+			NewUnmeteredPublicFunctionMember(
+				t,
+				StringTypeToCharactersFunctionName,
+				StringTypeToCharactersFunctionType,
+				stringTypeToCharactersFunctionDocString,
+			),
 			NewUnmeteredPublicFunctionMember(
 				t,
 				StringTypeSplitFunctionName,
@@ -398,6 +443,52 @@ const stringTypeToLowerFunctionDocString = `
 Returns the string with upper case letters replaced with lowercase
 `
 
+var StringTypeToUpperFunctionType = NewSimpleFunctionType(
+	FunctionPurityView,
+	nil,
+	StringTypeAnnotation,
+)
+
+const StringTypeToUpperFunctionName = "toUpper"
+
+const stringTypeToUpperFunctionDocString = `
+Returns the string with lower case letters replaced with uppercase.
+
+Letters are mapped using the Unicode case mapping of each character,
+without any language-specific rules
+`
+
+var StringTypeTrimFunctionType = NewSimpleFunctionType(
+	FunctionPurityView,
+	nil,
+	StringTypeAnnotation,
+)
+
+const StringTypeTrimFunctionName = "trim"
+
+const stringTypeTrimFunctionDocString = `
+Returns the string with all leading and trailing whitespace removed.
+
+Whitespace is as defined by the Unicode White Space property
+`
+
+var StringTypeToCharactersFunctionType = NewSimpleFunctionType(
+	FunctionPurityView,
+	nil,
+	NewTypeAnnotation(&VariableSizedType{
+		Type: CharacterType,
+	}),
+)
+
+const StringTypeToCharactersFunctionName = "toCharacters"
+
+const stringTypeToCharactersFunctionDocString = `
+Returns an array containing the characters (grapheme clusters) of the string, in order.
+
+The string is the concatenation of the characters,
+so ` + "`String.fromCharacters(s.toCharacters()) == s`" + ` for every string ` + "`s`" + `
+`
+
 const stringFunctionDocString = "Creates an empty string"
 
 var StringFunctionType = func() *FunctionType {
@@ -457,6 +548,13 @@ var StringFunctionType = func() *FunctionType {
 		StringTypeJoinFunctionDocString,
 	))
 
+	addMember(NewUnmeteredPublicFunctionMember(
+		functionType,
+		StringTypeFormatFunctionName,
+		StringTypeFormatFunctionType,
+		StringTypeFormatFunctionDocString,
+	))
+
 	BaseValueActivation.Set(
 		typeName,
 		baseFunctionVariable(
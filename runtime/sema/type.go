@@ -495,6 +495,13 @@ const toStringFunctionDocString = `
 A textual representation of this object
 `
 
+const fixedPointToStringFunctionDocString = `
+A textual representation of this number.
+
+The fractional part is always rendered with all decimal places of the type,
+including trailing zeros, e.g. ` + "`1.5`" + ` is rendered as ` + "`1.50000000`" + `
+`
+
 // fromString
 const FromStringFunctionName = "fromString"
 
@@ -517,11 +524,49 @@ func FromStringFunctionDocstring(ty Type) string {
 			"The string may optionally begin with a sign prefix of '-' or '+'.\n",
 		)
 	}
+	if ty == IntType {
+		builder.WriteString(
+			"The optional radix, the base of the digits, must be in the range 2 to 36, and defaults to 10.\n",
+		)
+	}
 
 	return builder.String()
 }
 
+const FromStringFunctionRadixParameterName = "radix"
+
+// IntFromStringFunctionType is the type of `Int.fromString`,
+// which, unlike the function of the other number types,
+// accepts an optional radix
+var IntFromStringFunctionType = func() *FunctionType {
+	functionType := NewSimpleFunctionType(
+		FunctionPurityView,
+		[]Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "input",
+				TypeAnnotation: StringTypeAnnotation,
+			},
+			{
+				Identifier:     FromStringFunctionRadixParameterName,
+				TypeAnnotation: IntTypeAnnotation,
+			},
+		},
+		NewTypeAnnotation(
+			&OptionalType{
+				Type: IntType,
+			},
+		),
+	)
+	functionType.Arity = &Arity{Min: 1, Max: 2}
+	return functionType
+}()
+
 func FromStringFunctionType(ty Type) *FunctionType {
+	if ty == IntType {
+		return IntFromStringFunctionType
+	}
+
 	return NewSimpleFunctionType(
 		FunctionPurityView,
 		[]Parameter{
@@ -582,6 +627,112 @@ const toBigEndianBytesFunctionDocString = `
 Returns an array containing the big-endian byte representation of the number
 `
 
+// popcount, leadingZeros, and trailingZeros
+
+const FixedSizeUnsignedIntegerTypePopCountFunctionName = "popcount"
+
+const fixedSizeUnsignedIntegerTypePopCountFunctionDocString = `
+Returns the number of one bits in the integer
+`
+
+const FixedSizeUnsignedIntegerTypeLeadingZerosFunctionName = "leadingZeros"
+
+const fixedSizeUnsignedIntegerTypeLeadingZerosFunctionDocString = `
+Returns the number of leading zero bits in the integer.
+Returns the bit size of the integer type if the integer is zero
+`
+
+const FixedSizeUnsignedIntegerTypeTrailingZerosFunctionName = "trailingZeros"
+
+const fixedSizeUnsignedIntegerTypeTrailingZerosFunctionDocString = `
+Returns the number of trailing zero bits in the integer.
+Returns the bit size of the integer type if the integer is zero
+`
+
+var BitCountingFunctionType = NewSimpleFunctionType(
+	FunctionPurityView,
+	nil,
+	IntTypeAnnotation,
+)
+
+// abs and signum
+
+const SignedNumberTypeAbsFunctionName = "abs"
+
+const signedNumberTypeAbsFunctionDocString = `
+Returns the absolute value of the number.
+Aborts with an overflow error if the number is the minimum value of a fixed-size type,
+e.g. -128 for Int8, as the absolute value cannot be represented
+`
+
+const SignedNumberTypeSignumFunctionName = "signum"
+
+const signedNumberTypeSignumFunctionDocString = `
+Returns the sign of the number: -1 if the number is negative, 0 if it is zero, and 1 if it is positive
+`
+
+var SignumFunctionType = NewSimpleFunctionType(
+	FunctionPurityView,
+	nil,
+	Int8TypeAnnotation,
+)
+
+// to<Type>Saturating
+
+// SaturatingConversionFunction is a function of integer types
+// which converts the integer to the target type,
+// saturating at the target type's bounds instead of overflowing or underflowing.
+type SaturatingConversionFunction struct {
+	TargetType   IntegerRangedType
+	FunctionType *FunctionType
+	DocString    string
+}
+
+// SaturatingConversionFunctions are the saturating conversion functions
+// available on all integer types, keyed by function name.
+var SaturatingConversionFunctions = func() map[string]SaturatingConversionFunction {
+	targetTypes := []IntegerRangedType{
+		UIntType,
+		UInt8Type,
+		UInt16Type,
+		UInt32Type,
+		UInt64Type,
+		UInt128Type,
+		UInt256Type,
+		Int8Type,
+		Int16Type,
+		Int32Type,
+		Int64Type,
+		Int128Type,
+		Int256Type,
+	}
+
+	functions := make(map[string]SaturatingConversionFunction, len(targetTypes))
+
+	for _, targetType := range targetTypes {
+		functionName := SaturatingConversionFunctionName(targetType)
+		functions[functionName] = SaturatingConversionFunction{
+			TargetType: targetType,
+			FunctionType: NewSimpleFunctionType(
+				FunctionPurityView,
+				nil,
+				NewTypeAnnotation(targetType),
+			),
+			DocString: fmt.Sprintf(
+				"Converts the integer to %s, saturating at the bounds of %s instead of overflowing or underflowing.",
+				targetType,
+				targetType,
+			),
+		}
+	}
+
+	return functions
+}()
+
+func SaturatingConversionFunctionName(targetType Type) string {
+	return fmt.Sprintf("to%sSaturating", targetType)
+}
+
 func withBuiltinMembers(ty Type, members map[string]MemberResolver) map[string]MemberResolver {
 	if members == nil {
 		members = map[string]MemberResolver{}
@@ -621,6 +772,11 @@ func withBuiltinMembers(ty Type, members map[string]MemberResolver) map[string]M
 
 	if IsSubType(ty, NumberType) || IsSubType(ty, TheAddressType) || IsSubType(ty, PathType) {
 
+		docString := toStringFunctionDocString
+		if IsSubType(ty, FixedPointType) {
+			docString = fixedPointToStringFunctionDocString
+		}
+
 		members[ToStringFunctionName] = MemberResolver{
 			Kind: common.DeclarationKindFunction,
 			Resolve: func(memoryGauge common.MemoryGauge, identifier string, _ ast.HasPosition, _ func(error)) *Member {
@@ -629,7 +785,7 @@ func withBuiltinMembers(ty Type, members map[string]MemberResolver) map[string]M
 					ty,
 					identifier,
 					ToStringFunctionType,
-					toStringFunctionDocString,
+					docString,
 				)
 			},
 		}
@@ -653,6 +809,97 @@ func withBuiltinMembers(ty Type, members map[string]MemberResolver) map[string]M
 		}
 	}
 
+	// All fixed-size unsigned integer types have bit counting functions
+
+	if IsSubType(ty, FixedSizeUnsignedIntegerType) {
+
+		addBitCountingFunction := func(name string, docString string) {
+			members[name] = MemberResolver{
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(memoryGauge common.MemoryGauge, identifier string, _ ast.HasPosition, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						memoryGauge,
+						ty,
+						identifier,
+						BitCountingFunctionType,
+						docString,
+					)
+				},
+			}
+		}
+
+		addBitCountingFunction(
+			FixedSizeUnsignedIntegerTypePopCountFunctionName,
+			fixedSizeUnsignedIntegerTypePopCountFunctionDocString,
+		)
+		addBitCountingFunction(
+			FixedSizeUnsignedIntegerTypeLeadingZerosFunctionName,
+			fixedSizeUnsignedIntegerTypeLeadingZerosFunctionDocString,
+		)
+		addBitCountingFunction(
+			FixedSizeUnsignedIntegerTypeTrailingZerosFunctionName,
+			fixedSizeUnsignedIntegerTypeTrailingZerosFunctionDocString,
+		)
+	}
+
+	// All signed number types have `abs` and `signum` functions
+
+	if IsSubType(ty, SignedNumberType) {
+
+		members[SignedNumberTypeAbsFunctionName] = MemberResolver{
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(memoryGauge common.MemoryGauge, identifier string, _ ast.HasPosition, _ func(error)) *Member {
+				return NewPublicFunctionMember(
+					memoryGauge,
+					ty,
+					identifier,
+					NewSimpleFunctionType(
+						FunctionPurityView,
+						nil,
+						NewTypeAnnotation(ty),
+					),
+					signedNumberTypeAbsFunctionDocString,
+				)
+			},
+		}
+
+		members[SignedNumberTypeSignumFunctionName] = MemberResolver{
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(memoryGauge common.MemoryGauge, identifier string, _ ast.HasPosition, _ func(error)) *Member {
+				return NewPublicFunctionMember(
+					memoryGauge,
+					ty,
+					identifier,
+					SignumFunctionType,
+					signedNumberTypeSignumFunctionDocString,
+				)
+			},
+		}
+	}
+
+	// All integer types have saturating conversion functions, e.g. `toUInt8Saturating`
+
+	if IsSubType(ty, IntegerType) {
+
+		for name, function := range SaturatingConversionFunctions {
+			// NOTE: declare in loop, as captured in closure below
+			function := function
+
+			members[name] = MemberResolver{
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(memoryGauge common.MemoryGauge, identifier string, _ ast.HasPosition, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						memoryGauge,
+						ty,
+						identifier,
+						function.FunctionType,
+						function.DocString,
+					)
+				},
+			}
+		}
+	}
+
 	return members
 }
 
@@ -821,6 +1068,23 @@ Returns nil if this optional is nil
 
 const OptionalTypeMapFunctionName = "map"
 
+const optionalTypeOrElseFunctionDocString = `
+Returns the value of this optional when it is not nil.
+
+Returns the result of calling the given function if this optional is nil.
+The function is only called if this optional is nil
+`
+
+const OptionalTypeOrElseFunctionName = "orElse"
+
+const optionalTypeUnwrapFunctionDocString = `
+Returns the value of this optional when it is not nil.
+
+Aborts the program with the given message if this optional is nil
+`
+
+const OptionalTypeUnwrapFunctionName = "unwrap"
+
 func (t *OptionalType) Map(memoryGauge common.MemoryGauge, typeParamMap map[*TypeParameter]*TypeParameter, f func(Type) Type) Type {
 	return f(NewOptionalType(memoryGauge, t.Type.Map(memoryGauge, typeParamMap, f)))
 }
@@ -865,6 +1129,68 @@ func (t *OptionalType) initializeMembers() {
 						)
 					},
 				},
+				OptionalTypeOrElseFunctionName: {
+					Kind: common.DeclarationKindFunction,
+					Resolve: func(
+						memoryGauge common.MemoryGauge,
+						identifier string,
+						targetRange ast.HasPosition,
+						report func(error),
+					) *Member {
+
+						// It's invalid for an optional of a resource to have an `orElse` function,
+						// as the value would be duplicated
+
+						if t.Type.IsResourceType() {
+							report(
+								&InvalidResourceOptionalMemberError{
+									Name:            identifier,
+									DeclarationKind: common.DeclarationKindFunction,
+									Range:           ast.NewRangeFromPositioned(memoryGauge, targetRange),
+								},
+							)
+						}
+
+						return NewPublicFunctionMember(
+							memoryGauge,
+							t,
+							identifier,
+							OptionalTypeOrElseFunctionType(t.Type),
+							optionalTypeOrElseFunctionDocString,
+						)
+					},
+				},
+				OptionalTypeUnwrapFunctionName: {
+					Kind: common.DeclarationKindFunction,
+					Resolve: func(
+						memoryGauge common.MemoryGauge,
+						identifier string,
+						targetRange ast.HasPosition,
+						report func(error),
+					) *Member {
+
+						// It's invalid for an optional of a resource to have an `unwrap` function,
+						// as the value would be duplicated
+
+						if t.Type.IsResourceType() {
+							report(
+								&InvalidResourceOptionalMemberError{
+									Name:            identifier,
+									DeclarationKind: common.DeclarationKindFunction,
+									Range:           ast.NewRangeFromPositioned(memoryGauge, targetRange),
+								},
+							)
+						}
+
+						return NewPublicFunctionMember(
+							memoryGauge,
+							t,
+							identifier,
+							OptionalTypeUnwrapFunctionType(t.Type),
+							optionalTypeUnwrapFunctionDocString,
+						)
+					},
+				},
 			},
 		)
 	})
@@ -915,6 +1241,41 @@ func OptionalTypeMapFunctionType(typ Type) *FunctionType {
 	}
 }
 
+func OptionalTypeOrElseFunctionType(typ Type) *FunctionType {
+	const functionPurity = FunctionPurityImpure
+
+	return &FunctionType{
+		Purity: functionPurity,
+		Parameters: []Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "default",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Purity:               functionPurity,
+						ReturnTypeAnnotation: NewTypeAnnotation(typ),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(typ),
+	}
+}
+
+func OptionalTypeUnwrapFunctionType(typ Type) *FunctionType {
+	return &FunctionType{
+		Purity: FunctionPurityView,
+		Parameters: []Parameter{
+			{
+				Label:          "orFail",
+				Identifier:     "message",
+				TypeAnnotation: StringTypeAnnotation,
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(typ),
+	}
+}
+
 // GenericType
 type GenericType struct {
 	TypeParameter *TypeParameter
@@ -2149,6 +2510,18 @@ It does not modify the original array.
 If either of the parameters are out of the bounds of the array, or the indices are invalid (` + "`from > upTo`" + `), then the function will fail.
 `
 
+const ArrayTypeSlicedFunctionName = "sliced"
+
+const arrayTypeSlicedFunctionDocString = `
+Returns a new variable-sized array containing the slice of the elements in the given array from start index ` + "`from`" + ` up to, but not including, the end index ` + "`to`" + `.
+
+Unlike ` + "`slice`" + `, the indices may be negative, in which case they count from the end of the array,
+e.g. ` + "`-1`" + ` is the index of the last element, and ` + "`array.sliced(from: -2, to: array.length)`" + ` returns the last two elements.
+It does not modify the original array.
+If either of the indices is out of the bounds of the array after resolving negative indices,
+or the resolved start index is greater than the resolved end index, then the function will fail.
+`
+
 const ArrayTypeReverseFunctionName = "reverse"
 
 const arrayTypeReverseFunctionDocString = `
@@ -2156,6 +2529,25 @@ Returns a new array with contents in the reversed order.
 Available if the array element type is not resource-kinded.
 `
 
+const ArrayTypeRotatedFunctionName = "rotated"
+
+const arrayTypeRotatedFunctionDocString = `
+Returns a new array with the contents of the array rotated by the given number of positions.
+A positive number rotates the elements to the left, i.e. towards the start of the array,
+and a negative number rotates the elements to the right.
+The number of positions may exceed the length of the array, in which case the array is rotated by the number modulo the length.
+It does not modify the original array.
+Available if the array element type is not resource-kinded.
+`
+
+const ArrayTypeShuffledFunctionName = "shuffled"
+
+const arrayTypeShuffledFunctionDocString = `
+Returns a new array with the contents of the array in a random order.
+It does not modify the original array.
+Available if the array element type is not resource-kinded.
+`
+
 const ArrayTypeToVariableSizedFunctionName = "toVariableSized"
 
 const arrayTypeToVariableSizedFunctionDocString = `
@@ -2193,6 +2585,47 @@ Returns a new array whose elements are filtered by applying the filter function
 Available if the array element type is not resource-kinded.
 `
 
+const ArrayTypePartitionFunctionName = "partition"
+
+const arrayTypePartitionFunctionDocString = `
+Returns two new arrays, by applying the predicate function on each element of the original array:
+The first array contains the elements for which the predicate returns true,
+and the second array contains the elements for which the predicate returns false.
+Both arrays preserve the order of the elements in the original array.
+Available if the array element type is not resource-kinded.
+`
+
+const ArrayTypeMinFunctionName = "min"
+
+const arrayTypeMinFunctionDocString = `
+Returns the smallest element of the array, or nil if the array is empty.
+Available if the array element type is comparable, e.g. a number type.
+`
+
+const ArrayTypeMaxFunctionName = "max"
+
+const arrayTypeMaxFunctionDocString = `
+Returns the largest element of the array, or nil if the array is empty.
+Available if the array element type is comparable, e.g. a number type.
+`
+
+const ArrayTypeForEachIndexedFunctionName = "forEachIndexed"
+
+const arrayTypeForEachIndexedFunctionDocString = `
+Calls the given function for each element of the array, in order.
+The function is called with the zero-based index of the element and the element.
+`
+
+const ArrayTypeZipFunctionName = "zip"
+
+const arrayTypeZipFunctionDocString = `
+Returns a new array whose elements are produced by applying the combine function
+on each element of the array and the element of the given array at the same index.
+If the arrays have different lengths, the result has the length of the shorter array,
+and the remaining elements of the longer array are ignored.
+Available if the array element types are not resource-kinded.
+`
+
 const ArrayTypeMapFunctionName = "map"
 
 const arrayTypeMapFunctionDocString = `
@@ -2332,6 +2765,66 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 				)
 			},
 		},
+		ArrayTypeRotatedFunctionName: {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(
+				memoryGauge common.MemoryGauge,
+				identifier string,
+				targetRange ast.HasPosition,
+				report func(error),
+			) *Member {
+				elementType := arrayType.ElementType(false)
+
+				// It is impossible for a resource to be present in two arrays.
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           ast.NewRangeFromPositioned(memoryGauge, targetRange),
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					memoryGauge,
+					arrayType,
+					identifier,
+					ArrayRotatedFunctionType(arrayType),
+					arrayTypeRotatedFunctionDocString,
+				)
+			},
+		},
+		ArrayTypeShuffledFunctionName: {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(
+				memoryGauge common.MemoryGauge,
+				identifier string,
+				targetRange ast.HasPosition,
+				report func(error),
+			) *Member {
+				elementType := arrayType.ElementType(false)
+
+				// It is impossible for a resource to be present in two arrays.
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           ast.NewRangeFromPositioned(memoryGauge, targetRange),
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					memoryGauge,
+					arrayType,
+					identifier,
+					ArrayShuffledFunctionType(arrayType),
+					arrayTypeShuffledFunctionDocString,
+				)
+			},
+		},
 		ArrayTypeFilterFunctionName: {
 			Kind: common.DeclarationKindFunction,
 			Resolve: func(
@@ -2362,6 +2855,94 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 				)
 			},
 		},
+		ArrayTypePartitionFunctionName: {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(
+				memoryGauge common.MemoryGauge,
+				identifier string,
+				targetRange ast.HasPosition,
+				report func(error),
+			) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           ast.NewRangeFromPositioned(memoryGauge, targetRange),
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					memoryGauge,
+					arrayType,
+					identifier,
+					ArrayPartitionFunctionType(memoryGauge, elementType),
+					arrayTypePartitionFunctionDocString,
+				)
+			},
+		},
+		ArrayTypeMinFunctionName: {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(
+				memoryGauge common.MemoryGauge,
+				identifier string,
+				targetRange ast.HasPosition,
+				report func(error),
+			) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				if !IsOrderableType(elementType) {
+					report(
+						&NotComparableTypeError{
+							Type:  elementType,
+							Range: ast.NewRangeFromPositioned(memoryGauge, targetRange),
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					memoryGauge,
+					arrayType,
+					identifier,
+					ArrayMinMaxFunctionType(elementType),
+					arrayTypeMinFunctionDocString,
+				)
+			},
+		},
+		ArrayTypeMaxFunctionName: {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(
+				memoryGauge common.MemoryGauge,
+				identifier string,
+				targetRange ast.HasPosition,
+				report func(error),
+			) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				if !IsOrderableType(elementType) {
+					report(
+						&NotComparableTypeError{
+							Type:  elementType,
+							Range: ast.NewRangeFromPositioned(memoryGauge, targetRange),
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					memoryGauge,
+					arrayType,
+					identifier,
+					ArrayMinMaxFunctionType(elementType),
+					arrayTypeMaxFunctionDocString,
+				)
+			},
+		},
 		ArrayTypeMapFunctionName: {
 			Kind: common.DeclarationKindFunction,
 			Resolve: func(
@@ -2392,6 +2973,66 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 				)
 			},
 		},
+		ArrayTypeForEachIndexedFunctionName: {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(
+				memoryGauge common.MemoryGauge,
+				identifier string,
+				targetRange ast.HasPosition,
+				report func(error),
+			) *Member {
+				elementType := arrayType.ElementType(false)
+
+				// The elements are passed to the function,
+				// which is impossible for resources.
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           ast.NewRangeFromPositioned(memoryGauge, targetRange),
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					memoryGauge,
+					arrayType,
+					identifier,
+					ArrayForEachIndexedFunctionType(elementType),
+					arrayTypeForEachIndexedFunctionDocString,
+				)
+			},
+		},
+		ArrayTypeZipFunctionName: {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(
+				memoryGauge common.MemoryGauge,
+				identifier string,
+				targetRange ast.HasPosition,
+				report func(error),
+			) *Member {
+				elementType := arrayType.ElementType(false)
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           ast.NewRangeFromPositioned(memoryGauge, targetRange),
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					memoryGauge,
+					arrayType,
+					identifier,
+					ArrayZipFunctionType(memoryGauge, elementType),
+					arrayTypeZipFunctionDocString,
+				)
+			},
+		},
 	}
 
 	// TODO: maybe still return members but report a helpful error?
@@ -2514,6 +3155,37 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 			},
 		}
 
+		members[ArrayTypeSlicedFunctionName] = MemberResolver{
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(
+				memoryGauge common.MemoryGauge,
+				identifier string,
+				targetRange ast.HasPosition,
+				report func(error),
+			) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           ast.NewRangeFromPositioned(memoryGauge, targetRange),
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					memoryGauge,
+					arrayType,
+					identifier,
+					ArraySlicedFunctionType(elementType),
+					arrayTypeSlicedFunctionDocString,
+				)
+			},
+		}
+
 		members["insert"] = MemberResolver{
 			Kind: common.DeclarationKindFunction,
 			Resolve: func(
@@ -2806,6 +3478,25 @@ func ArraySliceFunctionType(elementType Type) *FunctionType {
 	)
 }
 
+func ArraySlicedFunctionType(elementType Type) *FunctionType {
+	return NewSimpleFunctionType(
+		FunctionPurityView,
+		[]Parameter{
+			{
+				Identifier:     "from",
+				TypeAnnotation: IntTypeAnnotation,
+			},
+			{
+				Identifier:     "to",
+				TypeAnnotation: IntTypeAnnotation,
+			},
+		},
+		NewTypeAnnotation(&VariableSizedType{
+			Type: elementType,
+		}),
+	)
+}
+
 func ArrayToVariableSizedFunctionType(elementType Type) *FunctionType {
 	return NewSimpleFunctionType(
 		FunctionPurityView,
@@ -2871,18 +3562,80 @@ func ArrayToConstantSizedFunctionType(elementType Type) *FunctionType {
 			}
 		},
 	}
-}
+}
+
+func ArrayReverseFunctionType(arrayType ArrayType) *FunctionType {
+	return &FunctionType{
+		Parameters:           []Parameter{},
+		ReturnTypeAnnotation: NewTypeAnnotation(arrayType),
+		Purity:               FunctionPurityView,
+	}
+}
+
+func ArrayRotatedFunctionType(arrayType ArrayType) *FunctionType {
+	return &FunctionType{
+		Parameters: []Parameter{
+			{
+				Identifier:     "by",
+				TypeAnnotation: IntTypeAnnotation,
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(arrayType),
+		Purity:               FunctionPurityView,
+	}
+}
+
+func ArrayShuffledFunctionType(arrayType ArrayType) *FunctionType {
+	// Not a view function, as it consumes randomness
+	return &FunctionType{
+		Parameters:           []Parameter{},
+		ReturnTypeAnnotation: NewTypeAnnotation(arrayType),
+	}
+}
+
+func ArrayFilterFunctionType(memoryGauge common.MemoryGauge, elementType Type) *FunctionType {
+	// fun filter(_ function: ((T): Bool)): [T]
+	// funcType: elementType -> Bool
+	funcType := &FunctionType{
+		Parameters: []Parameter{
+			{
+				Identifier:     "element",
+				TypeAnnotation: NewTypeAnnotation(elementType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(BoolType),
+		Purity:               FunctionPurityView,
+	}
 
-func ArrayReverseFunctionType(arrayType ArrayType) *FunctionType {
 	return &FunctionType{
-		Parameters:           []Parameter{},
-		ReturnTypeAnnotation: NewTypeAnnotation(arrayType),
+		Parameters: []Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "f",
+				TypeAnnotation: NewTypeAnnotation(funcType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(NewVariableSizedType(memoryGauge, elementType)),
 		Purity:               FunctionPurityView,
 	}
 }
 
-func ArrayFilterFunctionType(memoryGauge common.MemoryGauge, elementType Type) *FunctionType {
-	// fun filter(_ function: ((T): Bool)): [T]
+func ArrayMinMaxFunctionType(elementType Type) *FunctionType {
+	// fun min(): T?
+	// fun max(): T?
+	return &FunctionType{
+		Parameters: []Parameter{},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: elementType,
+			},
+		),
+		Purity: FunctionPurityView,
+	}
+}
+
+func ArrayPartitionFunctionType(memoryGauge common.MemoryGauge, elementType Type) *FunctionType {
+	// fun partition(_ function: ((T): Bool)): [[T]; 2]
 	// funcType: elementType -> Bool
 	funcType := &FunctionType{
 		Parameters: []Parameter{
@@ -2903,8 +3656,14 @@ func ArrayFilterFunctionType(memoryGauge common.MemoryGauge, elementType Type) *
 				TypeAnnotation: NewTypeAnnotation(funcType),
 			},
 		},
-		ReturnTypeAnnotation: NewTypeAnnotation(NewVariableSizedType(memoryGauge, elementType)),
-		Purity:               FunctionPurityView,
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			NewConstantSizedType(
+				memoryGauge,
+				NewVariableSizedType(memoryGauge, elementType),
+				2,
+			),
+		),
+		Purity: FunctionPurityView,
 	}
 }
 
@@ -2958,6 +3717,97 @@ func ArrayMapFunctionType(memoryGauge common.MemoryGauge, arrayType ArrayType) *
 	}
 }
 
+func ArrayZipFunctionType(memoryGauge common.MemoryGauge, elementType Type) *FunctionType {
+	// fun zip<U: AnyStruct, V>(_ other: [U], _ combine: fun(T, U): V): [V]
+
+	// The elements of the other array are passed to the combine function,
+	// so they must not be resources.
+	otherTypeParameter := &TypeParameter{
+		Name:      "U",
+		TypeBound: AnyStructType,
+	}
+
+	typeU := &GenericType{
+		TypeParameter: otherTypeParameter,
+	}
+
+	resultTypeParameter := &TypeParameter{
+		Name: "V",
+	}
+
+	typeV := &GenericType{
+		TypeParameter: resultTypeParameter,
+	}
+
+	// combineFuncType: (elementType, U) -> V
+	combineFuncType := &FunctionType{
+		Parameters: []Parameter{
+			{
+				Identifier:     "element",
+				TypeAnnotation: NewTypeAnnotation(elementType),
+			},
+			{
+				Identifier:     "otherElement",
+				TypeAnnotation: NewTypeAnnotation(typeU),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(typeV),
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			otherTypeParameter,
+			resultTypeParameter,
+		},
+		Parameters: []Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "other",
+				TypeAnnotation: NewTypeAnnotation(NewVariableSizedType(memoryGauge, typeU)),
+			},
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "combine",
+				TypeAnnotation: NewTypeAnnotation(combineFuncType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(NewVariableSizedType(memoryGauge, typeV)),
+	}
+}
+
+func ArrayForEachIndexedFunctionType(elementType Type) *FunctionType {
+	const functionPurity = FunctionPurityImpure
+
+	// fun(Int, T): Void
+	funcType := NewSimpleFunctionType(
+		functionPurity,
+		[]Parameter{
+			{
+				Identifier:     "index",
+				TypeAnnotation: IntTypeAnnotation,
+			},
+			{
+				Identifier:     "element",
+				TypeAnnotation: NewTypeAnnotation(elementType),
+			},
+		},
+		VoidTypeAnnotation,
+	)
+
+	// fun forEachIndexed(_ function: fun(Int, T): Void): Void
+	return NewSimpleFunctionType(
+		functionPurity,
+		[]Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "function",
+				TypeAnnotation: NewTypeAnnotation(funcType),
+			},
+		},
+		VoidTypeAnnotation,
+	)
+}
+
 // VariableSizedType is a variable sized array type
 type VariableSizedType struct {
 	Type                Type
@@ -4332,6 +5182,12 @@ var AllIntegerTypes = common.Concat(
 	AllNonLeafIntegerTypes,
 )
 
+// IsOrderableType returns true if values of the given type can be ordered, e.g. using `<`,
+// i.e. if the type is comparable and is not a container type, like an array.
+func IsOrderableType(ty Type) bool {
+	return ty.IsComparable() && !ty.ContainFieldsOrElements()
+}
+
 var AllNumberTypes = common.Concat(
 	AllIntegerTypes,
 	AllFixedPointTypes,
@@ -4750,8 +5606,11 @@ type Conformance struct {
 }
 
 type CompositeType struct {
-	Location      common.Location
-	EnumRawType   Type
+	Location    common.Location
+	EnumRawType Type
+	// EnumCases are the names of the cases of the enum, in declaration order.
+	// Only applicable for enums declared in programs
+	EnumCases     []string
 	containerType Type
 	NestedTypes   *StringTypeOrderedMap
 
@@ -6233,6 +7092,35 @@ since no intermediate storage is allocated.
 The order of iteration is undefined
 `
 
+const DictionaryTypeSortedKeysFunctionName = "sortedKeys"
+
+const dictionaryTypeSortedKeysFunctionDocString = `
+Returns a new array with the keys of this dictionary, sorted by the given comparison function.
+
+The comparison function is called with the key and value of two entries,
+and must return true if the first entry should be ordered before the second entry.
+The sort is stable.
+Available if the key type and the value type are not resource-kinded.
+`
+
+const DictionaryTypeGetOrDefaultFunctionName = "getOrDefault"
+
+const dictionaryTypeGetOrDefaultFunctionDocString = `
+Returns the value for the given key, or the given default value if the dictionary does not contain the key.
+
+The dictionary is not modified.
+Available if the value type is not resource-kinded.
+`
+
+const DictionaryTypeGetOrInsertFunctionName = "getOrInsert"
+
+const dictionaryTypeGetOrInsertFunctionDocString = `
+Returns the value for the given key.
+If the dictionary does not contain the key, the given default value is inserted under the key first.
+
+Available if the value type is not resource-kinded.
+`
+
 const dictionaryTypeValuesFieldDocString = `
 An array containing all values of the dictionary
 `
@@ -6424,6 +7312,97 @@ func (t *DictionaryType) initializeMemberResolvers() {
 						)
 					},
 				},
+				DictionaryTypeSortedKeysFunctionName: {
+					Kind: common.DeclarationKindFunction,
+					Resolve: func(
+						memoryGauge common.MemoryGauge,
+						identifier string,
+						targetRange ast.HasPosition,
+						report func(error),
+					) *Member {
+						// The comparison function receives the values,
+						// which is impossible for resources
+
+						if t.KeyType.IsResourceType() || t.ValueType.IsResourceType() {
+							report(
+								&InvalidResourceDictionaryMemberError{
+									Name:            identifier,
+									DeclarationKind: common.DeclarationKindFunction,
+									Range:           ast.NewRangeFromPositioned(memoryGauge, targetRange),
+								},
+							)
+						}
+
+						return NewPublicFunctionMember(
+							memoryGauge,
+							t,
+							identifier,
+							DictionarySortedKeysFunctionType(t),
+							dictionaryTypeSortedKeysFunctionDocString,
+						)
+					},
+				},
+				DictionaryTypeGetOrDefaultFunctionName: {
+					Kind: common.DeclarationKindFunction,
+					Resolve: func(
+						memoryGauge common.MemoryGauge,
+						identifier string,
+						targetRange ast.HasPosition,
+						report func(error),
+					) *Member {
+						// Returning the value would leave either the stored value
+						// or the default value behind, which is impossible for resources
+
+						if t.ValueType.IsResourceType() {
+							report(
+								&InvalidResourceDictionaryMemberError{
+									Name:            identifier,
+									DeclarationKind: common.DeclarationKindFunction,
+									Range:           ast.NewRangeFromPositioned(memoryGauge, targetRange),
+								},
+							)
+						}
+
+						return NewPublicFunctionMember(
+							memoryGauge,
+							t,
+							identifier,
+							DictionaryGetOrDefaultFunctionType(t),
+							dictionaryTypeGetOrDefaultFunctionDocString,
+						)
+					},
+				},
+				DictionaryTypeGetOrInsertFunctionName: {
+					Kind: common.DeclarationKindFunction,
+					Resolve: func(
+						memoryGauge common.MemoryGauge,
+						identifier string,
+						targetRange ast.HasPosition,
+						report func(error),
+					) *Member {
+						// The value is both stored and returned,
+						// which is impossible for resources
+
+						if t.ValueType.IsResourceType() {
+							report(
+								&InvalidResourceDictionaryMemberError{
+									Name:            identifier,
+									DeclarationKind: common.DeclarationKindFunction,
+									Range:           ast.NewRangeFromPositioned(memoryGauge, targetRange),
+								},
+							)
+						}
+
+						return NewFunctionMember(
+							memoryGauge,
+							t,
+							insertMutateEntitledAccess,
+							identifier,
+							DictionaryGetOrInsertFunctionType(t),
+							dictionaryTypeGetOrInsertFunctionDocString,
+						)
+					},
+				},
 			},
 		)
 	})
@@ -6443,6 +7422,44 @@ func DictionaryContainsKeyFunctionType(t *DictionaryType) *FunctionType {
 	)
 }
 
+func DictionaryGetOrDefaultFunctionType(t *DictionaryType) *FunctionType {
+	return NewSimpleFunctionType(
+		FunctionPurityView,
+		[]Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "key",
+				TypeAnnotation: NewTypeAnnotation(t.KeyType),
+			},
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "defaultValue",
+				TypeAnnotation: NewTypeAnnotation(t.ValueType),
+			},
+		},
+		NewTypeAnnotation(t.ValueType),
+	)
+}
+
+func DictionaryGetOrInsertFunctionType(t *DictionaryType) *FunctionType {
+	return NewSimpleFunctionType(
+		FunctionPurityImpure,
+		[]Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "key",
+				TypeAnnotation: NewTypeAnnotation(t.KeyType),
+			},
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "defaultValue",
+				TypeAnnotation: NewTypeAnnotation(t.ValueType),
+			},
+		},
+		NewTypeAnnotation(t.ValueType),
+	)
+}
+
 func DictionaryInsertFunctionType(t *DictionaryType) *FunctionType {
 	return NewSimpleFunctionType(
 		FunctionPurityImpure,
@@ -6511,6 +7528,50 @@ func DictionaryForEachKeyFunctionType(t *DictionaryType) *FunctionType {
 	)
 }
 
+func DictionarySortedKeysFunctionType(t *DictionaryType) *FunctionType {
+	const functionPurity = FunctionPurityView
+
+	// fun(K, V, K, V): Bool
+	funcType := NewSimpleFunctionType(
+		functionPurity,
+		[]Parameter{
+			{
+				Identifier:     "key",
+				TypeAnnotation: NewTypeAnnotation(t.KeyType),
+			},
+			{
+				Identifier:     "value",
+				TypeAnnotation: NewTypeAnnotation(t.ValueType),
+			},
+			{
+				Identifier:     "otherKey",
+				TypeAnnotation: NewTypeAnnotation(t.KeyType),
+			},
+			{
+				Identifier:     "otherValue",
+				TypeAnnotation: NewTypeAnnotation(t.ValueType),
+			},
+		},
+		BoolTypeAnnotation,
+	)
+
+	// fun sortedKeys(by: fun(K, V, K, V): Bool): [K]
+	return NewSimpleFunctionType(
+		functionPurity,
+		[]Parameter{
+			{
+				Identifier:     "by",
+				TypeAnnotation: NewTypeAnnotation(funcType),
+			},
+		},
+		NewTypeAnnotation(
+			&VariableSizedType{
+				Type: t.KeyType,
+			},
+		),
+	)
+}
+
 func (*DictionaryType) isValueIndexableType() bool {
 	return true
 }
@@ -283,6 +283,15 @@ func NewAccountValue(
 	addressValue interpreter.AddressValue,
 ) interpreter.Value {
 
+	// NOTE: the interpreter is nil when the account value is constructed
+	// before the program is interpreted, e.g. for base activations
+	if inter != nil {
+		onAccountAccessed := inter.SharedState.Config.OnAccountAccessed
+		if onAccountAccessed != nil {
+			onAccountAccessed(inter, addressValue.ToAddress())
+		}
+	}
+
 	return interpreter.NewAccountValue(
 		inter,
 		addressValue,
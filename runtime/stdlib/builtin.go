@@ -45,6 +45,10 @@ func DefaultStandardLibraryValues(handler StandardLibraryHandler) []StandardLibr
 		SignatureAlgorithmConstructor,
 		RLPContract,
 		InclusiveRangeConstructorFunction,
+		GCDFunction,
+		LCMFunction,
+		MinFunction,
+		MaxFunction,
 		NewLogFunction(handler),
 		NewRevertibleRandomFunction(handler),
 		NewGetBlockFunction(handler),
@@ -0,0 +1,156 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// newComparableBinaryFunctionType returns the type of a function
+// which takes two comparable values of the same type T, and returns a T.
+func newComparableBinaryFunctionType() *sema.FunctionType {
+	typeParameter := &sema.TypeParameter{
+		Name: "T",
+	}
+
+	typeAnnotation := sema.NewTypeAnnotation(
+		&sema.GenericType{
+			TypeParameter: typeParameter,
+		},
+	)
+
+	return &sema.FunctionType{
+		Purity: sema.FunctionPurityView,
+		TypeParameters: []*sema.TypeParameter{
+			typeParameter,
+		},
+		Parameters: []sema.Parameter{
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "a",
+				TypeAnnotation: typeAnnotation,
+			},
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "b",
+				TypeAnnotation: typeAnnotation,
+			},
+		},
+		ReturnTypeAnnotation: typeAnnotation,
+		TypeArgumentsCheck: func(
+			memoryGauge common.MemoryGauge,
+			typeArguments *sema.TypeParameterTypeOrderedMap,
+			astTypeArguments []*ast.TypeAnnotation,
+			invocationRange ast.HasPosition,
+			report func(error),
+		) {
+			ty, ok := typeArguments.Get(typeParameter)
+			if !ok || ty == nil {
+				// Invalid, already reported by checker
+				return
+			}
+
+			// Only values of orderable types, e.g. leaf number types, can be compared
+			if sema.IsOrderableType(ty) {
+				return
+			}
+
+			// If type argument was provided, use its range otherwise fallback to invocation range.
+			errorRange := invocationRange
+			if len(astTypeArguments) > 0 {
+				errorRange = astTypeArguments[0]
+			}
+
+			report(&sema.InvalidTypeArgumentError{
+				TypeArgumentName: typeParameter.Name,
+				Range:            ast.NewRangeFromPositioned(memoryGauge, errorRange),
+				Details:          fmt.Sprintf("Type argument must be comparable, got `%s`", ty),
+			})
+		},
+	}
+}
+
+// comparableArguments returns the two comparable arguments of the invocation.
+func comparableArguments(invocation interpreter.Invocation) (a, b interpreter.ComparableValue) {
+	a, ok := invocation.Arguments[0].(interpreter.ComparableValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	b, ok = invocation.Arguments[1].(interpreter.ComparableValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return a, b
+}
+
+// MinFunction
+
+const minFunctionDocString = `
+Returns the smaller of the two given values.
+
+If the values are equal, the first value is returned.
+`
+
+var minFunctionType = newComparableBinaryFunctionType()
+
+var MinFunction = NewStandardLibraryStaticFunction(
+	"min",
+	minFunctionType,
+	minFunctionDocString,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		a, b := comparableArguments(invocation)
+
+		if b.Less(invocation.Interpreter, a, invocation.LocationRange) {
+			return b
+		}
+		return a
+	},
+)
+
+// MaxFunction
+
+const maxFunctionDocString = `
+Returns the larger of the two given values.
+
+If the values are equal, the first value is returned.
+`
+
+var maxFunctionType = newComparableBinaryFunctionType()
+
+var MaxFunction = NewStandardLibraryStaticFunction(
+	"max",
+	maxFunctionType,
+	maxFunctionDocString,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		a, b := comparableArguments(invocation)
+
+		if b.Greater(invocation.Interpreter, a, invocation.LocationRange) {
+			return b
+		}
+		return a
+	},
+)
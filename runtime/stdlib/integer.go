@@ -0,0 +1,248 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// newIntegerBinaryFunctionType returns the type of a function
+// which takes two integers of the same type T, and returns a T,
+// or an optional T if resultIsOptional is true.
+func newIntegerBinaryFunctionType(resultIsOptional bool) *sema.FunctionType {
+	typeParameter := &sema.TypeParameter{
+		Name:      "T",
+		TypeBound: sema.IntegerType,
+	}
+
+	typeAnnotation := sema.NewTypeAnnotation(
+		&sema.GenericType{
+			TypeParameter: typeParameter,
+		},
+	)
+
+	returnTypeAnnotation := typeAnnotation
+	if resultIsOptional {
+		returnTypeAnnotation = sema.NewTypeAnnotation(
+			&sema.OptionalType{
+				Type: typeAnnotation.Type,
+			},
+		)
+	}
+
+	return &sema.FunctionType{
+		Purity: sema.FunctionPurityView,
+		TypeParameters: []*sema.TypeParameter{
+			typeParameter,
+		},
+		Parameters: []sema.Parameter{
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "a",
+				TypeAnnotation: typeAnnotation,
+			},
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "b",
+				TypeAnnotation: typeAnnotation,
+			},
+		},
+		ReturnTypeAnnotation: returnTypeAnnotation,
+		TypeArgumentsCheck: func(
+			memoryGauge common.MemoryGauge,
+			typeArguments *sema.TypeParameterTypeOrderedMap,
+			astTypeArguments []*ast.TypeAnnotation,
+			invocationRange ast.HasPosition,
+			report func(error),
+		) {
+			integerType, ok := typeArguments.Get(typeParameter)
+			if !ok || integerType == nil {
+				// Invalid, already reported by checker
+				return
+			}
+
+			// integerType must only be a leaf integer type.
+			for _, ty := range sema.AllNonLeafIntegerTypes {
+				if integerType != ty {
+					continue
+				}
+
+				// If type argument was provided, use its range otherwise fallback to invocation range.
+				errorRange := invocationRange
+				if len(astTypeArguments) > 0 {
+					errorRange = astTypeArguments[0]
+				}
+
+				report(&sema.InvalidTypeArgumentError{
+					TypeArgumentName: typeParameter.Name,
+					Range:            ast.NewRangeFromPositioned(memoryGauge, errorRange),
+					Details:          fmt.Sprintf("Type argument cannot be `%s`", integerType),
+				})
+
+				break
+			}
+		},
+	}
+}
+
+// integerBigIntArguments returns the absolute values of the two integer arguments of the invocation.
+func integerBigIntArguments(invocation interpreter.Invocation) (a, b *big.Int) {
+	first, ok := invocation.Arguments[0].(interpreter.IntegerValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	second, ok := invocation.Arguments[1].(interpreter.IntegerValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	inter := invocation.Interpreter
+	locationRange := invocation.LocationRange
+
+	a = new(big.Int).Abs(interpreter.ConvertInt(inter, first, locationRange).BigInt)
+	b = new(big.Int).Abs(interpreter.ConvertInt(inter, second, locationRange).BigInt)
+
+	return a, b
+}
+
+// integerTypeArgument returns the type argument of the function's integer type parameter.
+func integerTypeArgument(invocation interpreter.Invocation) sema.Type {
+	typeParameterPair := invocation.TypeParameterTypes.Oldest()
+	if typeParameterPair == nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	return typeParameterPair.Value
+}
+
+// integerResultInRange returns true if the given result fits into the given integer type.
+func integerResultInRange(result *big.Int, integerType sema.Type) bool {
+	rangedType, ok := integerType.(sema.IntegerRangedType)
+	if !ok {
+		return true
+	}
+
+	maxInt := rangedType.MaxInt()
+	return maxInt == nil || result.Cmp(maxInt) <= 0
+}
+
+// newIntegerResult converts the given result to a value of the given integer type.
+// The result must fit into the integer type.
+func newIntegerResult(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	result *big.Int,
+	integerType sema.Type,
+) interpreter.Value {
+	intValue := interpreter.NewIntValueFromBigInt(
+		inter,
+		common.NewBigIntMemoryUsage(common.BigIntByteLength(result)),
+		func() *big.Int {
+			return result
+		},
+	)
+
+	return inter.ConvertAndBox(locationRange, intValue, sema.IntType, integerType)
+}
+
+// GCDFunction
+
+const gcdFunctionDocString = `
+Returns the greatest common divisor of the given integers.
+
+The result is always non-negative, and is zero if both integers are zero.
+Aborts if the result does not fit into the integer type,
+which is only the case for the minimum value of a signed integer type and zero,
+e.g. ` + "`gcd(Int8.min, 0)`" + `
+`
+
+var gcdFunctionType = newIntegerBinaryFunctionType(false)
+
+var GCDFunction = NewStandardLibraryStaticFunction(
+	"gcd",
+	gcdFunctionType,
+	gcdFunctionDocString,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		a, b := integerBigIntArguments(invocation)
+
+		inter := invocation.Interpreter
+		locationRange := invocation.LocationRange
+
+		result := new(big.Int).GCD(nil, nil, a, b)
+
+		integerType := integerTypeArgument(invocation)
+
+		if !integerResultInRange(result, integerType) {
+			panic(interpreter.OverflowError{
+				LocationRange: locationRange,
+			})
+		}
+
+		return newIntegerResult(inter, locationRange, result, integerType)
+	},
+)
+
+// LCMFunction
+
+const lcmFunctionDocString = `
+Returns the least common multiple of the given integers.
+
+The result is always non-negative, and is zero if either integer is zero.
+Returns nil if the result does not fit into the integer type.
+`
+
+var lcmFunctionType = newIntegerBinaryFunctionType(true)
+
+var LCMFunction = NewStandardLibraryStaticFunction(
+	"lcm",
+	lcmFunctionType,
+	lcmFunctionDocString,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		a, b := integerBigIntArguments(invocation)
+
+		inter := invocation.Interpreter
+		locationRange := invocation.LocationRange
+
+		result := new(big.Int)
+		if a.Sign() != 0 && b.Sign() != 0 {
+			gcd := new(big.Int).GCD(nil, nil, a, b)
+			result.Div(a, gcd)
+			result.Mul(result, b)
+		}
+
+		integerType := integerTypeArgument(invocation)
+
+		if !integerResultInRange(result, integerType) {
+			return interpreter.Nil
+		}
+
+		return interpreter.NewSomeValueNonCopying(
+			inter,
+			newIntegerResult(inter, locationRange, result, integerType),
+		)
+	},
+)
@@ -97,6 +97,34 @@ type RandomGenerator interface {
 	ReadRandom([]byte) error
 }
 
+// RandomHistoryRecorder is a RandomGenerator which reads from another generator,
+// and records the random bytes read, in order.
+// Test providers can use it to report the history of the random source,
+// e.g. of a seeded random source.
+type RandomHistoryRecorder struct {
+	Generator RandomGenerator
+	// History are the random bytes read, one entry per read
+	History [][]byte
+}
+
+var _ RandomGenerator = &RandomHistoryRecorder{}
+
+func (r *RandomHistoryRecorder) ReadRandom(buffer []byte) error {
+	err := r.Generator.ReadRandom(buffer)
+	if err != nil {
+		return err
+	}
+
+	r.History = append(r.History, append([]byte(nil), buffer...))
+
+	return nil
+}
+
+// Reset clears the recorded history.
+func (r *RandomHistoryRecorder) Reset() {
+	r.History = nil
+}
+
 func getRandomBytes(buffer []byte, generator RandomGenerator) {
 	var err error
 	errors.WrapPanic(func() {
@@ -328,38 +356,12 @@ func getUint64RandomNumber(
 		panic(ZeroModuloError)
 	}
 
-	// `max` is the maximum value that can be returned
-	max := modulo - 1
-	// get a bit mask (0b11..11) that covers all `max` bits,
-	// and count the byte size of `max`
-	mask := uint64(0)
-	bitSize := 0
-	for max&mask != max {
-		bitSize++
-		mask = (mask << 1) | 1
-	}
-	byteSize := (bitSize + 7) >> 3
-
-	// Generate a number less or equal than `max`.
-	// use the reject-sample method to avoid the modulo bias.
-	// the function isn't constant-time in this case and may take longer than computing
-	// a modular reduction.
-	// However, sampling exactly the size of `max` in bits makes the loop return fast:
-	// the probability of the loop running for (k) iterations is at most (1/2)^k.
-	//
-	// (a different approach would be to pull 128 bits more bits than the size of `max`
-	// from the random generator and use big number reduction by `modulo`)
-	for {
-		// only generate `byteSize` random bytes
-		getRandomBytes(buffer[bufferSize-byteSize:], generator)
-		// big endianness must be used in this case
-		random := binary.BigEndian.Uint64(buffer[:])
-		// truncate to the bit size of `max`
-		random &= mask
-		if random <= max {
-			return random
-		}
-	}
+	return interpreter.RandomUint64(
+		modulo,
+		func(buffer []byte) {
+			getRandomBytes(buffer, generator)
+		},
+	)
 }
 
 // cases of a random number of size larger than 8 bytes can be all treated
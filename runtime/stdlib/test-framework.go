@@ -33,6 +33,26 @@ type TestFramework interface {
 	EmulatorBackend() Blockchain
 
 	ReadFile(string) (string, error)
+
+	// WriteFile writes the given content to the file with the given path,
+	// which is resolved like the path of ReadFile.
+	WriteFile(path string, content string) error
+
+	// UpdateGoldenFiles returns true if golden files should be written
+	// instead of compared against, e.g. when the runner is in update mode.
+	UpdateGoldenFiles() bool
+
+	// DecodeJSONValue decodes the given JSON-Cadence encoded data
+	// and imports the decoded value into the given interpreter.
+	DecodeJSONValue(inter *interpreter.Interpreter, data []byte) (interpreter.Value, error)
+
+	// EncodeJSONValue exports the given value from the given interpreter
+	// and encodes it as JSON-Cadence.
+	EncodeJSONValue(inter *interpreter.Interpreter, value interpreter.Value) ([]byte, error)
+
+	// ReportWarning reports the given warning for the current test-case.
+	// Warnings do not fail the test-case, e.g. see ResourceLeakWarning.
+	ReportWarning(warning error)
 }
 
 type Blockchain interface {
@@ -41,8 +61,40 @@ type Blockchain interface {
 		code string, arguments []interpreter.Value,
 	) *ScriptResult
 
+	// RunScriptAtHeight runs the script against the state of the blockchain
+	// as of the committed block with the given height.
+	// The result has an error if the height is beyond the latest committed block.
+	RunScriptAtHeight(
+		inter *interpreter.Interpreter,
+		code string,
+		arguments []interpreter.Value,
+		height uint64,
+	) *ScriptResult
+
+	// RunScriptWithAccounts runs the script against the given mocked accounts,
+	// instead of the accounts of the blockchain.
+	RunScriptWithAccounts(
+		inter *interpreter.Interpreter,
+		code string,
+		arguments []interpreter.Value,
+		accounts []*MockedAccount,
+	) *ScriptResult
+
+	// CreateAccount creates a new account.
+	// Addresses must be allocated deterministically, in sequence,
+	// as generated by the address generator of the chain,
+	// so tests can refer to the addresses of created accounts, e.g. in imports.
+	// Loading a snapshot restores the address allocation state of the snapshot,
+	// so accounts created after loading it get the same addresses again.
 	CreateAccount() (*Account, error)
 
+	// CreateAccountWithKeys creates an account with one key per given weight,
+	// e.g. a weight of 500 is half of the weight required to sign a transaction.
+	// The account signs transactions with all of its keys, so a transaction fails
+	// with an authorization error if the combined weight of the keys is below 1000.
+	// The public key of the returned account is the public key of the first key
+	CreateAccountWithKeys(keyWeights []int) (*Account, error)
+
 	GetAccount(interpreter.AddressValue) (*Account, error)
 
 	AddTransaction(
@@ -53,6 +105,19 @@ type Blockchain interface {
 		arguments []interpreter.Value,
 	) error
 
+	// AddTransactionWithPayer adds the transaction to the current block like AddTransaction,
+	// but with the given proposer and payer, instead of the first signer.
+	// The fees of the transaction are charged to the payer.
+	AddTransactionWithPayer(
+		inter *interpreter.Interpreter,
+		code string,
+		authorizers []common.Address,
+		signers []*Account,
+		proposer *Account,
+		payer *Account,
+		arguments []interpreter.Value,
+	) error
+
 	ExecuteNextTransaction() *TransactionResult
 
 	CommitBlock() error
@@ -64,8 +129,71 @@ type Blockchain interface {
 		arguments []interpreter.Value,
 	) error
 
+	// DeployContractWithCost deploys the contract like DeployContract,
+	// and additionally reports the computation and storage used by the deployment.
+	DeployContractWithCost(
+		inter *interpreter.Interpreter,
+		name string,
+		path string,
+		arguments []interpreter.Value,
+	) *ContractDeploymentResult
+
+	// DeployContractWithCode deploys the given contract code to the given account,
+	// and initializes it with the given arguments.
+	// The contract can then be imported from the address of the account,
+	// e.g. in scripts and transactions
+	DeployContractWithCode(
+		inter *interpreter.Interpreter,
+		name string,
+		code string,
+		account *Account,
+		arguments []interpreter.Value,
+	) error
+
+	// ContractInitCount returns the number of times the initializer
+	// of the contract with the given name has run.
+	ContractInitCount(name string) (uint64, error)
+
+	// ContractConstant returns the value of the constant field (declared with `let`)
+	// with the given name of the deployed contract with the given name.
+	// An error is returned if the contract is not deployed,
+	// or if it has no constant field with the given name.
+	// The value must belong to the given interpreter
+	ContractConstant(inter *interpreter.Interpreter, name string, fieldName string) (interpreter.Value, error)
+
+	// CapabilityControllerCount returns the number of storage capability controllers
+	// of the account with the given address which target the given storage path.
+	CapabilityControllerCount(address common.Address, path interpreter.PathValue) (int, error)
+
+	// CapabilityControllers returns the storage capability controllers
+	// of the account with the given address which target the given storage path,
+	// ordered by capability ID.
+	CapabilityControllers(address common.Address, path interpreter.PathValue) ([]CapabilityController, error)
+
+	// AllCapabilityControllers returns all storage capability controllers
+	// of the account with the given address, ordered by capability ID.
+	// The controllers may target storage paths at which no value is stored.
+	AllCapabilityControllers(address common.Address) ([]CapabilityController, error)
+
+	// StoredValues returns the values stored in the storage domain
+	// of the account with the given address, keyed by storage path, e.g. /storage/foo.
+	// The values belong to the given interpreter
+	StoredValues(inter *interpreter.Interpreter, address common.Address) (map[string]interpreter.Value, error)
+
+	// CollectionSize returns the number of elements in the resource collection
+	// stored at the given storage path of the account with the given address.
+	// The collection must expose a `getLength` or `getIDs` function,
+	// otherwise an error is returned.
+	CollectionSize(address common.Address, path interpreter.PathValue) (int, error)
+
+	// RandomHistory returns the random bytes read from the random source
+	// by the last executed script or transaction, one entry per read, in order.
+	RandomHistory() [][]byte
+
 	Logs() []string
 
+	// ServiceAccount returns the service account of the chain,
+	// which has a well-known address, e.g. to fund created accounts.
 	ServiceAccount() (*Account, error)
 
 	Events(
@@ -77,21 +205,115 @@ type Blockchain interface {
 
 	MoveTime(int64)
 
+	// SetComputationLimit sets the computation limit for subsequently executed
+	// transactions and scripts. Executions which exceed the limit fail
+	SetComputationLimit(limit uint64)
+
+	// CurrentBlock returns the current block of the blockchain.
+	// Its timestamp reflects the time moved by MoveTime,
+	// and is kept when committing blocks
+	CurrentBlock() (Block, error)
+
 	CreateSnapshot(string) error
 
 	LoadSnapshot(string) error
+
+	StoragePathExists(address common.Address, path interpreter.PathValue) (bool, error)
+
+	// ApplyMigration applies the migration with the given name
+	// to the state of the snapshot with the given name, and updates the snapshot.
+	// It returns the storage paths of the values changed by the migration.
+	ApplyMigration(migration string, snapshot string) ([]string, error)
+
+	// GetAccountContractNames returns the names of the contracts
+	// deployed in the account with the given address.
+	GetAccountContractNames(address common.Address) ([]string, error)
+
+	// GetContractType returns the type of the deployed contract with the given name.
+	GetContractType(name string) (interpreter.StaticType, error)
+
+	// DiffSnapshots returns the stored values which differ
+	// between the snapshot with the name `before` and the snapshot with the name `after`.
+	// The keys are the hex-encoded address of the account, followed by the path,
+	// e.g. `0x0000000000000001/storage/balance`.
+	// The values are the values in the `after` snapshot, and are nil for values which were removed.
+	DiffSnapshots(
+		inter *interpreter.Interpreter,
+		before string,
+		after string,
+	) (map[string]interpreter.Value, error)
 }
 
 type ScriptResult struct {
 	Value interpreter.Value
 	Error error
+	// StorageWrites are the storage paths which were written
+	// during the execution of the script, as recorded by the blockchain backend,
+	// e.g. /storage/foo. Scripts are expected to not write to storage
+	StorageWrites []string
+	// ComputationUsed is the computation used by the script
+	ComputationUsed uint64
 }
 
 type TransactionResult struct {
 	Error error
+	// AccessedAccounts are the addresses of the accounts
+	// which were accessed during the execution of the transaction,
+	// e.g. as recorded through the interpreter's OnAccountAccessed hook
+	AccessedAccounts []common.Address
+	// Authorizers are the addresses of the accounts
+	// which authorized the transaction, in declaration order
+	Authorizers []common.Address
+	// ComputationUsed is the computation used by the transaction
+	ComputationUsed uint64
+	// ComputationBreakdown is the computation used by the transaction,
+	// broken down by kind of operation, e.g. function invocations or loop iterations.
+	// The computation of all kinds sums up to ComputationUsed
+	ComputationBreakdown map[string]uint64
+	// Index is the position of the transaction in the execution order
+	// of the transactions of its block, starting at zero
+	Index int
+	// Events are the events emitted by the transaction, in emission order.
+	// The values belong to the interpreter the transaction was added with
+	Events []interpreter.Value
+	// EventPhases are the transaction phases the events were emitted in,
+	// one entry per event, e.g. as determined by Interpreter.TransactionPhase
+	EventPhases []interpreter.TransactionPhase
+	// StorageReads is the number of storage registers read by the transaction,
+	// as counted by the storage of the blockchain backend
+	StorageReads int
+	// StorageWrites is the number of storage registers written by the transaction
+	StorageWrites int
+}
+
+type CapabilityController struct {
+	// BorrowType is the type the capability of the controller can be borrowed as
+	BorrowType   interpreter.StaticType
+	CapabilityID uint64
+	// TargetPath is the storage path targeted by the controller
+	TargetPath interpreter.PathValue
+}
+
+type ContractDeploymentResult struct {
+	Error error
+	// ComputationUsed is the computation used by the deployment
+	ComputationUsed uint64
+	// StorageUsed is the increase of the storage used by the account
+	// the contract was deployed to, in bytes
+	StorageUsed uint64
 }
 
 type Account struct {
 	PublicKey *PublicKey
 	Address   common.Address
 }
+
+type MockedAccount struct {
+	Address common.Address
+	// Balance is the balance of the account, in the fixed-point representation of UFix64
+	Balance uint64
+	// StorageUsed is the storage used by the account, in bytes
+	StorageUsed uint64
+	// Storage are the values stored in the account, keyed by storage path
+	Storage map[interpreter.PathValue]interpreter.Value
+}
@@ -19,7 +19,9 @@
 package stdlib
 
 import (
+	goerrors "errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/onflow/cadence/runtime/ast"
@@ -36,17 +38,50 @@ import (
 const testContractTypeName = "Test"
 
 const testScriptResultTypeName = "ScriptResult"
+const testTransactionTypeName = "Transaction"
 const testTransactionResultTypeName = "TransactionResult"
+const testContractDeploymentResultTypeName = "ContractDeploymentResult"
 const testResultStatusTypeName = "ResultStatus"
 const testResultStatusTypeSucceededCaseName = "succeeded"
 const testResultStatusTypeFailedCaseName = "failed"
 const testAccountTypeName = "TestAccount"
 const testErrorTypeName = "Error"
+const testErrorCategoryTypeName = "ErrorCategory"
+const testErrorCategoryTypePreConditionCaseName = "preCondition"
+const testErrorCategoryTypePostConditionCaseName = "postCondition"
 const testMatcherTypeName = "Matcher"
+const testEventFieldTypeName = "EventField"
+const testCapabilityControllerTypeName = "CapabilityController"
+const testDeprecatedValueTypeName = "DeprecatedValue"
+const testTransactionPhaseTypeName = "TransactionPhase"
 
 const accountAddressFieldName = "address"
 
+const scriptResultReturnValueFieldName = "returnValue"
+const scriptResultComputationUsedFieldName = "computationUsed"
+
+const transactionResultAccessedAccountsFieldName = "accessedAccounts"
+const transactionResultAuthorizersFieldName = "authorizers"
+const transactionResultComputationUsedFieldName = "computationUsed"
+const transactionResultComputationBreakdownFieldName = "computationBreakdown"
+const transactionResultIndexFieldName = "index"
+const transactionResultEventsFieldName = "events"
+const transactionResultEventPhasesFieldName = "eventPhases"
+const transactionResultStorageReadsFieldName = "storageReads"
+const transactionResultStorageWritesFieldName = "storageWrites"
+
+const mockedAccountBalanceFieldName = "balance"
+const mockedAccountStorageUsedFieldName = "storageUsed"
+const mockedAccountStorageFieldName = "storage"
+
+const resultErrorFieldName = "error"
+const errorMessageFieldName = "message"
+const errorCategoryFieldName = "category"
+const errorConditionMessageFieldName = "conditionMessage"
+const errorConditionLocationFieldName = "conditionLocation"
+
 const matcherTestFieldName = "test"
+const matcherExpectedFieldName = "expected"
 
 const TestContractLocation = common.IdentifierLocation(testContractTypeName)
 
@@ -183,6 +218,20 @@ func newScriptResult(
 		panic(err)
 	}
 
+	if result.ComputationUsed > 0 {
+		compositeValue, ok := scriptResult.(*interpreter.CompositeValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			scriptResultComputationUsedFieldName,
+			interpreter.NewUnmeteredUInt64Value(result.ComputationUsed),
+		)
+	}
+
 	return scriptResult
 }
 
@@ -300,6 +349,108 @@ func accountFromValue(
 	}
 }
 
+func mockedAccountsArrayValueToSlice(
+	inter *interpreter.Interpreter,
+	accountsValue interpreter.Value,
+	locationRange interpreter.LocationRange,
+) []*MockedAccount {
+
+	accountsArray, ok := accountsValue.(*interpreter.ArrayValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	accounts := make([]*MockedAccount, 0, accountsArray.Count())
+
+	accountsArray.Iterate(
+		inter,
+		func(element interpreter.Value) (resume bool) {
+			accountValue, ok := element.(interpreter.MemberAccessibleValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			accounts = append(
+				accounts,
+				mockedAccountFromValue(inter, accountValue, locationRange),
+			)
+
+			return true
+		},
+		false,
+		locationRange,
+	)
+
+	return accounts
+}
+
+func mockedAccountFromValue(
+	inter *interpreter.Interpreter,
+	accountValue interpreter.MemberAccessibleValue,
+	locationRange interpreter.LocationRange,
+) *MockedAccount {
+
+	address, ok := accountValue.GetMember(
+		inter,
+		locationRange,
+		accountAddressFieldName,
+	).(interpreter.AddressValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	balance, ok := accountValue.GetMember(
+		inter,
+		locationRange,
+		mockedAccountBalanceFieldName,
+	).(interpreter.UFix64Value)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	storageUsed, ok := accountValue.GetMember(
+		inter,
+		locationRange,
+		mockedAccountStorageUsedFieldName,
+	).(interpreter.UInt64Value)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	storageValue, ok := accountValue.GetMember(
+		inter,
+		locationRange,
+		mockedAccountStorageFieldName,
+	).(*interpreter.DictionaryValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	storage := make(map[interpreter.PathValue]interpreter.Value, storageValue.Count())
+
+	storageValue.Iterate(
+		inter,
+		locationRange,
+		func(key, value interpreter.Value) (resume bool) {
+			path, ok := key.(interpreter.PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			storage[path] = value
+
+			return true
+		},
+	)
+
+	return &MockedAccount{
+		Address:     common.Address(address),
+		Balance:     uint64(balance),
+		StorageUsed: uint64(storageUsed),
+		Storage:     storage,
+	}
+}
+
 // newTransactionResult Creates a "TransactionResult" indicating the status of the transaction execution.
 func newTransactionResult(inter *interpreter.Interpreter, result *TransactionResult) interpreter.Value {
 	// Lookup and get 'ResultStatus' enum value.
@@ -331,9 +482,257 @@ func newTransactionResult(inter *interpreter.Interpreter, result *TransactionRes
 		panic(err)
 	}
 
+	compositeValue, ok := transactionResult.(*interpreter.CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	if len(result.AccessedAccounts) > 0 {
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			transactionResultAccessedAccountsFieldName,
+			addressSliceToArrayValue(inter, result.AccessedAccounts),
+		)
+	}
+
+	if len(result.Authorizers) > 0 {
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			transactionResultAuthorizersFieldName,
+			addressSliceToArrayValue(inter, result.Authorizers),
+		)
+	}
+
+	if result.ComputationUsed > 0 {
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			transactionResultComputationUsedFieldName,
+			interpreter.NewUnmeteredUInt64Value(result.ComputationUsed),
+		)
+	}
+
+	if len(result.ComputationBreakdown) > 0 {
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			transactionResultComputationBreakdownFieldName,
+			computationBreakdownToDictionaryValue(inter, result.ComputationBreakdown),
+		)
+	}
+
+	if result.Index > 0 {
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			transactionResultIndexFieldName,
+			interpreter.NewUnmeteredIntValueFromInt64(int64(result.Index)),
+		)
+	}
+
+	if len(result.Events) > 0 {
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			transactionResultEventsFieldName,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				interpreter.NewVariableSizedStaticType(inter, interpreter.PrimitiveStaticTypeAnyStruct),
+				common.ZeroAddress,
+				result.Events...,
+			),
+		)
+	}
+
+	if len(result.EventPhases) > 0 {
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			transactionResultEventPhasesFieldName,
+			transactionPhasesToArrayValue(inter, result.EventPhases),
+		)
+	}
+
+	if result.StorageReads > 0 {
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			transactionResultStorageReadsFieldName,
+			interpreter.NewUnmeteredIntValueFromInt64(int64(result.StorageReads)),
+		)
+	}
+
+	if result.StorageWrites > 0 {
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			transactionResultStorageWritesFieldName,
+			interpreter.NewUnmeteredIntValueFromInt64(int64(result.StorageWrites)),
+		)
+	}
+
 	return transactionResult
 }
 
+func transactionPhasesToArrayValue(
+	inter *interpreter.Interpreter,
+	phases []interpreter.TransactionPhase,
+) *interpreter.ArrayValue {
+	transactionPhaseConstructor := getConstructor(inter, testTransactionPhaseTypeName)
+
+	values := make([]interpreter.Value, 0, len(phases))
+	for _, phase := range phases {
+		// The enum cases are named like the phases.
+		// Copy the enum case, as adding it to the array moves the value.
+		phaseVariable, ok := transactionPhaseConstructor.NestedVariables[phase.String()]
+		if !ok {
+			// Events are always emitted in one of the phases of the transaction
+			panic(errors.NewUnexpectedError("invalid transaction phase of event: %s", phase))
+		}
+		values = append(values, phaseVariable.GetValue(inter).Clone(inter))
+	}
+
+	// The constructor of the enum returns an optional of the enum type
+	constructorReturnType, ok := transactionPhaseConstructor.Type.ReturnTypeAnnotation.Type.(*sema.OptionalType)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	transactionPhaseType := interpreter.ConvertSemaToStaticType(
+		inter,
+		constructorReturnType.Type,
+	)
+
+	return interpreter.NewArrayValue(
+		inter,
+		interpreter.EmptyLocationRange,
+		interpreter.NewVariableSizedStaticType(inter, transactionPhaseType),
+		common.ZeroAddress,
+		values...,
+	)
+}
+
+func computationBreakdownToDictionaryValue(
+	inter *interpreter.Interpreter,
+	computationBreakdown map[string]uint64,
+) *interpreter.DictionaryValue {
+	dictionaryType := interpreter.NewDictionaryStaticType(
+		inter,
+		interpreter.PrimitiveStaticTypeString,
+		interpreter.PrimitiveStaticTypeUInt64,
+	)
+
+	kinds := make([]string, 0, len(computationBreakdown))
+	for kind := range computationBreakdown { //nolint:maprange
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	keysAndValues := make([]interpreter.Value, 0, len(kinds)*2)
+	for _, kind := range kinds {
+		keysAndValues = append(
+			keysAndValues,
+			interpreter.NewUnmeteredStringValue(kind),
+			interpreter.NewUnmeteredUInt64Value(computationBreakdown[kind]),
+		)
+	}
+
+	return interpreter.NewDictionaryValue(
+		inter,
+		interpreter.EmptyLocationRange,
+		dictionaryType,
+		keysAndValues...,
+	)
+}
+
+func stringSliceToArrayValue(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	values []string,
+) *interpreter.ArrayValue {
+	arrayType := interpreter.NewVariableSizedStaticType(
+		inter,
+		interpreter.NewPrimitiveStaticType(
+			inter,
+			interpreter.PrimitiveStaticTypeString,
+		),
+	)
+
+	elements := make([]interpreter.Value, len(values))
+	for i, str := range values {
+		memoryUsage := common.NewStringMemoryUsage(len(str))
+		elements[i] = interpreter.NewStringValue(
+			inter,
+			memoryUsage,
+			func() string {
+				return str
+			},
+		)
+	}
+
+	return interpreter.NewArrayValue(
+		inter,
+		locationRange,
+		arrayType,
+		common.ZeroAddress,
+		elements...,
+	)
+}
+
+func addressSliceToArrayValue(inter *interpreter.Interpreter, addresses []common.Address) *interpreter.ArrayValue {
+	values := make([]interpreter.Value, 0, len(addresses))
+	for _, address := range addresses {
+		values = append(values, interpreter.NewAddressValue(inter, address))
+	}
+
+	return interpreter.NewArrayValue(
+		inter,
+		interpreter.EmptyLocationRange,
+		&interpreter.VariableSizedStaticType{
+			Type: interpreter.PrimitiveStaticTypeAddress,
+		},
+		common.ZeroAddress,
+		values...,
+	)
+}
+
+func newDeploymentResult(inter *interpreter.Interpreter, result *ContractDeploymentResult) interpreter.Value {
+	// Lookup and get 'ResultStatus' enum value.
+	resultStatusConstructor := getConstructor(inter, testResultStatusTypeName)
+	var status interpreter.Value
+	if result.Error == nil {
+		succeededVar := resultStatusConstructor.NestedVariables[testResultStatusTypeSucceededCaseName]
+		status = succeededVar.GetValue(inter)
+	} else {
+		failedVar := resultStatusConstructor.NestedVariables[testResultStatusTypeFailedCaseName]
+		status = failedVar.GetValue(inter)
+	}
+
+	errValue := newErrorValue(inter, result.Error)
+
+	// Create a 'ContractDeploymentResult' by calling its constructor.
+	contractDeploymentResultConstructor := getConstructor(inter, testContractDeploymentResultTypeName)
+	deploymentResult, err := inter.InvokeExternally(
+		contractDeploymentResultConstructor,
+		contractDeploymentResultConstructor.Type,
+		[]interpreter.Value{
+			status,
+			errValue,
+			interpreter.NewUnmeteredUInt64Value(result.ComputationUsed),
+			interpreter.NewUnmeteredUInt64Value(result.StorageUsed),
+		},
+	)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return deploymentResult
+}
+
 func newErrorValue(inter *interpreter.Interpreter, err error) interpreter.Value {
 	if err == nil {
 		return interpreter.Nil
@@ -354,9 +753,78 @@ func newErrorValue(inter *interpreter.Interpreter, err error) interpreter.Value
 		panic(invocationErr)
 	}
 
+	// Report failed pre-conditions and post-conditions,
+	// e.g. of the executed script or transaction, in detail.
+	var conditionErr interpreter.ConditionError
+	if goerrors.As(err, &conditionErr) {
+		setConditionErrorMembers(inter, errorValue, conditionErr)
+	}
+
 	return errorValue
 }
 
+func setConditionErrorMembers(
+	inter *interpreter.Interpreter,
+	errorValue interpreter.Value,
+	conditionErr interpreter.ConditionError,
+) {
+	compositeValue, ok := errorValue.(*interpreter.CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	var categoryCaseName string
+	switch conditionErr.ConditionKind {
+	case ast.ConditionKindPre:
+		categoryCaseName = testErrorCategoryTypePreConditionCaseName
+	case ast.ConditionKindPost:
+		categoryCaseName = testErrorCategoryTypePostConditionCaseName
+	default:
+		return
+	}
+
+	// Copy the enum case, as setting the member moves the value.
+	errorCategoryConstructor := getConstructor(inter, testErrorCategoryTypeName)
+	category := errorCategoryConstructor.NestedVariables[categoryCaseName].GetValue(inter).Clone(inter)
+
+	compositeValue.SetMember(
+		inter,
+		interpreter.EmptyLocationRange,
+		errorCategoryFieldName,
+		category,
+	)
+
+	if conditionErr.Message != "" {
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			errorConditionMessageFieldName,
+			interpreter.NewUnmeteredSomeValueNonCopying(
+				interpreter.NewUnmeteredStringValue(conditionErr.Message),
+			),
+		)
+	}
+
+	if conditionErr.Location != nil && conditionErr.HasPosition != nil {
+		position := conditionErr.StartPosition()
+		location := fmt.Sprintf(
+			"%s:%d:%d",
+			conditionErr.Location,
+			position.Line,
+			position.Column,
+		)
+
+		compositeValue.SetMember(
+			inter,
+			interpreter.EmptyLocationRange,
+			errorConditionLocationFieldName,
+			interpreter.NewUnmeteredSomeValueNonCopying(
+				interpreter.NewUnmeteredStringValue(location),
+			),
+		)
+	}
+}
+
 // TestFailedError
 
 type TestFailedError struct {
@@ -524,3 +992,41 @@ func NewTestInterpreterContractValueHandler(
 		}
 	}
 }
+
+// ResourceLeakWarning is reported when a function returned,
+// but a resource it created was neither moved nor destroyed
+type ResourceLeakWarning struct {
+	interpreter.LocationRange
+	Identifier string
+	Type       sema.Type
+}
+
+func (w ResourceLeakWarning) Error() string {
+	return fmt.Sprintf(
+		"resource `%s` of type `%s` was neither moved nor destroyed",
+		w.Identifier,
+		w.Type.QualifiedString(),
+	)
+}
+
+// NewTestInterpreterResourceLeakHandler returns a handler for leaked resources,
+// which reports each leaked resource as a ResourceLeakWarning to the given test framework.
+//
+// Tracking leaked resources is opt-in:
+// test runners enable it by setting the handler as interpreter.Config.OnResourceLeak
+func NewTestInterpreterResourceLeakHandler(
+	testFramework TestFramework,
+) interpreter.OnResourceLeakFunc {
+	return func(
+		inter *interpreter.Interpreter,
+		locationRange interpreter.LocationRange,
+		resource interpreter.ResourceKindedValue,
+		identifier string,
+	) {
+		testFramework.ReportWarning(ResourceLeakWarning{
+			LocationRange: locationRange,
+			Identifier:    identifier,
+			Type:          inter.MustConvertStaticToSemaType(resource.StaticType(inter)),
+		})
+	}
+}
@@ -19,8 +19,19 @@
 package stdlib
 
 import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"sync/atomic"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/atree"
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
@@ -45,6 +56,14 @@ type TestContractType struct {
 	containFunction          testContractBoundFunctionGenerator
 	beLessThanFunction       testContractBoundFunctionGenerator
 	expectFailureFunction    testContractBoundFunctionGenerator
+	// assertEventSchemaFunctionType depends on the 'EventField' type
+	assertEventSchemaFunctionType *sema.FunctionType
+	// assertTransactionAtomicFunctionType depends on the 'Transaction' type
+	assertTransactionAtomicFunctionType *sema.FunctionType
+	// assertTransactionIdempotentFunctionType depends on the 'Transaction' type
+	assertTransactionIdempotentFunctionType *sema.FunctionType
+	// assertGoldenOutputFunctionType depends on the 'ScriptResult' type
+	assertGoldenOutputFunctionType *sema.FunctionType
 }
 
 type testContractBoundFunctionGenerator func(
@@ -202,215 +221,3558 @@ func testTypeAssertEqualFunction(
 	)
 }
 
-// 'Test.fail' function
+// 'Test.assertNil' and 'Test.assertNotNil' functions
 
-const testTypeFailFunctionDocString = `
-Fails the test-case with a message.
+const testTypeAssertNilFunctionDocString = `
+Fails the test-case if the given optional value is not nil.
+
+Only the outermost optional is inspected: for a nested optional,
+e.g. of type ` + "`Int??`" + `, a non-nil optional which wraps nil is not nil.
+The failure message shows the nesting of the optional, e.g. ` + "`Some(nil)`" + `.
 `
 
-const testTypeFailFunctionName = "fail"
+const testTypeAssertNilFunctionName = "assertNil"
 
-var testTypeFailFunctionType = &sema.FunctionType{
-	Purity: sema.FunctionPurityView,
+const testTypeAssertNotNilFunctionDocString = `
+Fails the test-case if the given optional value is nil.
+
+Only the outermost optional is inspected: for a nested optional,
+e.g. of type ` + "`Int??`" + `, a non-nil optional which wraps nil is not nil.
+`
+
+const testTypeAssertNotNilFunctionName = "assertNotNil"
+
+var testTypeAssertNilFunctionType = &sema.FunctionType{
 	Parameters: []sema.Parameter{
 		{
-			Identifier:     "message",
-			TypeAnnotation: sema.StringTypeAnnotation,
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "value",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.OptionalType{
+					Type: sema.AnyStructType,
+				},
+			),
 		},
 	},
-	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
-	// `message` parameter is optional
-	Arity: &sema.Arity{Min: 0, Max: 1},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
 }
 
-func testTypeFailFunction(
+var testTypeAssertNotNilFunctionType = testTypeAssertNilFunctionType
+
+// optionalArgument returns the optional argument of an invocation of 'Test.assertNil' or 'Test.assertNotNil',
+// and the number of optional layers of the argument's static type.
+//
+// An argument which is not statically an optional gets boxed into an optional when it is passed,
+// so the boxing optional is removed again.
+func optionalArgument(invocation interpreter.Invocation) (value interpreter.Value, optionalDepth int) {
+	value = invocation.Arguments[0]
+
+	argumentType := invocation.ArgumentTypes[0]
+	for {
+		optionalType, ok := argumentType.(*sema.OptionalType)
+		if !ok {
+			break
+		}
+		optionalDepth++
+		argumentType = optionalType.Type
+	}
+
+	if optionalDepth == 0 {
+		// NOTE: nil is not boxed
+		if someValue, ok := value.(*interpreter.SomeValue); ok {
+			value = someValue.InnerValue(invocation.Interpreter, invocation.LocationRange)
+		}
+	}
+
+	return value, optionalDepth
+}
+
+// optionalNestingString returns a string representation of the given value,
+// which shows the nesting of the given number of optional layers,
+// e.g. "Some(nil)" for an optional of type `Int??` which wraps nil.
+func optionalNestingString(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	value interpreter.Value,
+	optionalDepth int,
+) string {
+	var builder strings.Builder
+
+	someCount := 0
+	for someCount < optionalDepth {
+		someValue, ok := value.(*interpreter.SomeValue)
+		if !ok {
+			break
+		}
+		builder.WriteString("Some(")
+		someCount++
+		value = someValue.InnerValue(inter, locationRange)
+	}
+
+	builder.WriteString(value.String())
+	builder.WriteString(strings.Repeat(")", someCount))
+
+	return builder.String()
+}
+
+func testTypeAssertNilFunction(
 	inter *interpreter.Interpreter,
 	testContractValue *interpreter.CompositeValue,
 ) interpreter.BoundFunctionValue {
 	return interpreter.NewUnmeteredBoundHostFunctionValue(
 		inter,
 		testContractValue,
-		testTypeFailFunctionType,
+		testTypeAssertNilFunctionType,
 		func(invocation interpreter.Invocation) interpreter.Value {
-			var message string
-			if len(invocation.Arguments) > 0 {
-				messageValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
-				if !ok {
-					panic(errors.NewUnreachableError())
+			value, optionalDepth := optionalArgument(invocation)
+
+			if _, ok := value.(interpreter.NilValue); !ok {
+				var message string
+				if optionalDepth == 0 {
+					message = fmt.Sprintf(
+						"expected nil, got non-optional value: %s",
+						value,
+					)
+				} else {
+					message = fmt.Sprintf(
+						"expected nil, got: %s",
+						optionalNestingString(
+							invocation.Interpreter,
+							invocation.LocationRange,
+							value,
+							optionalDepth,
+						),
+					)
 				}
-				message = messageValue.Str
+
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: invocation.LocationRange,
+				})
 			}
 
-			panic(AssertionError{
-				Message:       message,
-				LocationRange: invocation.LocationRange,
-			})
+			return interpreter.Void
 		},
 	)
 }
 
-// 'Test.expect' function
+func testTypeAssertNotNilFunction(
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertNotNilFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			value, _ := optionalArgument(invocation)
 
-const testTypeExpectFunctionDocString = `
-Expect function tests a value against a matcher, and fails the test if it's not a match.
-`
+			if _, ok := value.(interpreter.NilValue); ok {
+				panic(AssertionError{
+					Message:       "expected non-nil value, got: nil",
+					LocationRange: invocation.LocationRange,
+				})
+			}
 
-const testTypeExpectFunctionName = "expect"
+			return interpreter.Void
+		},
+	)
+}
 
-func newTestTypeExpectFunctionType(matcherType *sema.CompositeType) *sema.FunctionType {
-	typeParameter := &sema.TypeParameter{
-		TypeBound: sema.AnyStructType,
-		Name:      "T",
-		Optional:  true,
-	}
+// 'Test.assertSameReference' function
 
-	return &sema.FunctionType{
-		TypeParameters: []*sema.TypeParameter{
-			typeParameter,
+const testTypeAssertSameReferenceFunctionDocString = `
+Fails the test-case if the given references do not refer to the same value.
+Unlike 'assertEqual', this asserts identity, not equality:
+references to distinct but equal values are not the same.
+`
+
+const testTypeAssertSameReferenceFunctionName = "assertSameReference"
+
+var testTypeAssertSameReferenceFunctionType = &sema.FunctionType{
+	Purity: sema.FunctionPurityView,
+	Parameters: []sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "expected",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.ReferenceType{
+					Type:          sema.AnyType,
+					Authorization: sema.UnauthorizedAccess,
+				},
+			),
 		},
-		Parameters: []sema.Parameter{
-			{
-				Label:      sema.ArgumentLabelNotRequired,
-				Identifier: "value",
-				TypeAnnotation: sema.NewTypeAnnotation(
-					&sema.GenericType{
-						TypeParameter: typeParameter,
-					},
-				),
-			},
-			{
-				Label:          sema.ArgumentLabelNotRequired,
-				Identifier:     "matcher",
-				TypeAnnotation: sema.NewTypeAnnotation(matcherType),
-			},
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "actual",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.ReferenceType{
+					Type:          sema.AnyType,
+					Authorization: sema.UnauthorizedAccess,
+				},
+			),
 		},
-		ReturnTypeAnnotation: sema.VoidTypeAnnotation,
-	}
+	},
+	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
 }
 
-func newTestTypeExpectFunction(functionType *sema.FunctionType) testContractBoundFunctionGenerator {
-	return func(inter *interpreter.Interpreter, testContractValue *interpreter.CompositeValue) interpreter.BoundFunctionValue {
-		return interpreter.NewUnmeteredBoundHostFunctionValue(
-			inter,
-			testContractValue,
-			functionType,
-			func(invocation interpreter.Invocation) interpreter.Value {
-				value := invocation.Arguments[0]
+func testTypeAssertSameReferenceFunction(
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertSameReferenceFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			expected, ok := invocation.Arguments[0].(interpreter.ReferenceValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
 
-				matcher, ok := invocation.Arguments[1].(*interpreter.CompositeValue)
-				if !ok {
-					panic(errors.NewUnreachableError())
-				}
+			actual, ok := invocation.Arguments[1].(interpreter.ReferenceValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
 
-				inter := invocation.Interpreter
-				locationRange := invocation.LocationRange
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
 
-				result := invokeMatcherTest(
-					inter,
-					matcher,
-					value,
-					locationRange,
+			if !interpreter.ReferencesHaveSameTarget(inter, locationRange, expected, actual) {
+				message := fmt.Sprintf(
+					"not the same reference: expected: %s, actual: %s",
+					expected,
+					actual,
 				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
 
-				if !result {
-					message := fmt.Sprintf(
-						"given value is: %s",
-						value,
-					)
-					panic(AssertionError{
-						Message:       message,
-						LocationRange: locationRange,
-					})
-				}
+			return interpreter.Void
+		},
+	)
+}
 
-				return interpreter.Void
-			},
-		)
-	}
+// 'Test.assertAllMoved' function
+
+const testTypeAssertAllMovedFunctionDocString = `
+Fails the test-case if any of the resources the given references refer to
+was neither moved nor destroyed, e.g. after a batch transfer,
+reporting the indices and types of the remaining resources.
+
+The resources are inspected through the references, they are not moved.
+`
+
+const testTypeAssertAllMovedFunctionName = "assertAllMoved"
+
+var testTypeAssertAllMovedFunctionType = &sema.FunctionType{
+	Purity: sema.FunctionPurityView,
+	Parameters: []sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "references",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.VariableSizedType{
+					Type: &sema.ReferenceType{
+						Type:          sema.AnyResourceType,
+						Authorization: sema.UnauthorizedAccess,
+					},
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
 }
 
-func invokeMatcherTest(
+func testTypeAssertAllMovedFunction(
 	inter *interpreter.Interpreter,
-	matcher interpreter.MemberAccessibleValue,
-	value interpreter.Value,
-	locationRange interpreter.LocationRange,
-) bool {
-	testFunc := matcher.GetMember(
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
 		inter,
-		locationRange,
-		matcherTestFieldName,
-	)
+		testContractValue,
+		testTypeAssertAllMovedFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			references, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
 
-	funcValue, ok := testFunc.(interpreter.FunctionValue)
-	if !ok {
-		panic(errors.NewUnexpectedError(
-			"invalid type for '%s'. expected function",
-			matcherTestFieldName,
-		))
-	}
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
 
-	functionType := funcValue.FunctionType()
+			var remaining []string
 
-	testResult, err := inter.InvokeExternally(
-		funcValue,
-		functionType,
-		[]interpreter.Value{
-			value,
+			// NOTE: Elements are accessed by index instead of iterating,
+			// as iteration fails for references to moved or destroyed resources
+			count := references.Count()
+			for index := 0; index < count; index++ {
+				var referencedValue interpreter.Value
+
+				switch reference := references.Get(inter, locationRange, index).(type) {
+				case *interpreter.EphemeralReferenceValue:
+					if reference.IsInvalidated(inter) {
+						continue
+					}
+					referencedValue = reference.Value
+
+				case *interpreter.StorageReferenceValue:
+					// A reference to storage, e.g. a borrowed reference,
+					// refers to whatever is stored at the path.
+					// The resource was moved if the path is empty,
+					// or now stores a value of an unrelated type
+					storedValue := reference.ReferencedValue(inter, locationRange, false)
+					if storedValue == nil {
+						continue
+					}
+					referencedValue = *storedValue
+
+				default:
+					panic(errors.NewUnreachableError())
+				}
+
+				remaining = append(
+					remaining,
+					fmt.Sprintf(
+						"%d (%s)",
+						index,
+						inter.MustConvertStaticToSemaType(referencedValue.StaticType(inter)).QualifiedString(),
+					),
+				)
+			}
+
+			if len(remaining) > 0 {
+				message := fmt.Sprintf(
+					"%d of %d resources were not moved: %s",
+					len(remaining),
+					count,
+					strings.Join(remaining, ", "),
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			return interpreter.Void
 		},
 	)
+}
+
+// 'Test.describe' function
+
+const testTypeDescribeFunctionDocString = `
+Returns a description of the resource the given reference refers to,
+which maps the names of its fields to their values, e.g. for debugging.
+
+The resource is inspected through the reference, it is not moved.
+Nested resources are described recursively,
+and all other values are copied.
+`
+
+const testTypeDescribeFunctionName = "describe"
+
+var testTypeDescribeFunctionType = &sema.FunctionType{
+	Purity: sema.FunctionPurityView,
+	Parameters: []sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "resource",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.ReferenceType{
+					Type:          sema.AnyResourceType,
+					Authorization: sema.UnauthorizedAccess,
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		&sema.DictionaryType{
+			KeyType:   sema.StringType,
+			ValueType: sema.AnyStructType,
+		},
+	),
+}
+
+func testTypeDescribeFunction(
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeDescribeFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			reference, ok := invocation.Arguments[0].(interpreter.ReferenceValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			referencedValue := *reference.ReferencedValue(inter, locationRange, true)
+
+			composite, ok := referencedValue.(*interpreter.CompositeValue)
+			if !ok {
+				panic(errors.NewDefaultUserError(
+					"cannot describe value of type %s: expected a composite resource",
+					inter.MustConvertStaticToSemaType(referencedValue.StaticType(inter)).QualifiedString(),
+				))
+			}
+
+			return describeComposite(inter, locationRange, composite)
+		},
+	)
+}
+
+// describeComposite returns a dictionary which maps the field names of the given composite
+// to the descriptions of the field values.
+func describeComposite(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	composite *interpreter.CompositeValue,
+) *interpreter.DictionaryValue {
+
+	var fieldNames []string
+	composite.ForEachFieldName(func(fieldName string) (resume bool) {
+		fieldNames = append(fieldNames, fieldName)
+		return true
+	})
+	sort.Strings(fieldNames)
+
+	keysAndValues := make([]interpreter.Value, 0, len(fieldNames)*2)
+	for _, fieldName := range fieldNames {
+		keysAndValues = append(
+			keysAndValues,
+			interpreter.NewUnmeteredStringValue(fieldName),
+			describeValue(inter, locationRange, composite.GetField(inter, locationRange, fieldName)),
+		)
+	}
+
+	return interpreter.NewDictionaryValue(
+		inter,
+		locationRange,
+		interpreter.NewDictionaryStaticType(
+			inter,
+			interpreter.PrimitiveStaticTypeString,
+			interpreter.PrimitiveStaticTypeAnyStruct,
+		),
+		keysAndValues...,
+	)
+}
+
+// describeValue returns a description of the given value, without moving it.
+//
+// Resources are described recursively: composites become dictionaries of their fields,
+// and arrays, dictionaries, and optionals of resources become
+// arrays, dictionaries, and optionals of the descriptions of their elements.
+// All other values are copied.
+func describeValue(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	value interpreter.Value,
+) interpreter.Value {
+
+	if !value.IsResourceKinded(inter) {
+		return value.Transfer(
+			inter,
+			locationRange,
+			atree.Address{},
+			false,
+			nil,
+			nil,
+			false,
+		)
+	}
+
+	switch value := value.(type) {
+	case *interpreter.CompositeValue:
+		return describeComposite(inter, locationRange, value)
+
+	case *interpreter.ArrayValue:
+		var elements []interpreter.Value
+		value.Iterate(
+			inter,
+			func(element interpreter.Value) (resume bool) {
+				elements = append(elements, describeValue(inter, locationRange, element))
+				return true
+			},
+			false,
+			locationRange,
+		)
+
+		return interpreter.NewArrayValue(
+			inter,
+			locationRange,
+			interpreter.NewVariableSizedStaticType(
+				inter,
+				interpreter.PrimitiveStaticTypeAnyStruct,
+			),
+			common.ZeroAddress,
+			elements...,
+		)
+
+	case *interpreter.DictionaryValue:
+		var keysAndValues []interpreter.Value
+		value.Iterate(
+			inter,
+			locationRange,
+			func(key, value interpreter.Value) (resume bool) {
+				keysAndValues = append(
+					keysAndValues,
+					describeValue(inter, locationRange, key),
+					describeValue(inter, locationRange, value),
+				)
+				return true
+			},
+		)
+
+		return interpreter.NewDictionaryValue(
+			inter,
+			locationRange,
+			interpreter.NewDictionaryStaticType(
+				inter,
+				value.Type.KeyType,
+				interpreter.PrimitiveStaticTypeAnyStruct,
+			),
+			keysAndValues...,
+		)
+
+	case *interpreter.SomeValue:
+		return interpreter.NewUnmeteredSomeValueNonCopying(
+			describeValue(inter, locationRange, value.InnerValue(inter, locationRange)),
+		)
+
+	default:
+		panic(errors.NewUnexpectedError("cannot describe resource value of type %T", value))
+	}
+}
+
+// 'Test.assertEventEquals' function
+
+const testTypeAssertEventEqualsFunctionDocString = `
+Fails the test-case if the given event is not of the given type,
+or if its fields are not exactly the given fields.
+
+Unlike matching a subset of the fields, all fields of the event must be given,
+and any missing, unexpected, or differing field is reported.
+`
+
+const testTypeAssertEventEqualsFunctionName = "assertEventEquals"
+
+var testTypeAssertEventEqualsFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "event",
+			TypeAnnotation: sema.AnyStructTypeAnnotation,
+		},
+		{
+			Identifier:     "type",
+			TypeAnnotation: sema.MetaTypeAnnotation,
+		},
+		{
+			Identifier: "fields",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.DictionaryType{
+					KeyType:   sema.StringType,
+					ValueType: sema.AnyStructType,
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+func testTypeAssertEventEqualsFunction(
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertEventEqualsFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			event, ok := invocation.Arguments[0].(*interpreter.CompositeValue)
+			if !ok || event.Kind != common.CompositeKindEvent {
+				message := fmt.Sprintf(
+					"not an event: %s",
+					invocation.Arguments[0],
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			typeValue, ok := invocation.Arguments[1].(interpreter.TypeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			expectedFields, ok := invocation.Arguments[2].(*interpreter.DictionaryValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			expectedType := typeValue.Type
+			actualType := event.StaticType(inter)
+			if expectedType == nil || !expectedType.Equal(actualType) {
+				message := fmt.Sprintf(
+					"not equal event types: expected: %s, actual: %s",
+					expectedType,
+					actualType,
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			var differences []string
+
+			event.ForEachField(
+				inter,
+				func(fieldName string, actualValue interpreter.Value) (resume bool) {
+					expectedValue, ok := expectedFields.Get(
+						inter,
+						locationRange,
+						interpreter.NewUnmeteredStringValue(fieldName),
+					)
+					if !ok {
+						differences = append(
+							differences,
+							fmt.Sprintf("unexpected field `%s`: %s", fieldName, actualValue),
+						)
+						return true
+					}
+
+					equatableValue, ok := expectedValue.(interpreter.EquatableValue)
+					if !ok || !equatableValue.Equal(inter, locationRange, actualValue) {
+						differences = append(
+							differences,
+							fmt.Sprintf(
+								"not equal field `%s`: expected: %s, actual: %s",
+								fieldName,
+								expectedValue,
+								actualValue,
+							),
+						)
+					}
+
+					return true
+				},
+				locationRange,
+			)
+
+			expectedFields.IterateKeys(
+				inter,
+				locationRange,
+				func(key interpreter.Value) (resume bool) {
+					fieldName, ok := key.(*interpreter.StringValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+
+					if event.GetField(inter, locationRange, fieldName.Str) == nil {
+						differences = append(
+							differences,
+							fmt.Sprintf("missing field `%s`", fieldName.Str),
+						)
+					}
+
+					return true
+				},
+			)
+
+			if len(differences) > 0 {
+				message := fmt.Sprintf(
+					"not equal event %s: %s",
+					actualType,
+					strings.Join(differences, ", "),
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'Test.eventField' function
+
+const testTypeEventFieldFunctionDocString = `
+Returns the value of the field with the given name of the given event,
+cast to the type argument.
+
+Fails the test-case if the given value is not an event,
+if the event has no field with the given name,
+or if the value of the field is not of the type argument.
+`
+
+const testTypeEventFieldFunctionName = "eventField"
+
+var testTypeEventFieldFunctionType = func() *sema.FunctionType {
+	typeParameter := &sema.TypeParameter{
+		TypeBound: sema.AnyStructType,
+		Name:      "T",
+	}
+
+	return &sema.FunctionType{
+		Purity: sema.FunctionPurityView,
+		TypeParameters: []*sema.TypeParameter{
+			typeParameter,
+		},
+		Parameters: []sema.Parameter{
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "event",
+				TypeAnnotation: sema.AnyStructTypeAnnotation,
+			},
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "name",
+				TypeAnnotation: sema.StringTypeAnnotation,
+			},
+		},
+		ReturnTypeAnnotation: sema.NewTypeAnnotation(
+			&sema.GenericType{
+				TypeParameter: typeParameter,
+			},
+		),
+	}
+}()
+
+func testTypeEventFieldFunction(
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeEventFieldFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			event, ok := invocation.Arguments[0].(*interpreter.CompositeValue)
+			if !ok || event.Kind != common.CompositeKindEvent {
+				message := fmt.Sprintf(
+					"not an event: %s",
+					invocation.Arguments[0],
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			name, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			typeParameterPair := invocation.TypeParameterTypes.Oldest()
+			if typeParameterPair == nil {
+				panic(errors.NewUnreachableError())
+			}
+
+			expectedType := typeParameterPair.Value
+			eventType := event.StaticType(inter)
+
+			value := event.GetField(inter, locationRange, name.Str)
+			if value == nil {
+				message := fmt.Sprintf(
+					"event %s has no field `%s`",
+					eventType,
+					name.Str,
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			valueType := value.StaticType(inter)
+			if !inter.IsSubTypeOfSemaType(valueType, expectedType) {
+				message := fmt.Sprintf(
+					"field `%s` of event %s has type %s, expected type %s",
+					name.Str,
+					eventType,
+					valueType,
+					expectedType.QualifiedString(),
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			return value.Transfer(
+				inter,
+				locationRange,
+				atree.Address{},
+				false,
+				nil,
+				nil,
+				false,
+			)
+		},
+	)
+}
+
+// 'Test.assertCapabilityType' function
+
+const testTypeAssertCapabilityTypeFunctionDocString = `
+Fails the test-case if the borrow type of the given capability
+is not the given type.
+`
+
+const testTypeAssertCapabilityTypeFunctionName = "assertCapabilityType"
+
+var testTypeAssertCapabilityTypeFunctionType = &sema.FunctionType{
+	Purity: sema.FunctionPurityView,
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "capability",
+			TypeAnnotation: sema.NewTypeAnnotation(&sema.CapabilityType{}),
+		},
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "type",
+			TypeAnnotation: sema.MetaTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+}
+
+func testTypeAssertCapabilityTypeFunction(
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertCapabilityTypeFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			var borrowType interpreter.StaticType
+
+			switch capability := invocation.Arguments[0].(type) {
+			case *interpreter.IDCapabilityValue:
+				borrowType = capability.BorrowType
+			case *interpreter.PathCapabilityValue: //nolint:staticcheck
+				borrowType = capability.BorrowType
+			default:
+				panic(errors.NewUnreachableError())
+			}
+
+			typeValue, ok := invocation.Arguments[1].(interpreter.TypeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			expectedType := typeValue.Type
+
+			var expectedTypeID, actualTypeID interpreter.TypeID
+			if expectedType != nil {
+				expectedTypeID = expectedType.ID()
+			}
+			if borrowType != nil {
+				actualTypeID = borrowType.ID()
+			}
+
+			if expectedTypeID != actualTypeID {
+				message := fmt.Sprintf(
+					"capability borrow type mismatch: expected: %s, actual: %s",
+					expectedTypeID,
+					actualTypeID,
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: invocation.LocationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'Test.fail' function
+
+const testTypeFailFunctionDocString = `
+Fails the test-case with a message.
+`
+
+const testTypeFailFunctionName = "fail"
+
+var testTypeFailFunctionType = &sema.FunctionType{
+	Purity: sema.FunctionPurityView,
+	Parameters: []sema.Parameter{
+		{
+			Identifier:     "message",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+	// `message` parameter is optional
+	Arity: &sema.Arity{Min: 0, Max: 1},
+}
+
+func testTypeFailFunction(
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeFailFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			var message string
+			if len(invocation.Arguments) > 0 {
+				messageValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				message = messageValue.Str
+			}
+
+			panic(AssertionError{
+				Message:       message,
+				LocationRange: invocation.LocationRange,
+			})
+		},
+	)
+}
+
+// 'Test.expect' function
+
+const testTypeExpectFunctionDocString = `
+Expect function tests a value against a matcher, and fails the test if it's not a match.
+`
+
+const testTypeExpectFunctionName = "expect"
+
+func newTestTypeExpectFunctionType(matcherType *sema.CompositeType) *sema.FunctionType {
+	typeParameter := &sema.TypeParameter{
+		TypeBound: sema.AnyStructType,
+		Name:      "T",
+		Optional:  true,
+	}
+
+	return &sema.FunctionType{
+		TypeParameters: []*sema.TypeParameter{
+			typeParameter,
+		},
+		Parameters: []sema.Parameter{
+			{
+				Label:      sema.ArgumentLabelNotRequired,
+				Identifier: "value",
+				TypeAnnotation: sema.NewTypeAnnotation(
+					&sema.GenericType{
+						TypeParameter: typeParameter,
+					},
+				),
+			},
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "matcher",
+				TypeAnnotation: sema.NewTypeAnnotation(matcherType),
+			},
+		},
+		ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+	}
+}
+
+func newTestTypeExpectFunction(functionType *sema.FunctionType) testContractBoundFunctionGenerator {
+	return func(inter *interpreter.Interpreter, testContractValue *interpreter.CompositeValue) interpreter.BoundFunctionValue {
+		return interpreter.NewUnmeteredBoundHostFunctionValue(
+			inter,
+			testContractValue,
+			functionType,
+			func(invocation interpreter.Invocation) interpreter.Value {
+				value := invocation.Arguments[0]
+
+				matcher, ok := invocation.Arguments[1].(*interpreter.CompositeValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				inter := invocation.Interpreter
+				locationRange := invocation.LocationRange
+
+				result := invokeMatcherTest(
+					inter,
+					matcher,
+					value,
+					locationRange,
+				)
+
+				if !result {
+					message := fmt.Sprintf(
+						"given value is: %s",
+						value,
+					)
+
+					expected := matcher.GetMember(inter, locationRange, matcherExpectedFieldName)
+					if someExpected, ok := expected.(*interpreter.SomeValue); ok {
+						expectedString, ok := someExpected.InnerValue(inter, locationRange).(*interpreter.StringValue)
+						if !ok {
+							panic(errors.NewUnreachableError())
+						}
+						message = fmt.Sprintf("%s, expected: %s", message, expectedString.Str)
+					}
+
+					panic(AssertionError{
+						Message:       message,
+						LocationRange: locationRange,
+					})
+				}
+
+				return interpreter.Void
+			},
+		)
+	}
+}
+
+func invokeMatcherTest(
+	inter *interpreter.Interpreter,
+	matcher interpreter.MemberAccessibleValue,
+	value interpreter.Value,
+	locationRange interpreter.LocationRange,
+) bool {
+	testFunc := matcher.GetMember(
+		inter,
+		locationRange,
+		matcherTestFieldName,
+	)
+
+	funcValue, ok := testFunc.(interpreter.FunctionValue)
+	if !ok {
+		panic(errors.NewUnexpectedError(
+			"invalid type for '%s'. expected function",
+			matcherTestFieldName,
+		))
+	}
+
+	functionType := funcValue.FunctionType()
+
+	testResult, err := inter.InvokeExternally(
+		funcValue,
+		functionType,
+		[]interpreter.Value{
+			value,
+		},
+	)
+
+	if err != nil {
+		panic(err)
+	}
+
+	result, ok := testResult.(interpreter.BoolValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return bool(result)
+}
+
+// 'Test.readFile' function
+
+const testTypeReadFileFunctionDocString = `
+Read a local file, and return the content as a string.
+`
+
+const testTypeReadFileFunctionName = "readFile"
+
+var testTypeReadFileFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "path",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.StringTypeAnnotation,
+}
+
+func newTestTypeReadFileFunction(
+	testFramework TestFramework,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeReadFileFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			pathString, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			content, err := testFramework.ReadFile(pathString.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			return interpreter.NewUnmeteredStringValue(content)
+		},
+	)
+}
+
+// 'Test.loadFixture' function
+
+const testTypeLoadFixtureFunctionDocString = `
+Loads the fixture with the given name, and returns the decoded value.
+
+The fixture is read like ` + "`readFile`" + `, and must contain a JSON-Cadence encoded value.
+Fails the test-case if the fixture cannot be read or decoded.
+`
+
+const testTypeLoadFixtureFunctionName = "loadFixture"
+
+var testTypeLoadFixtureFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "name",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.AnyStructTypeAnnotation,
+}
+
+func newTestTypeLoadFixtureFunction(
+	testFramework TestFramework,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeLoadFixtureFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			content, err := testFramework.ReadFile(name.Str)
+			if err != nil {
+				panic(errors.NewDefaultUserError(
+					"cannot read fixture `%s`: %s",
+					name.Str,
+					err,
+				))
+			}
+
+			value, err := testFramework.DecodeJSONValue(invocation.Interpreter, []byte(content))
+			if err != nil {
+				panic(errors.NewDefaultUserError(
+					"cannot decode fixture `%s`: %s",
+					name.Str,
+					err,
+				))
+			}
+
+			return value
+		},
+	)
+}
+
+// 'Test.assertStorageMigration' function
+
+const testTypeAssertStorageMigrationFunctionDocString = `
+Fails the test-case if the stored values which differ between the two given snapshots
+are not exactly the expected changes.
+
+The expected changes are keyed by the hex-encoded address of the account followed by the path,
+e.g. ` + "`0x0000000000000001/storage/balance`" + `,
+and are the values in the ` + "`after`" + ` snapshot, or nil for values which were removed.
+Any unexpected, missing, or differing change is reported.
+`
+
+const testTypeAssertStorageMigrationFunctionName = "assertStorageMigration"
+
+var testTypeAssertStorageMigrationFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Identifier:     "before",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+		{
+			Identifier:     "after",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+		{
+			Identifier: "expectedChanges",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.DictionaryType{
+					KeyType: sema.StringType,
+					ValueType: &sema.OptionalType{
+						Type: sema.AnyStructType,
+					},
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+func newTestTypeAssertStorageMigrationFunction(
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertStorageMigrationFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			before, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			after, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			expectedChanges, ok := invocation.Arguments[2].(*interpreter.DictionaryValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			actualChanges, err := blockchain.DiffSnapshots(inter, before.Str, after.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			changeString := func(value interpreter.Value) string {
+				if value == nil {
+					return "removed"
+				}
+				return value.String()
+			}
+
+			var differences []string
+
+			// Report unexpected and differing changes in a deterministic order
+
+			paths := make([]string, 0, len(actualChanges))
+			for path := range actualChanges { //nolint:maprange
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+
+			for _, path := range paths {
+				actualValue := actualChanges[path]
+
+				expectedValue, ok := expectedChanges.Get(
+					inter,
+					locationRange,
+					interpreter.NewUnmeteredStringValue(path),
+				)
+				if !ok {
+					differences = append(
+						differences,
+						fmt.Sprintf("unexpected change of `%s`: %s", path, changeString(actualValue)),
+					)
+					continue
+				}
+
+				// The expected value is nil if the value is expected to be removed
+				switch expectedValue := expectedValue.(type) {
+				case interpreter.NilValue:
+					if actualValue == nil {
+						continue
+					}
+
+				case *interpreter.SomeValue:
+					innerValue := expectedValue.InnerValue(inter, locationRange)
+					equatableValue, ok := innerValue.(interpreter.EquatableValue)
+					if actualValue != nil &&
+						ok &&
+						equatableValue.Equal(inter, locationRange, actualValue) {
+
+						continue
+					}
+
+				default:
+					panic(errors.NewUnreachableError())
+				}
+
+				differences = append(
+					differences,
+					fmt.Sprintf(
+						"not equal change of `%s`: expected: %s, actual: %s",
+						path,
+						expectedValue,
+						changeString(actualValue),
+					),
+				)
+			}
+
+			expectedChanges.IterateKeys(
+				inter,
+				locationRange,
+				func(key interpreter.Value) (resume bool) {
+					path, ok := key.(*interpreter.StringValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+
+					if _, ok := actualChanges[path.Str]; !ok {
+						differences = append(
+							differences,
+							fmt.Sprintf("missing change of `%s`", path.Str),
+						)
+					}
+
+					return true
+				},
+			)
+
+			if len(differences) > 0 {
+				message := fmt.Sprintf(
+					"storage migration changes are not as expected: %s",
+					strings.Join(differences, ", "),
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'Test.assertConformance' function
+
+const testTypeAssertConformanceFunctionDocString = `
+Fails the test-case if the deployed contract with the given name
+does not conform to the given contract interface type, e.g. ` + "`Type<{I}>()`" + `.
+If the type is an intersection of multiple interfaces,
+the contract must conform to all of them.
+`
+
+const testTypeAssertConformanceFunctionName = "assertConformance"
+
+var testTypeAssertConformanceFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "contractName",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "interfaceType",
+			TypeAnnotation: sema.MetaTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+func newTestTypeAssertConformanceFunction(
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertConformanceFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			contractName, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			typeValue, ok := invocation.Arguments[1].(interpreter.TypeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			// Interfaces can only be used in intersection types, e.g. `{I}`
+
+			var interfaceTypes []*sema.InterfaceType
+			if typeValue.Type != nil {
+				switch ty := inter.MustConvertStaticToSemaType(typeValue.Type).(type) {
+				case *sema.IntersectionType:
+					interfaceTypes = ty.Types
+				case *sema.InterfaceType:
+					interfaceTypes = []*sema.InterfaceType{ty}
+				}
+			}
+
+			isContractInterfaceType := len(interfaceTypes) > 0
+			for _, interfaceType := range interfaceTypes {
+				if interfaceType.CompositeKind != common.CompositeKindContract {
+					isContractInterfaceType = false
+					break
+				}
+			}
+			if !isContractInterfaceType {
+				panic(errors.NewDefaultUserError(
+					"expected contract interface type, got `%s`",
+					typeValue.Type,
+				))
+			}
+
+			contractStaticType, err := blockchain.GetContractType(contractName.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			contractType, ok := inter.MustConvertStaticToSemaType(contractStaticType).(*sema.CompositeType)
+			if !ok || contractType.Kind != common.CompositeKindContract {
+				panic(errors.NewUnexpectedError(
+					"type of contract `%s` is not a contract type: %s",
+					contractName.Str,
+					contractStaticType,
+				))
+			}
+
+			conformances := contractType.EffectiveInterfaceConformanceSet()
+
+			for _, interfaceType := range interfaceTypes {
+				if !conformances.Contains(interfaceType) {
+					message := fmt.Sprintf(
+						"contract `%s` does not conform to interface `%s`",
+						contractName.Str,
+						interfaceType.QualifiedString(),
+					)
+					panic(AssertionError{
+						Message:       message,
+						LocationRange: invocation.LocationRange,
+					})
+				}
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'Test.assertEventCountInRange' function
+
+const testTypeAssertEventCountInRangeFunctionDocString = `
+Fails the test-case if the number of events of the given type emitted from the blockchain
+is not in the given inclusive range. If max is nil, the range has no upper bound.
+`
+
+const testTypeAssertEventCountInRangeFunctionName = "assertEventCountInRange"
+
+var testTypeAssertEventCountInRangeFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "type",
+			TypeAnnotation: sema.MetaTypeAnnotation,
+		},
+		{
+			Identifier:     "min",
+			TypeAnnotation: sema.IntTypeAnnotation,
+		},
+		{
+			Identifier: "max",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.OptionalType{
+					Type: sema.IntType,
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+func newTestTypeAssertEventCountInRangeFunction(
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertEventCountInRangeFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			typeValue, ok := invocation.Arguments[0].(interpreter.TypeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			minValue, ok := invocation.Arguments[1].(interpreter.IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			minCount := minValue.ToInt(locationRange)
+
+			maxCount := -1
+			switch maxValue := invocation.Arguments[2].(type) {
+			case interpreter.NilValue:
+				// No upper bound
+			case *interpreter.SomeValue:
+				innerValue, ok := maxValue.InnerValue(inter, locationRange).(interpreter.IntValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				maxCount = innerValue.ToInt(locationRange)
+			default:
+				panic(errors.NewUnreachableError())
+			}
+
+			if minCount < 0 {
+				panic(errors.NewDefaultUserError(
+					"invalid event count range: min must not be negative, got %d",
+					minCount,
+				))
+			}
+
+			if maxCount >= 0 && minCount > maxCount {
+				panic(errors.NewDefaultUserError(
+					"invalid event count range: min %d is greater than max %d",
+					minCount,
+					maxCount,
+				))
+			}
+
+			events, ok := blockchain.Events(inter, typeValue.Type).(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			count := events.Count()
+
+			if count < minCount || (maxCount >= 0 && count > maxCount) {
+				var message string
+				if maxCount >= 0 {
+					message = fmt.Sprintf(
+						"expected between %d and %d events of type %s, got %d",
+						minCount,
+						maxCount,
+						typeValue.Type,
+						count,
+					)
+				} else {
+					message = fmt.Sprintf(
+						"expected at least %d events of type %s, got %d",
+						minCount,
+						typeValue.Type,
+						count,
+					)
+				}
+
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'Test.assertContractRemoved' function
+
+const testTypeAssertContractRemovedFunctionDocString = `
+Fails the test-case if a contract with the given name is still deployed
+in the account with the given address.
+`
+
+const testTypeAssertContractRemovedFunctionName = "assertContractRemoved"
+
+var testTypeAssertContractRemovedFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "address",
+			TypeAnnotation: sema.AddressTypeAnnotation,
+		},
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "name",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+func newTestTypeAssertContractRemovedFunction(
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertContractRemovedFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			contractName, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			names, err := blockchain.GetAccountContractNames(common.Address(address))
+			if err != nil {
+				panic(err)
+			}
+
+			for _, name := range names {
+				if name == contractName.Str {
+					message := fmt.Sprintf(
+						"contract `%s` is still deployed in account %s",
+						contractName.Str,
+						address,
+					)
+					panic(AssertionError{
+						Message:       message,
+						LocationRange: invocation.LocationRange,
+					})
+				}
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'Test.assertEventJSON' function
+
+const testTypeAssertEventJSONFunctionDocString = `
+Fails the test-case if no event of the given type emitted from the blockchain
+encodes to the given JSON-Cadence value.
+
+The JSON is compared after normalization, so formatting and the order of object keys do not matter.
+`
+
+const testTypeAssertEventJSONFunctionName = "assertEventJSON"
+
+var testTypeAssertEventJSONFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "type",
+			TypeAnnotation: sema.MetaTypeAnnotation,
+		},
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "expectedJSON",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+func newTestTypeAssertEventJSONFunction(
+	testFramework TestFramework,
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertEventJSONFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			typeValue, ok := invocation.Arguments[0].(interpreter.TypeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			expectedJSONValue, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			expectedJSON, err := normalizeJSON([]byte(expectedJSONValue.Str))
+			if err != nil {
+				panic(errors.NewDefaultUserError(
+					"invalid expected JSON: %s",
+					err,
+				))
+			}
+
+			events, ok := blockchain.Events(inter, typeValue.Type).(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			var found bool
+			var actualJSONs []string
+
+			events.Iterate(
+				inter,
+				func(event interpreter.Value) (resume bool) {
+					encoded, err := testFramework.EncodeJSONValue(inter, event)
+					if err != nil {
+						panic(errors.NewDefaultUserError(
+							"cannot encode event of type %s: %s",
+							typeValue.Type,
+							err,
+						))
+					}
+
+					actualJSON, err := normalizeJSON(encoded)
+					if err != nil {
+						panic(errors.NewUnexpectedErrorFromCause(err))
+					}
+
+					actualJSONs = append(actualJSONs, actualJSON)
+
+					found = actualJSON == expectedJSON
+
+					return !found
+				},
+				false,
+				locationRange,
+			)
+
+			if found {
+				return interpreter.Void
+			}
+
+			var message string
+			if len(actualJSONs) == 0 {
+				message = fmt.Sprintf(
+					"no events of type %s were emitted",
+					typeValue.Type,
+				)
+			} else {
+				message = fmt.Sprintf(
+					"no event of type %s encodes to the expected JSON: expected: %s, actual: [%s]",
+					typeValue.Type,
+					expectedJSON,
+					strings.Join(actualJSONs, ", "),
+				)
+			}
+
+			panic(AssertionError{
+				Message:       message,
+				LocationRange: locationRange,
+			})
+		},
+	)
+}
+
+// normalizeJSON re-encodes the given JSON compactly, with sorted object keys.
+func normalizeJSON(data []byte) (string, error) {
+	var value any
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return "", err
+	}
+
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(normalized), nil
+}
+
+// 'Test.assertStorageEmpty' function
+
+const testTypeAssertStorageEmptyFunctionDocString = `
+Fails the test-case if the storage of the account with the given address
+holds values which are not in the snapshot with the given name,
+i.e. values which were stored or changed since the baseline snapshot was created.
+
+The current state is captured in a snapshot named ` + "`assertStorageEmpty.<n>`" + `,
+where ` + "`<n>`" + ` is unique for each call.
+`
+
+const testTypeAssertStorageEmptyFunctionName = "assertStorageEmpty"
+
+const storageLeakCheckSnapshotPrefix = "assertStorageEmpty"
+
+var testTypeAssertStorageEmptyFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "address",
+			TypeAnnotation: sema.AddressTypeAnnotation,
+		},
+		{
+			Identifier:     "baseline",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+func newTestTypeAssertStorageEmptyFunction(
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertStorageEmptyFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			baseline, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			current := createCheckSnapshot(blockchain, storageLeakCheckSnapshotPrefix)
+
+			changes, err := blockchain.DiffSnapshots(inter, baseline.Str, current)
+			if err != nil {
+				panic(err)
+			}
+
+			// Changes are keyed by the address and the storage path.
+			// Values which were removed since the baseline are not leaks.
+
+			accountPrefix := common.Address(address).HexWithPrefix()
+
+			var paths []string
+			for key, value := range changes { //nolint:maprange
+				if value == nil || !strings.HasPrefix(key, accountPrefix+"/") {
+					continue
+				}
+				paths = append(paths, strings.TrimPrefix(key, accountPrefix))
+			}
+
+			if len(paths) == 0 {
+				return interpreter.Void
+			}
+
+			sort.Strings(paths)
+
+			panic(AssertionError{
+				Message: fmt.Sprintf(
+					"storage of account %s is not empty: unexpected values at %s",
+					address,
+					strings.Join(paths, ", "),
+				),
+				LocationRange: invocation.LocationRange,
+			})
+		},
+	)
+}
+
+// 'Test.assertFieldsEqual' function
+
+const testTypeAssertFieldsEqualFunctionDocString = `
+Fails the test-case if the resources referenced by the given references
+are not of the same type, or if any of their fields are not equal.
+
+The ` + "`uuid`" + ` field is ignored, also for nested resources,
+so two distinct resources with equal field values are considered equal.
+`
+
+const testTypeAssertFieldsEqualFunctionName = "assertFieldsEqual"
+
+var anyResourceReferenceTypeAnnotation = sema.NewTypeAnnotation(
+	&sema.ReferenceType{
+		Type:          sema.AnyResourceType,
+		Authorization: sema.UnauthorizedAccess,
+	},
+)
+
+var testTypeAssertFieldsEqualFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "expected",
+			TypeAnnotation: anyResourceReferenceTypeAnnotation,
+		},
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "actual",
+			TypeAnnotation: anyResourceReferenceTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+func newTestTypeAssertFieldsEqualFunction(
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertFieldsEqualFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			referencedComposite := func(argument interpreter.Value) *interpreter.CompositeValue {
+				reference, ok := argument.(interpreter.ReferenceValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				referencedValue := *reference.ReferencedValue(inter, locationRange, true)
+
+				composite, ok := referencedValue.(*interpreter.CompositeValue)
+				if !ok {
+					panic(AssertionError{
+						Message:       fmt.Sprintf("not a composite resource: %s", referencedValue),
+						LocationRange: locationRange,
+					})
+				}
+
+				return composite
+			}
+
+			expected := referencedComposite(invocation.Arguments[0])
+			actual := referencedComposite(invocation.Arguments[1])
+
+			expectedType := expected.StaticType(inter)
+			actualType := actual.StaticType(inter)
+			if !expectedType.Equal(actualType) {
+				message := fmt.Sprintf(
+					"not equal types: expected: %s, actual: %s",
+					expectedType,
+					actualType,
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			differences := compositeFieldDifferences(inter, locationRange, "", expected, actual)
+			if len(differences) > 0 {
+				message := fmt.Sprintf(
+					"not equal fields of %s: %s",
+					actualType,
+					strings.Join(differences, ", "),
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// compositeFieldDifferences returns the differences between the fields
+// of the given composites of the same type, ignoring the UUIDs of resources.
+// Nested resources are compared field by field.
+// The differences are sorted by field name.
+func compositeFieldDifferences(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	prefix string,
+	expected *interpreter.CompositeValue,
+	actual *interpreter.CompositeValue,
+) []string {
+	var fieldNames []string
+	expected.ForEachFieldName(func(fieldName string) (resume bool) {
+		if fieldName != sema.ResourceUUIDFieldName {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		return true
+	})
+	sort.Strings(fieldNames)
+
+	var differences []string
+
+	for _, fieldName := range fieldNames {
+		qualifiedFieldName := prefix + fieldName
+
+		expectedValue := expected.GetField(inter, locationRange, fieldName)
+		actualValue := actual.GetField(inter, locationRange, fieldName)
+
+		expectedComposite, ok := expectedValue.(*interpreter.CompositeValue)
+		if ok && expectedComposite.IsResourceKinded(inter) {
+			actualComposite, ok := actualValue.(*interpreter.CompositeValue)
+			if ok && expectedComposite.StaticType(inter).Equal(actualComposite.StaticType(inter)) {
+				differences = append(
+					differences,
+					compositeFieldDifferences(
+						inter,
+						locationRange,
+						qualifiedFieldName+".",
+						expectedComposite,
+						actualComposite,
+					)...,
+				)
+				continue
+			}
+		}
+
+		equatableValue, ok := expectedValue.(interpreter.EquatableValue)
+		if !ok || !equatableValue.Equal(inter, locationRange, actualValue) {
+			differences = append(
+				differences,
+				fmt.Sprintf(
+					"not equal field `%s`: expected: %s, actual: %s",
+					qualifiedFieldName,
+					expectedValue,
+					actualValue,
+				),
+			)
+		}
+	}
+
+	return differences
+}
+
+// 'Test.assertStableEncoding' function
+
+const testTypeAssertStableEncodingFunctionDocString = `
+Fails the test-case if the storage encoding of the given value
+is not equal to the given expected bytes.
+
+The value is encoded like it would be when stored in an account,
+in a fresh storage, so the encoding only depends on the value.
+This exposes the internal storage encoding, which is only intended
+to be used to detect changes to the encoding, e.g. across versions.
+`
+
+const testTypeAssertStableEncodingFunctionName = "assertStableEncoding"
+
+var testTypeAssertStableEncodingFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "value",
+			TypeAnnotation: sema.AnyStructTypeAnnotation,
+		},
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "expected",
+			TypeAnnotation: sema.ByteArrayTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+func newTestTypeAssertStableEncodingFunction(
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertStableEncodingFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			value := invocation.Arguments[0]
+
+			expected, err := interpreter.ByteArrayValueToByteSlice(
+				inter,
+				invocation.Arguments[1],
+				locationRange,
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			actual, err := encodeStorageValue(inter, locationRange, value)
+			if err != nil {
+				panic(errors.NewDefaultUserError("cannot encode value %s: %s", value, err))
+			}
+
+			if !bytes.Equal(expected, actual) {
+				message := fmt.Sprintf(
+					"not equal encoding: expected: %x, actual: %x",
+					expected,
+					actual,
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// stableEncodingAddress is the address the value is stored in
+// when producing its storage encoding.
+var stableEncodingAddress = common.MustBytesToAddress([]byte{0x1})
+
+// encodeStorageValue returns the storage encoding of the given value.
+//
+// The value is copied into a new, empty storage, so the slab IDs,
+// and the hash seeds of composites and dictionaries derived from them,
+// only depend on the value.
+// Values which are not containers are encoded as storables.
+// Containers are encoded as the concatenation of the encodings
+// of all slabs of the value, in slab ID order.
+func encodeStorageValue(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	value interpreter.Value,
+) ([]byte, error) {
+
+	storage := interpreter.NewInMemoryStorage(nil)
+
+	encodingInter, err := interpreter.NewInterpreter(
+		nil,
+		inter.Location,
+		&interpreter.Config{
+			Storage: storage,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	storedValue := copyValueForStableEncoding(inter, encodingInter, locationRange, value)
+
+	var buffer bytes.Buffer
+
+	if storage.Count() == 0 {
+		storable, err := storedValue.Storable(
+			storage,
+			atree.Address(stableEncodingAddress),
+			math.MaxUint64,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := atree.NewEncoder(&buffer, interpreter.CBOREncMode)
+		err = storable.Encode(encoder)
+		if err != nil {
+			return nil, err
+		}
+
+		err = encoder.CBOR.Flush()
+		if err != nil {
+			return nil, err
+		}
+
+		return buffer.Bytes(), nil
+	}
+
+	encodedSlabs, err := storage.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	slabIDs := make([]atree.SlabID, 0, len(encodedSlabs))
+	for slabID := range encodedSlabs {
+		slabIDs = append(slabIDs, slabID)
+	}
+	sort.Slice(slabIDs, func(i, j int) bool {
+		return slabIDs[i].Compare(slabIDs[j]) < 0
+	})
+
+	for _, slabID := range slabIDs {
+		buffer.Write(encodedSlabs[slabID])
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// copyValueForStableEncoding copies the given value of the given interpreter
+// into the storage of the encoding interpreter.
+//
+// Composites, dictionaries, and arrays are re-created instead of transferred,
+// as transferring keeps the hash seeds of the original values,
+// which depend on the slabs previously allocated in the original storage.
+func copyValueForStableEncoding(
+	inter *interpreter.Interpreter,
+	encodingInter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	value interpreter.Value,
+) interpreter.Value {
+
+	copyValue := func(value interpreter.Value) interpreter.Value {
+		return copyValueForStableEncoding(inter, encodingInter, locationRange, value)
+	}
+
+	switch value := value.(type) {
+	case *interpreter.CompositeValue:
+		var fieldNames []string
+		value.ForEachFieldName(func(fieldName string) (resume bool) {
+			fieldNames = append(fieldNames, fieldName)
+			return true
+		})
+		sort.Strings(fieldNames)
+
+		fields := make([]interpreter.CompositeField, 0, len(fieldNames))
+		for _, fieldName := range fieldNames {
+			fields = append(
+				fields,
+				interpreter.NewUnmeteredCompositeField(
+					fieldName,
+					copyValue(value.GetField(inter, locationRange, fieldName)),
+				),
+			)
+		}
+
+		return interpreter.NewCompositeValue(
+			encodingInter,
+			locationRange,
+			value.Location,
+			value.QualifiedIdentifier,
+			value.Kind,
+			fields,
+			stableEncodingAddress,
+		)
+
+	case *interpreter.DictionaryValue:
+		var keysAndValues []interpreter.Value
+		value.Iterate(
+			inter,
+			locationRange,
+			func(key, value interpreter.Value) (resume bool) {
+				keysAndValues = append(keysAndValues, copyValue(key), copyValue(value))
+				return true
+			},
+		)
+
+		return interpreter.NewDictionaryValueWithAddress(
+			encodingInter,
+			locationRange,
+			value.Type,
+			stableEncodingAddress,
+			keysAndValues...,
+		)
+
+	case *interpreter.ArrayValue:
+		var elements []interpreter.Value
+		value.Iterate(
+			inter,
+			func(element interpreter.Value) (resume bool) {
+				elements = append(elements, copyValue(element))
+				return true
+			},
+			false,
+			locationRange,
+		)
+
+		return interpreter.NewArrayValue(
+			encodingInter,
+			locationRange,
+			value.Type,
+			stableEncodingAddress,
+			elements...,
+		)
+
+	case *interpreter.SomeValue:
+		return interpreter.NewUnmeteredSomeValueNonCopying(
+			copyValue(value.InnerValue(inter, locationRange)),
+		)
+
+	default:
+		return value.Transfer(
+			encodingInter,
+			locationRange,
+			atree.Address(stableEncodingAddress),
+			false,
+			nil,
+			nil,
+			true, // value is only copied for the encoding.
+		)
+	}
+}
+
+// 'Test.assertEventSchema' function
+
+const testTypeAssertEventSchemaFunctionDocString = `
+Fails the test-case if the event with the given name,
+declared in the deployed contract with the given name,
+does not have exactly the given fields, with the given types, in the given order.
+`
+
+const testTypeAssertEventSchemaFunctionName = "assertEventSchema"
+
+const eventFieldNameFieldName = "name"
+const eventFieldTypeFieldName = "type"
+
+func newTestTypeAssertEventSchemaFunctionType(eventFieldType *sema.CompositeType) *sema.FunctionType {
+	return &sema.FunctionType{
+		Parameters: []sema.Parameter{
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "contractName",
+				TypeAnnotation: sema.StringTypeAnnotation,
+			},
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "eventName",
+				TypeAnnotation: sema.StringTypeAnnotation,
+			},
+			{
+				Label:      sema.ArgumentLabelNotRequired,
+				Identifier: "fields",
+				TypeAnnotation: sema.NewTypeAnnotation(
+					&sema.VariableSizedType{
+						Type: eventFieldType,
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: sema.NewTypeAnnotation(
+			sema.VoidType,
+		),
+	}
+}
+
+func newTestTypeAssertEventSchemaFunction(
+	functionType *sema.FunctionType,
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		functionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			contractName, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			eventName, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			fieldsValue, ok := invocation.Arguments[2].(*interpreter.ArrayValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			contractStaticType, err := blockchain.GetContractType(contractName.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			contractType, ok := inter.MustConvertStaticToSemaType(contractStaticType).(*sema.CompositeType)
+			if !ok || contractType.Kind != common.CompositeKindContract {
+				panic(errors.NewUnexpectedError(
+					"type of contract `%s` is not a contract type: %s",
+					contractName.Str,
+					contractStaticType,
+				))
+			}
+
+			nestedType, ok := contractType.NestedTypes.Get(eventName.Str)
+			eventType, isComposite := nestedType.(*sema.CompositeType)
+			if !ok || !isComposite || eventType.Kind != common.CompositeKindEvent {
+				message := fmt.Sprintf(
+					"contract `%s` has no event `%s`",
+					contractName.Str,
+					eventName.Str,
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			// The fields of an event are its parameters
+
+			var actualNames []string
+			var actualTypes []sema.Type
+			for _, parameter := range eventType.ConstructorParameters {
+				actualNames = append(actualNames, parameter.Identifier)
+				actualTypes = append(actualTypes, parameter.TypeAnnotation.Type)
+			}
+
+			var expectedNames []string
+			var expectedTypes []sema.Type
+			fieldsValue.Iterate(
+				inter,
+				func(element interpreter.Value) (resume bool) {
+					field, ok := element.(*interpreter.CompositeValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+
+					name, ok := field.GetField(inter, locationRange, eventFieldNameFieldName).(*interpreter.StringValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+
+					typeValue, ok := field.GetField(inter, locationRange, eventFieldTypeFieldName).(interpreter.TypeValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+
+					var fieldType sema.Type = sema.InvalidType
+					if typeValue.Type != nil {
+						fieldType = inter.MustConvertStaticToSemaType(typeValue.Type)
+					}
+
+					expectedNames = append(expectedNames, name.Str)
+					expectedTypes = append(expectedTypes, fieldType)
+
+					return true
+				},
+				false,
+				locationRange,
+			)
+
+			matches := len(expectedNames) == len(actualNames)
+			for i := 0; matches && i < len(expectedNames); i++ {
+				matches = expectedNames[i] == actualNames[i] &&
+					expectedTypes[i].Equal(actualTypes[i])
+			}
+
+			if !matches {
+				message := fmt.Sprintf(
+					"event `%s` does not match schema: expected: %s, actual: %s",
+					eventType.QualifiedString(),
+					formatEventSchema(expectedNames, expectedTypes),
+					formatEventSchema(actualNames, actualTypes),
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// formatEventSchema formats the given event fields like the parameter list of an event declaration,
+// e.g. `(amount: UFix64, to: Address?)`.
+func formatEventSchema(names []string, types []sema.Type) string {
+	var builder strings.Builder
+	builder.WriteByte('(')
+	for i, name := range names {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(name)
+		builder.WriteString(": ")
+		builder.WriteString(types[i].QualifiedString())
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// 'Test.assertTotalSupplyUnchanged' function
+
+const testTypeAssertTotalSupplyUnchangedFunctionDocString = `
+Reads the ` + "`totalSupply`" + ` field of the deployed contract with the given name,
+calls the given function, and fails the test-case if the total supply changed.
+`
+
+const testTypeAssertTotalSupplyUnchangedFunctionName = "assertTotalSupplyUnchanged"
+
+var testTypeAssertTotalSupplyUnchangedFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "contractName",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+		{
+			Identifier: "during",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.FunctionType{
+					ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+}
+
+const totalSupplyScriptTemplate = `
+import "%[1]s"
+
+access(all)
+fun main(): AnyStruct {
+    return %[1]s.totalSupply
+}
+`
+
+func newTestTypeAssertTotalSupplyUnchangedFunction(
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertTotalSupplyUnchangedFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			contractName, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			during, ok := invocation.Arguments[1].(interpreter.FunctionValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			script := fmt.Sprintf(totalSupplyScriptTemplate, contractName.Str)
+
+			readTotalSupply := func() interpreter.Value {
+				result := blockchain.RunScript(inter, script, nil)
+				if result.Error != nil {
+					panic(errors.NewDefaultUserError(
+						"failed to read the total supply of contract `%s`: %s",
+						contractName.Str,
+						result.Error.Error(),
+					))
+				}
+				return result.Value
+			}
+
+			before := readTotalSupply()
+
+			_, err := inter.InvokeExternally(
+				during,
+				during.FunctionType(),
+				nil,
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			after := readTotalSupply()
+
+			equatableValue, ok := before.(interpreter.EquatableValue)
+			if !ok {
+				panic(errors.NewDefaultUserError(
+					"total supply of contract `%s` is not equatable: %s",
+					contractName.Str,
+					before.StaticType(inter),
+				))
+			}
+
+			if !equatableValue.Equal(inter, locationRange, after) {
+				message := fmt.Sprintf(
+					"total supply of contract `%s` changed: before: %s, after: %s",
+					contractName.Str,
+					before,
+					after,
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'Test.hashValue' function
+
+const testTypeHashValueFunctionDocString = `
+Returns the SHA3-256 hash of the storage encoding of the given value.
+
+The value is encoded like by ` + "`assertStableEncoding`" + `, so equal values have equal hashes,
+independent of how they were created, and the hash can be used to key values in tests.
+`
+
+const testTypeHashValueFunctionName = "hashValue"
+
+var testTypeHashValueFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "value",
+			TypeAnnotation: sema.AnyStructTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.ByteArrayTypeAnnotation,
+}
+
+func newTestTypeHashValueFunction(
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeHashValueFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			value := invocation.Arguments[0]
+
+			encoded, err := encodeStorageValue(inter, locationRange, value)
+			if err != nil {
+				panic(errors.NewDefaultUserError("cannot encode value %s: %s", value, err))
+			}
+
+			hash := sha3.Sum256(encoded)
+
+			return interpreter.ByteSliceToByteArrayValue(inter, hash[:])
+		},
+	)
+}
+
+// 'Test.assertFailsAt' function
+
+const testTypeAssertFailsAtFunctionDocString = `
+Calls the given function, and fails the test-case if the function does not fail,
+or if the failure did not originate at the given line of the program with the given location,
+e.g. ` + "`A.0000000000000001.FooContract`" + ` for a contract imported by the test.
+`
+
+const testTypeAssertFailsAtFunctionName = "assertFailsAt"
+
+var testTypeAssertFailsAtFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "function",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.FunctionType{
+					ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+				},
+			),
+		},
+		{
+			Identifier:     "location",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+		{
+			Identifier:     "line",
+			TypeAnnotation: sema.IntTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+}
+
+func newTestTypeAssertFailsAtFunction(
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertFailsAtFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			functionValue, ok := invocation.Arguments[0].(interpreter.FunctionValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			expectedLocation, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			lineValue, ok := invocation.Arguments[2].(interpreter.IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			expectedLine := lineValue.ToInt(locationRange)
+
+			err := func() (err error) {
+				defer inter.RecoverErrors(func(internalErr error) {
+					err = internalErr
+				})
+
+				_, err = inter.InvokeExternally(
+					functionValue,
+					functionValue.FunctionType(),
+					nil,
+				)
+				return
+			}()
+			if err == nil {
+				message := fmt.Sprintf(
+					"expected a failure at %s:%d, but found none",
+					expectedLocation.Str,
+					expectedLine,
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			location, line, ok := errorOrigin(err)
+			if !ok {
+				message := fmt.Sprintf(
+					"expected a failure at %s:%d, but the failure has no location: %s",
+					expectedLocation.Str,
+					expectedLine,
+					err.Error(),
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			if location.ID() != expectedLocation.Str || line != expectedLine {
+				message := fmt.Sprintf(
+					"expected a failure at %s:%d, but it failed at %s:%d",
+					expectedLocation.Str,
+					expectedLine,
+					location.ID(),
+					line,
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// errorOrigin returns the location and the line of the innermost error
+// of the given interpreter error, i.e. where the failure originated
+func errorOrigin(err error) (location common.Location, line int, ok bool) {
+	var interpreterErr interpreter.Error
+	for goerrors.As(err, &interpreterErr) {
+		err = interpreterErr.Err
+	}
+
+	var locatedErr interface {
+		common.HasLocation
+		ast.HasPosition
+	}
+	if !goerrors.As(err, &locatedErr) {
+		return nil, 0, false
+	}
+
+	location = locatedErr.ImportLocation()
+	if location == nil {
+		return nil, 0, false
+	}
+
+	return location, locatedErr.StartPosition().Line, true
+}
+
+// 'Test.assertScriptReadOnly' function
+
+const testTypeAssertScriptReadOnlyFunctionDocString = `
+Executes the given script, and fails the test-case if the script fails,
+or if the script wrote to storage.
+`
+
+const testTypeAssertScriptReadOnlyFunctionName = "assertScriptReadOnly"
+
+var testTypeAssertScriptReadOnlyFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "script",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+}
+
+func newTestTypeAssertScriptReadOnlyFunction(
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertScriptReadOnlyFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			script, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			result := blockchain.RunScript(inter, script.Str, nil)
+			if result.Error != nil {
+				panic(AssertionError{
+					Message:       fmt.Sprintf("script failed: %s", result.Error.Error()),
+					LocationRange: locationRange,
+				})
+			}
+
+			if len(result.StorageWrites) > 0 {
+				message := fmt.Sprintf(
+					"script is not read-only: wrote to: %s",
+					strings.Join(result.StorageWrites, ", "),
+				)
+				panic(AssertionError{
+					Message:       message,
+					LocationRange: locationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// checkSnapshotCount is the number of snapshots created by snapshot-based assertions,
+// and is used to give each snapshot a unique name
+var checkSnapshotCount atomic.Uint64
+
+// createCheckSnapshot creates a snapshot of the blockchain with a unique name,
+// which starts with the given prefix, and returns the name.
+// Snapshots of previous assertions are not overwritten,
+// so they can still be inspected after the assertion failed
+func createCheckSnapshot(blockchain Blockchain, prefix string) string {
+	name := fmt.Sprintf("%s.%d", prefix, checkSnapshotCount.Add(1))
+
+	err := blockchain.CreateSnapshot(name)
+	if err != nil {
+		panic(err)
+	}
+
+	return name
+}
+
+// executeTransaction adds the given transaction, executes it, and commits the block
+func executeTransaction(
+	inter *interpreter.Interpreter,
+	blockchain Blockchain,
+	code string,
+	authorizers []common.Address,
+	signerAccounts []*Account,
+	args []interpreter.Value,
+) *TransactionResult {
+	err := blockchain.AddTransaction(
+		inter,
+		code,
+		authorizers,
+		signerAccounts,
+		args,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	result := blockchain.ExecuteNextTransaction()
+	if result == nil {
+		panic(errors.NewUnexpectedError("transaction was not executed"))
+	}
+
+	err = blockchain.CommitBlock()
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+// diffSnapshotPaths returns the sorted storage paths of the values
+// which differ between the snapshots with the given names
+func diffSnapshotPaths(
+	inter *interpreter.Interpreter,
+	blockchain Blockchain,
+	before string,
+	after string,
+) []string {
+	changes, err := blockchain.DiffSnapshots(inter, before, after)
+	if err != nil {
+		panic(err)
+	}
+
+	paths := make([]string, 0, len(changes))
+	for path := range changes { //nolint:maprange
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// 'Test.assertTransactionAtomic' function
+
+const testTypeAssertTransactionAtomicFunctionDocString = `
+Executes the given transaction, which is expected to fail, and commits the block.
+Fails the test-case if the transaction succeeds,
+or if any of the storage changes of the transaction persisted.
+
+The state before and after the transaction is captured in snapshots named
+` + "`assertTransactionAtomic.before.<n>`" + ` and ` + "`assertTransactionAtomic.after.<n>`" + `,
+where ` + "`<n>`" + ` is unique for each call.
+`
+
+const testTypeAssertTransactionAtomicFunctionName = "assertTransactionAtomic"
+
+const transactionAtomicityCheckBeforeSnapshotPrefix = "assertTransactionAtomic.before"
+const transactionAtomicityCheckAfterSnapshotPrefix = "assertTransactionAtomic.after"
+
+func newTestTypeAssertTransactionAtomicFunctionType(transactionType *sema.CompositeType) *sema.FunctionType {
+	return &sema.FunctionType{
+		Parameters: []sema.Parameter{
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "tx",
+				TypeAnnotation: sema.NewTypeAnnotation(transactionType),
+			},
+		},
+		ReturnTypeAnnotation: sema.NewTypeAnnotation(
+			sema.VoidType,
+		),
+	}
+}
+
+func newTestTypeAssertTransactionAtomicFunction(
+	functionType *sema.FunctionType,
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		functionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			code, authorizers, signerAccounts, args := transactionFromValue(
+				inter,
+				invocation.Arguments[0],
+				locationRange,
+			)
+
+			before := createCheckSnapshot(blockchain, transactionAtomicityCheckBeforeSnapshotPrefix)
+
+			result := executeTransaction(
+				inter,
+				blockchain,
+				code,
+				authorizers,
+				signerAccounts,
+				args,
+			)
+			if result.Error == nil {
+				panic(AssertionError{
+					Message:       "expected the transaction to fail, but it succeeded",
+					LocationRange: locationRange,
+				})
+			}
+
+			after := createCheckSnapshot(blockchain, transactionAtomicityCheckAfterSnapshotPrefix)
+
+			paths := diffSnapshotPaths(inter, blockchain, before, after)
+			if len(paths) == 0 {
+				return interpreter.Void
+			}
+
+			panic(AssertionError{
+				Message: fmt.Sprintf(
+					"transaction is not atomic: changes of the failed transaction persisted: %s",
+					strings.Join(paths, ", "),
+				),
+				LocationRange: locationRange,
+			})
+		},
+	)
+}
+
+// 'Test.assertTransactionIdempotent' function
+
+const testTypeAssertTransactionIdempotentFunctionDocString = `
+Executes the given transaction twice, each time in a new block.
+Fails the test-case if the first execution fails,
+or if the second execution changed any stored values.
+
+The transaction is idempotent if executing it again, right after it was executed,
+has no effect on storage: the second execution either succeeds without changing any stored values,
+or it fails, e.g. because of a guard, and its changes are reverted.
+
+The state after the first and second execution is captured in snapshots named
+` + "`assertTransactionIdempotent.once.<n>`" + ` and ` + "`assertTransactionIdempotent.twice.<n>`" + `,
+where ` + "`<n>`" + ` is unique for each call.
+`
+
+const testTypeAssertTransactionIdempotentFunctionName = "assertTransactionIdempotent"
+
+const transactionIdempotencyCheckOnceSnapshotPrefix = "assertTransactionIdempotent.once"
+const transactionIdempotencyCheckTwiceSnapshotPrefix = "assertTransactionIdempotent.twice"
+
+func newTestTypeAssertTransactionIdempotentFunctionType(transactionType *sema.CompositeType) *sema.FunctionType {
+	return &sema.FunctionType{
+		Parameters: []sema.Parameter{
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "tx",
+				TypeAnnotation: sema.NewTypeAnnotation(transactionType),
+			},
+		},
+		ReturnTypeAnnotation: sema.NewTypeAnnotation(
+			sema.VoidType,
+		),
+	}
+}
+
+func newTestTypeAssertTransactionIdempotentFunction(
+	functionType *sema.FunctionType,
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		functionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			code, authorizers, signerAccounts, args := transactionFromValue(
+				inter,
+				invocation.Arguments[0],
+				locationRange,
+			)
+
+			execute := func() *TransactionResult {
+				return executeTransaction(
+					inter,
+					blockchain,
+					code,
+					authorizers,
+					signerAccounts,
+					args,
+				)
+			}
+
+			result := execute()
+			if result.Error != nil {
+				panic(AssertionError{
+					Message:       fmt.Sprintf("transaction failed: %s", result.Error.Error()),
+					LocationRange: locationRange,
+				})
+			}
+
+			once := createCheckSnapshot(blockchain, transactionIdempotencyCheckOnceSnapshotPrefix)
+
+			// The result of the second execution is irrelevant:
+			// a failed execution is reverted, so it has no effect on storage
+
+			execute()
+
+			twice := createCheckSnapshot(blockchain, transactionIdempotencyCheckTwiceSnapshotPrefix)
+
+			paths := diffSnapshotPaths(inter, blockchain, once, twice)
+			if len(paths) == 0 {
+				return interpreter.Void
+			}
+
+			panic(AssertionError{
+				Message: fmt.Sprintf(
+					"transaction is not idempotent: second execution changed: %s",
+					strings.Join(paths, ", "),
+				),
+				LocationRange: locationRange,
+			})
+		},
+	)
+}
+
+// 'Test.assertMigrationIdempotent' function
+
+const testTypeAssertMigrationIdempotentFunctionDocString = `
+Applies the migration with the given name twice to the state of the
+snapshot with the given name, and fails the test-case if the second
+application changed any values, reporting the changed storage paths.
+The snapshot is updated.
+`
+
+const testTypeAssertMigrationIdempotentFunctionName = "assertMigrationIdempotent"
+
+var testTypeAssertMigrationIdempotentFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "migration",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+		{
+			Identifier:     "snapshot",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+}
+
+func newTestTypeAssertMigrationIdempotentFunction(
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertMigrationIdempotentFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			migration, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			snapshot, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			_, err := blockchain.ApplyMigration(migration.Str, snapshot.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			paths, err := blockchain.ApplyMigration(migration.Str, snapshot.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			if len(paths) == 0 {
+				return interpreter.Void
+			}
+
+			sort.Strings(paths)
+
+			panic(AssertionError{
+				Message: fmt.Sprintf(
+					"migration is not idempotent: second application changed: %s",
+					strings.Join(paths, ", "),
+				),
+				LocationRange: invocation.LocationRange,
+			})
+		},
+	)
+}
+
+// 'Test.assertMigrationsCommute' function
+
+const testTypeAssertMigrationsCommuteFunctionDocString = `
+Applies the two migrations with the given names to copies of the snapshot with the given name,
+once in the order ` + "`migrationA`" + ` then ` + "`migrationB`" + `, and once in the reverse order.
+Fails the test-case if the resulting states differ, reporting the differing storage paths.
+
+The copies are snapshots named
+` + "`assertMigrationsCommute.ab.<n>`" + ` and ` + "`assertMigrationsCommute.ba.<n>`" + `,
+where ` + "`<n>`" + ` is unique for each call. The given snapshot and the current state are left unchanged.
+`
+
+const testTypeAssertMigrationsCommuteFunctionName = "assertMigrationsCommute"
+
+const migrationsCommuteCheckCurrentSnapshotPrefix = "assertMigrationsCommute.current"
+const migrationsCommuteCheckABSnapshotPrefix = "assertMigrationsCommute.ab"
+const migrationsCommuteCheckBASnapshotPrefix = "assertMigrationsCommute.ba"
+
+var testTypeAssertMigrationsCommuteFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "migrationA",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "migrationB",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+		{
+			Identifier:     "snapshot",
+			TypeAnnotation: sema.StringTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+}
+
+func newTestTypeAssertMigrationsCommuteFunction(
+	blockchain Blockchain,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		testTypeAssertMigrationsCommuteFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			migrationA, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			migrationB, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			snapshot, ok := invocation.Arguments[2].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			// Copy the given snapshot twice, by loading it and creating new snapshots of it.
+			// Keep the current state, so it can be restored afterwards
+
+			current := createCheckSnapshot(blockchain, migrationsCommuteCheckCurrentSnapshotPrefix)
+
+			err := blockchain.LoadSnapshot(snapshot.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			ab := createCheckSnapshot(blockchain, migrationsCommuteCheckABSnapshotPrefix)
+			ba := createCheckSnapshot(blockchain, migrationsCommuteCheckBASnapshotPrefix)
+
+			err = blockchain.LoadSnapshot(current)
+			if err != nil {
+				panic(err)
+			}
+
+			applyMigrations := func(snapshot string, migrations ...string) {
+				for _, migration := range migrations {
+					_, err := blockchain.ApplyMigration(migration, snapshot)
+					if err != nil {
+						panic(err)
+					}
+				}
+			}
+
+			applyMigrations(ab, migrationA.Str, migrationB.Str)
+			applyMigrations(ba, migrationB.Str, migrationA.Str)
+
+			paths := diffSnapshotPaths(inter, blockchain, ab, ba)
+			if len(paths) == 0 {
+				return interpreter.Void
+			}
+
+			panic(AssertionError{
+				Message: fmt.Sprintf(
+					"migrations %s and %s do not commute: results differ at: %s",
+					migrationA.Str,
+					migrationB.Str,
+					strings.Join(paths, ", "),
+				),
+				LocationRange: locationRange,
+			})
+		},
+	)
+}
+
+// 'Test.assertGoldenOutput' function
+
+const testTypeAssertGoldenOutputFunctionDocString = `
+Fails the test-case if the script failed,
+or if the storage encoding of the return value of the script
+is not equal to the encoding in the golden file with the given name.
+
+The golden file is the file ` + "`<goldenName>.golden`" + `, resolved like ` + "`readFile`" + `,
+which contains the hex-encoded encoding.
+If the test runner is in golden file update mode, the golden file is written instead.
+`
+
+const testTypeAssertGoldenOutputFunctionName = "assertGoldenOutput"
+
+const goldenFileExtension = ".golden"
+
+func newTestTypeAssertGoldenOutputFunctionType(scriptResultType *sema.CompositeType) *sema.FunctionType {
+	return &sema.FunctionType{
+		Parameters: []sema.Parameter{
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "scriptResult",
+				TypeAnnotation: sema.NewTypeAnnotation(scriptResultType),
+			},
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "goldenName",
+				TypeAnnotation: sema.StringTypeAnnotation,
+			},
+		},
+		ReturnTypeAnnotation: sema.NewTypeAnnotation(
+			sema.VoidType,
+		),
+	}
+}
+
+func newTestTypeAssertGoldenOutputFunction(
+	functionType *sema.FunctionType,
+	testFramework TestFramework,
+	inter *interpreter.Interpreter,
+	testContractValue *interpreter.CompositeValue,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		testContractValue,
+		functionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			scriptResult, ok := invocation.Arguments[0].(*interpreter.CompositeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			goldenName, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			if errorValue, ok := scriptResult.GetMember(
+				inter,
+				locationRange,
+				resultErrorFieldName,
+			).(*interpreter.SomeValue); ok {
+				errorComposite, ok := errorValue.InnerValue(inter, locationRange).(*interpreter.CompositeValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				message, ok := errorComposite.GetMember(
+					inter,
+					locationRange,
+					errorMessageFieldName,
+				).(*interpreter.StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				panic(AssertionError{
+					Message:       fmt.Sprintf("script failed: %s", message.Str),
+					LocationRange: locationRange,
+				})
+			}
+
+			returnValue := scriptResult.GetMember(
+				inter,
+				locationRange,
+				scriptResultReturnValueFieldName,
+			)
+
+			encoded, err := encodeStorageValue(inter, locationRange, returnValue)
+			if err != nil {
+				panic(errors.NewDefaultUserError("cannot encode value %s: %s", returnValue, err))
+			}
+
+			actual := hex.EncodeToString(encoded)
+
+			path := goldenName.Str + goldenFileExtension
+
+			if testFramework.UpdateGoldenFiles() {
+				err := testFramework.WriteFile(path, actual+"\n")
+				if err != nil {
+					panic(errors.NewDefaultUserError(
+						"cannot write golden file `%s`: %s",
+						path,
+						err,
+					))
+				}
+				return interpreter.Void
+			}
 
-	if err != nil {
-		panic(err)
-	}
+			content, err := testFramework.ReadFile(path)
+			if err != nil {
+				panic(errors.NewDefaultUserError(
+					"cannot read golden file `%s`: %s",
+					path,
+					err,
+				))
+			}
 
-	result, ok := testResult.(interpreter.BoolValue)
-	if !ok {
-		panic(errors.NewUnreachableError())
-	}
+			expected := strings.TrimSpace(content)
+			if expected != actual {
+				panic(AssertionError{
+					Message: fmt.Sprintf(
+						"output does not match golden file `%s`: expected: %s, actual: %s",
+						path,
+						expected,
+						actual,
+					),
+					LocationRange: locationRange,
+				})
+			}
 
-	return bool(result)
+			return interpreter.Void
+		},
+	)
 }
 
-// 'Test.readFile' function
+// 'Test.assertDeterministic' function
 
-const testTypeReadFileFunctionDocString = `
-Read a local file, and return the content as a string.
+const testTypeAssertDeterministicFunctionDocString = `
+Executes the given script the given number of times, and fails the test-case
+if any of the executions fails, or if the executions do not all return equal values.
 `
 
-const testTypeReadFileFunctionName = "readFile"
+const testTypeAssertDeterministicFunctionName = "assertDeterministic"
 
-var testTypeReadFileFunctionType = &sema.FunctionType{
+var testTypeAssertDeterministicFunctionType = &sema.FunctionType{
 	Parameters: []sema.Parameter{
 		{
 			Label:          sema.ArgumentLabelNotRequired,
-			Identifier:     "path",
+			Identifier:     "script",
 			TypeAnnotation: sema.StringTypeAnnotation,
 		},
+		{
+			Identifier:     "runs",
+			TypeAnnotation: sema.IntTypeAnnotation,
+		},
 	},
-	ReturnTypeAnnotation: sema.StringTypeAnnotation,
+	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
 }
 
-func newTestTypeReadFileFunction(
-	testFramework TestFramework,
+func newTestTypeAssertDeterministicFunction(
+	blockchain Blockchain,
 	inter *interpreter.Interpreter,
 	testContractValue *interpreter.CompositeValue,
 ) interpreter.BoundFunctionValue {
 	return interpreter.NewUnmeteredBoundHostFunctionValue(
 		inter,
 		testContractValue,
-		testTypeReadFileFunctionType,
+		testTypeAssertDeterministicFunctionType,
 		func(invocation interpreter.Invocation) interpreter.Value {
-			pathString, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			script, ok := invocation.Arguments[0].(*interpreter.StringValue)
 			if !ok {
 				panic(errors.NewUnreachableError())
 			}
 
-			content, err := testFramework.ReadFile(pathString.Str)
-			if err != nil {
-				panic(err)
+			runsValue, ok := invocation.Arguments[1].(interpreter.IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
 			}
 
-			return interpreter.NewUnmeteredStringValue(content)
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			runs := runsValue.ToInt(locationRange)
+			if runs < 1 {
+				panic(errors.NewDefaultUserError("number of runs must be at least 1, got %d", runs))
+			}
+
+			var firstValue interpreter.Value
+
+			for run := 1; run <= runs; run++ {
+				result := blockchain.RunScript(inter, script.Str, nil)
+				if result.Error != nil {
+					message := fmt.Sprintf(
+						"script failed in run %d: %s",
+						run,
+						result.Error.Error(),
+					)
+					panic(AssertionError{
+						Message:       message,
+						LocationRange: locationRange,
+					})
+				}
+
+				value := result.Value
+				if value == nil {
+					value = interpreter.Nil
+				}
+
+				if firstValue == nil {
+					firstValue = value
+					continue
+				}
+
+				equatableValue, ok := firstValue.(interpreter.EquatableValue)
+				if !ok {
+					panic(errors.NewDefaultUserError(
+						"script return value is not equatable: %s",
+						firstValue.StaticType(inter),
+					))
+				}
+
+				if !equatableValue.Equal(inter, locationRange, value) {
+					message := fmt.Sprintf(
+						"script is not deterministic: run 1 returned: %s, run %d returned: %s",
+						firstValue,
+						run,
+						value,
+					)
+					panic(AssertionError{
+						Message:       message,
+						LocationRange: locationRange,
+					})
+				}
+			}
+
+			return interpreter.Void
 		},
 	)
 }
@@ -570,11 +3932,27 @@ func newTestTypeEqualFunction(
 					},
 				)
 
-				return newMatcherWithGenericTestFunction(
+				matcher := newMatcherWithGenericTestFunction(
 					invocation,
 					equalTestFunc,
 					matcherTestFunctionType,
 				)
+
+				matcherValue, ok := matcher.(*interpreter.CompositeValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				matcherValue.SetMember(
+					inter,
+					invocation.LocationRange,
+					matcherExpectedFieldName,
+					interpreter.NewUnmeteredSomeValueNonCopying(
+						interpreter.NewUnmeteredStringValue(otherValue.String()),
+					),
+				)
+
+				return matcherValue
 			},
 		)
 	}
@@ -980,133 +4358,448 @@ func newTestTypeBeLessThanFunction(
 							panic(errors.NewUnreachableError())
 						}
 
-						isLessThan := thisValue.Less(
-							inter,
-							otherValue,
-							invocation.LocationRange,
-						)
+						isLessThan := thisValue.Less(
+							inter,
+							otherValue,
+							invocation.LocationRange,
+						)
+
+						return isLessThan
+					},
+				)
+
+				return newMatcherWithAnyStructTestFunction(
+					invocation,
+					beLessThanTestFunc,
+				)
+			},
+		)
+	}
+}
+
+func newTestContractType() *TestContractType {
+
+	program, err := parser.ParseProgram(
+		nil,
+		contracts.TestContract,
+		parser.Config{},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	activation := sema.NewVariableActivation(sema.BaseValueActivation)
+	activation.DeclareValue(AssertFunction)
+	activation.DeclareValue(PanicFunction)
+
+	checker, err := sema.NewChecker(
+		program,
+		TestContractLocation,
+		nil,
+		&sema.Config{
+			BaseValueActivationHandler: func(_ common.Location) *sema.VariableActivation {
+				return activation
+			},
+			AccessCheckMode: sema.AccessCheckModeStrict,
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	err = checker.Check()
+	if err != nil {
+		panic(err)
+	}
+
+	variable, ok := checker.Elaboration.GetGlobalType(testContractTypeName)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	compositeType := variable.Type.(*sema.CompositeType)
+
+	initializerTypes := make([]sema.Type, len(compositeType.ConstructorParameters))
+	for i, parameter := range compositeType.ConstructorParameters {
+		initializerTypes[i] = parameter.TypeAnnotation.Type
+	}
+
+	ty := &TestContractType{
+		Checker:          checker,
+		CompositeType:    compositeType,
+		InitializerTypes: initializerTypes,
+	}
+
+	blockchainBackendInterfaceType := ty.blockchainBackendInterfaceType()
+
+	emulatorBackendType := newTestEmulatorBackendType(blockchainBackendInterfaceType)
+	ty.emulatorBackendType = emulatorBackendType
+
+	// Enrich 'Test' contract elaboration with natively implemented composite types.
+	// e.g: 'EmulatorBackend' type.
+	checker.Elaboration.SetCompositeType(
+		emulatorBackendType.compositeType.ID(),
+		emulatorBackendType.compositeType,
+	)
+
+	matcherType := ty.matcherType()
+	matcherTestFunctionType := compositeFunctionType(matcherType, matcherTestFieldName)
+
+	// Test.assert()
+	compositeType.Members.Set(
+		testTypeAssertFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertFunctionName,
+			testTypeAssertFunctionType,
+			testTypeAssertFunctionDocString,
+		),
+	)
+
+	// Test.assertEqual()
+	compositeType.Members.Set(
+		testTypeAssertEqualFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertEqualFunctionName,
+			testTypeAssertEqualFunctionType,
+			testTypeAssertEqualFunctionDocString,
+		),
+	)
+
+	// Test.assertCapabilityType()
+	compositeType.Members.Set(
+		testTypeAssertCapabilityTypeFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertCapabilityTypeFunctionName,
+			testTypeAssertCapabilityTypeFunctionType,
+			testTypeAssertCapabilityTypeFunctionDocString,
+		),
+	)
+
+	// Test.assertNil()
+	compositeType.Members.Set(
+		testTypeAssertNilFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertNilFunctionName,
+			testTypeAssertNilFunctionType,
+			testTypeAssertNilFunctionDocString,
+		),
+	)
+
+	// Test.assertNotNil()
+	compositeType.Members.Set(
+		testTypeAssertNotNilFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertNotNilFunctionName,
+			testTypeAssertNotNilFunctionType,
+			testTypeAssertNotNilFunctionDocString,
+		),
+	)
+
+	// Test.eventField()
+	compositeType.Members.Set(
+		testTypeEventFieldFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeEventFieldFunctionName,
+			testTypeEventFieldFunctionType,
+			testTypeEventFieldFunctionDocString,
+		),
+	)
+
+	// Test.assertEventEquals()
+	compositeType.Members.Set(
+		testTypeAssertEventEqualsFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertEventEqualsFunctionName,
+			testTypeAssertEventEqualsFunctionType,
+			testTypeAssertEventEqualsFunctionDocString,
+		),
+	)
+
+	// Test.assertSameReference()
+	compositeType.Members.Set(
+		testTypeAssertSameReferenceFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertSameReferenceFunctionName,
+			testTypeAssertSameReferenceFunctionType,
+			testTypeAssertSameReferenceFunctionDocString,
+		),
+	)
+
+	// Test.assertAllMoved()
+	compositeType.Members.Set(
+		testTypeAssertAllMovedFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertAllMovedFunctionName,
+			testTypeAssertAllMovedFunctionType,
+			testTypeAssertAllMovedFunctionDocString,
+		),
+	)
+
+	// Test.describe()
+	compositeType.Members.Set(
+		testTypeDescribeFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeDescribeFunctionName,
+			testTypeDescribeFunctionType,
+			testTypeDescribeFunctionDocString,
+		),
+	)
+
+	// Test.fail()
+	compositeType.Members.Set(
+		testTypeFailFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeFailFunctionName,
+			testTypeFailFunctionType,
+			testTypeFailFunctionDocString,
+		),
+	)
+
+	// Test.readFile()
+	compositeType.Members.Set(
+		testTypeReadFileFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeReadFileFunctionName,
+			testTypeReadFileFunctionType,
+			testTypeReadFileFunctionDocString,
+		),
+	)
+
+	// Test.loadFixture()
+	compositeType.Members.Set(
+		testTypeLoadFixtureFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeLoadFixtureFunctionName,
+			testTypeLoadFixtureFunctionType,
+			testTypeLoadFixtureFunctionDocString,
+		),
+	)
 
-						return isLessThan
-					},
-				)
+	// Test.assertStorageMigration()
+	compositeType.Members.Set(
+		testTypeAssertStorageMigrationFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertStorageMigrationFunctionName,
+			testTypeAssertStorageMigrationFunctionType,
+			testTypeAssertStorageMigrationFunctionDocString,
+		),
+	)
 
-				return newMatcherWithAnyStructTestFunction(
-					invocation,
-					beLessThanTestFunc,
-				)
-			},
-		)
-	}
-}
+	// Test.assertConformance()
+	compositeType.Members.Set(
+		testTypeAssertConformanceFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertConformanceFunctionName,
+			testTypeAssertConformanceFunctionType,
+			testTypeAssertConformanceFunctionDocString,
+		),
+	)
 
-func newTestContractType() *TestContractType {
+	// Test.assertEventCountInRange()
+	compositeType.Members.Set(
+		testTypeAssertEventCountInRangeFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertEventCountInRangeFunctionName,
+			testTypeAssertEventCountInRangeFunctionType,
+			testTypeAssertEventCountInRangeFunctionDocString,
+		),
+	)
 
-	program, err := parser.ParseProgram(
-		nil,
-		contracts.TestContract,
-		parser.Config{},
+	// Test.assertContractRemoved()
+	compositeType.Members.Set(
+		testTypeAssertContractRemovedFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertContractRemovedFunctionName,
+			testTypeAssertContractRemovedFunctionType,
+			testTypeAssertContractRemovedFunctionDocString,
+		),
 	)
-	if err != nil {
-		panic(err)
-	}
 
-	activation := sema.NewVariableActivation(sema.BaseValueActivation)
-	activation.DeclareValue(AssertFunction)
-	activation.DeclareValue(PanicFunction)
+	// Test.assertEventJSON()
+	compositeType.Members.Set(
+		testTypeAssertEventJSONFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertEventJSONFunctionName,
+			testTypeAssertEventJSONFunctionType,
+			testTypeAssertEventJSONFunctionDocString,
+		),
+	)
 
-	checker, err := sema.NewChecker(
-		program,
-		TestContractLocation,
-		nil,
-		&sema.Config{
-			BaseValueActivationHandler: func(_ common.Location) *sema.VariableActivation {
-				return activation
-			},
-			AccessCheckMode: sema.AccessCheckModeStrict,
-		},
+	// Test.assertStorageEmpty()
+	compositeType.Members.Set(
+		testTypeAssertStorageEmptyFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertStorageEmptyFunctionName,
+			testTypeAssertStorageEmptyFunctionType,
+			testTypeAssertStorageEmptyFunctionDocString,
+		),
 	)
-	if err != nil {
-		panic(err)
-	}
 
-	err = checker.Check()
-	if err != nil {
-		panic(err)
-	}
+	// Test.assertFieldsEqual()
+	compositeType.Members.Set(
+		testTypeAssertFieldsEqualFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertFieldsEqualFunctionName,
+			testTypeAssertFieldsEqualFunctionType,
+			testTypeAssertFieldsEqualFunctionDocString,
+		),
+	)
 
-	variable, ok := checker.Elaboration.GetGlobalType(testContractTypeName)
-	if !ok {
-		panic(errors.NewUnreachableError())
-	}
-	compositeType := variable.Type.(*sema.CompositeType)
+	// Test.assertStableEncoding()
+	compositeType.Members.Set(
+		testTypeAssertStableEncodingFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertStableEncodingFunctionName,
+			testTypeAssertStableEncodingFunctionType,
+			testTypeAssertStableEncodingFunctionDocString,
+		),
+	)
 
-	initializerTypes := make([]sema.Type, len(compositeType.ConstructorParameters))
-	for i, parameter := range compositeType.ConstructorParameters {
-		initializerTypes[i] = parameter.TypeAnnotation.Type
-	}
+	// Test.assertEventSchema()
+	eventFieldType := ty.eventFieldType()
+	ty.assertEventSchemaFunctionType = newTestTypeAssertEventSchemaFunctionType(eventFieldType)
+	compositeType.Members.Set(
+		testTypeAssertEventSchemaFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertEventSchemaFunctionName,
+			ty.assertEventSchemaFunctionType,
+			testTypeAssertEventSchemaFunctionDocString,
+		),
+	)
 
-	ty := &TestContractType{
-		Checker:          checker,
-		CompositeType:    compositeType,
-		InitializerTypes: initializerTypes,
-	}
+	// Test.assertTotalSupplyUnchanged()
+	compositeType.Members.Set(
+		testTypeAssertTotalSupplyUnchangedFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertTotalSupplyUnchangedFunctionName,
+			testTypeAssertTotalSupplyUnchangedFunctionType,
+			testTypeAssertTotalSupplyUnchangedFunctionDocString,
+		),
+	)
 
-	blockchainBackendInterfaceType := ty.blockchainBackendInterfaceType()
+	// Test.hashValue()
+	compositeType.Members.Set(
+		testTypeHashValueFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeHashValueFunctionName,
+			testTypeHashValueFunctionType,
+			testTypeHashValueFunctionDocString,
+		),
+	)
 
-	emulatorBackendType := newTestEmulatorBackendType(blockchainBackendInterfaceType)
-	ty.emulatorBackendType = emulatorBackendType
+	// Test.assertFailsAt()
+	compositeType.Members.Set(
+		testTypeAssertFailsAtFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertFailsAtFunctionName,
+			testTypeAssertFailsAtFunctionType,
+			testTypeAssertFailsAtFunctionDocString,
+		),
+	)
 
-	// Enrich 'Test' contract elaboration with natively implemented composite types.
-	// e.g: 'EmulatorBackend' type.
-	checker.Elaboration.SetCompositeType(
-		emulatorBackendType.compositeType.ID(),
-		emulatorBackendType.compositeType,
+	// Test.assertScriptReadOnly()
+	compositeType.Members.Set(
+		testTypeAssertScriptReadOnlyFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertScriptReadOnlyFunctionName,
+			testTypeAssertScriptReadOnlyFunctionType,
+			testTypeAssertScriptReadOnlyFunctionDocString,
+		),
 	)
 
-	matcherType := ty.matcherType()
-	matcherTestFunctionType := compositeFunctionType(matcherType, matcherTestFieldName)
+	// Test.assertTransactionAtomic()
+	transactionType := ty.transactionType()
+	ty.assertTransactionAtomicFunctionType = newTestTypeAssertTransactionAtomicFunctionType(transactionType)
+	compositeType.Members.Set(
+		testTypeAssertTransactionAtomicFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertTransactionAtomicFunctionName,
+			ty.assertTransactionAtomicFunctionType,
+			testTypeAssertTransactionAtomicFunctionDocString,
+		),
+	)
 
-	// Test.assert()
+	// Test.assertTransactionIdempotent()
+	ty.assertTransactionIdempotentFunctionType = newTestTypeAssertTransactionIdempotentFunctionType(transactionType)
 	compositeType.Members.Set(
-		testTypeAssertFunctionName,
+		testTypeAssertTransactionIdempotentFunctionName,
 		sema.NewUnmeteredPublicFunctionMember(
 			compositeType,
-			testTypeAssertFunctionName,
-			testTypeAssertFunctionType,
-			testTypeAssertFunctionDocString,
+			testTypeAssertTransactionIdempotentFunctionName,
+			ty.assertTransactionIdempotentFunctionType,
+			testTypeAssertTransactionIdempotentFunctionDocString,
 		),
 	)
 
-	// Test.assertEqual()
+	// Test.assertMigrationIdempotent()
 	compositeType.Members.Set(
-		testTypeAssertEqualFunctionName,
+		testTypeAssertMigrationIdempotentFunctionName,
 		sema.NewUnmeteredPublicFunctionMember(
 			compositeType,
-			testTypeAssertEqualFunctionName,
-			testTypeAssertEqualFunctionType,
-			testTypeAssertEqualFunctionDocString,
+			testTypeAssertMigrationIdempotentFunctionName,
+			testTypeAssertMigrationIdempotentFunctionType,
+			testTypeAssertMigrationIdempotentFunctionDocString,
 		),
 	)
 
-	// Test.fail()
+	// Test.assertMigrationsCommute()
 	compositeType.Members.Set(
-		testTypeFailFunctionName,
+		testTypeAssertMigrationsCommuteFunctionName,
 		sema.NewUnmeteredPublicFunctionMember(
 			compositeType,
-			testTypeFailFunctionName,
-			testTypeFailFunctionType,
-			testTypeFailFunctionDocString,
+			testTypeAssertMigrationsCommuteFunctionName,
+			testTypeAssertMigrationsCommuteFunctionType,
+			testTypeAssertMigrationsCommuteFunctionDocString,
 		),
 	)
 
-	// Test.readFile()
+	// Test.assertGoldenOutput()
+	scriptResultType := ty.scriptResultType()
+	ty.assertGoldenOutputFunctionType = newTestTypeAssertGoldenOutputFunctionType(scriptResultType)
 	compositeType.Members.Set(
-		testTypeReadFileFunctionName,
+		testTypeAssertGoldenOutputFunctionName,
 		sema.NewUnmeteredPublicFunctionMember(
 			compositeType,
-			testTypeReadFileFunctionName,
-			testTypeReadFileFunctionType,
-			testTypeReadFileFunctionDocString,
+			testTypeAssertGoldenOutputFunctionName,
+			ty.assertGoldenOutputFunctionType,
+			testTypeAssertGoldenOutputFunctionDocString,
+		),
+	)
+
+	// Test.assertDeterministic()
+	compositeType.Members.Set(
+		testTypeAssertDeterministicFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testTypeAssertDeterministicFunctionName,
+			testTypeAssertDeterministicFunctionType,
+			testTypeAssertDeterministicFunctionDocString,
 		),
 	)
 
@@ -1290,6 +4983,57 @@ func (t *TestContractType) matcherType() *sema.CompositeType {
 	return matcherType
 }
 
+func (t *TestContractType) eventFieldType() *sema.CompositeType {
+	typ, ok := t.CompositeType.NestedTypes.Get(testEventFieldTypeName)
+	if !ok {
+		panic(typeNotFoundError(testContractTypeName, testEventFieldTypeName))
+	}
+
+	eventFieldType, ok := typ.(*sema.CompositeType)
+	if !ok || eventFieldType.Kind != common.CompositeKindStructure {
+		panic(errors.NewUnexpectedError(
+			"invalid type for '%s'. expected struct type",
+			testEventFieldTypeName,
+		))
+	}
+
+	return eventFieldType
+}
+
+func (t *TestContractType) transactionType() *sema.CompositeType {
+	typ, ok := t.CompositeType.NestedTypes.Get(testTransactionTypeName)
+	if !ok {
+		panic(typeNotFoundError(testContractTypeName, testTransactionTypeName))
+	}
+
+	transactionType, ok := typ.(*sema.CompositeType)
+	if !ok || transactionType.Kind != common.CompositeKindStructure {
+		panic(errors.NewUnexpectedError(
+			"invalid type for '%s'. expected struct type",
+			testTransactionTypeName,
+		))
+	}
+
+	return transactionType
+}
+
+func (t *TestContractType) scriptResultType() *sema.CompositeType {
+	typ, ok := t.CompositeType.NestedTypes.Get(testScriptResultTypeName)
+	if !ok {
+		panic(typeNotFoundError(testContractTypeName, testScriptResultTypeName))
+	}
+
+	scriptResultType, ok := typ.(*sema.CompositeType)
+	if !ok || scriptResultType.Kind != common.CompositeKindStructure {
+		panic(errors.NewUnexpectedError(
+			"invalid type for '%s'. expected struct type",
+			testScriptResultTypeName,
+		))
+	}
+
+	return scriptResultType
+}
+
 func (t *TestContractType) NewTestContract(
 	inter *interpreter.Interpreter,
 	testFramework TestFramework,
@@ -1300,9 +5044,10 @@ func (t *TestContractType) NewTestContract(
 	error,
 ) {
 	initializerTypes := t.InitializerTypes
+	blockchain := testFramework.EmulatorBackend()
 	emulatorBackend := t.emulatorBackendType.newEmulatorBackend(
 		inter,
-		testFramework.EmulatorBackend(),
+		blockchain,
 		interpreter.EmptyLocationRange,
 	)
 	returnType := constructor.FunctionType().ReturnTypeAnnotation.Type
@@ -1323,12 +5068,138 @@ func (t *TestContractType) NewTestContract(
 	// Inject natively implemented function values
 	compositeValue.Functions.Set(testTypeAssertFunctionName, testTypeAssertFunction(inter, compositeValue))
 	compositeValue.Functions.Set(testTypeAssertEqualFunctionName, testTypeAssertEqualFunction(inter, compositeValue))
+	compositeValue.Functions.Set(testTypeAssertNilFunctionName, testTypeAssertNilFunction(inter, compositeValue))
+	compositeValue.Functions.Set(testTypeAssertNotNilFunctionName, testTypeAssertNotNilFunction(inter, compositeValue))
+	compositeValue.Functions.Set(
+		testTypeAssertSameReferenceFunctionName,
+		testTypeAssertSameReferenceFunction(inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertAllMovedFunctionName,
+		testTypeAssertAllMovedFunction(inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeDescribeFunctionName,
+		testTypeDescribeFunction(inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertEventEqualsFunctionName,
+		testTypeAssertEventEqualsFunction(inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeEventFieldFunctionName,
+		testTypeEventFieldFunction(inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertCapabilityTypeFunctionName,
+		testTypeAssertCapabilityTypeFunction(inter, compositeValue),
+	)
 	compositeValue.Functions.Set(testTypeFailFunctionName, testTypeFailFunction(inter, compositeValue))
 	compositeValue.Functions.Set(testTypeExpectFunctionName, t.expectFunction(inter, compositeValue))
 	compositeValue.Functions.Set(
 		testTypeReadFileFunctionName,
 		newTestTypeReadFileFunction(testFramework, inter, compositeValue),
 	)
+	compositeValue.Functions.Set(
+		testTypeLoadFixtureFunctionName,
+		newTestTypeLoadFixtureFunction(testFramework, inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertStorageMigrationFunctionName,
+		newTestTypeAssertStorageMigrationFunction(blockchain, inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertConformanceFunctionName,
+		newTestTypeAssertConformanceFunction(blockchain, inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertEventCountInRangeFunctionName,
+		newTestTypeAssertEventCountInRangeFunction(blockchain, inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertContractRemovedFunctionName,
+		newTestTypeAssertContractRemovedFunction(blockchain, inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertEventJSONFunctionName,
+		newTestTypeAssertEventJSONFunction(testFramework, blockchain, inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertStorageEmptyFunctionName,
+		newTestTypeAssertStorageEmptyFunction(blockchain, inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertFieldsEqualFunctionName,
+		newTestTypeAssertFieldsEqualFunction(inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertStableEncodingFunctionName,
+		newTestTypeAssertStableEncodingFunction(inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertEventSchemaFunctionName,
+		newTestTypeAssertEventSchemaFunction(
+			t.assertEventSchemaFunctionType,
+			blockchain,
+			inter,
+			compositeValue,
+		),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertTotalSupplyUnchangedFunctionName,
+		newTestTypeAssertTotalSupplyUnchangedFunction(blockchain, inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeHashValueFunctionName,
+		newTestTypeHashValueFunction(inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertFailsAtFunctionName,
+		newTestTypeAssertFailsAtFunction(inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertScriptReadOnlyFunctionName,
+		newTestTypeAssertScriptReadOnlyFunction(blockchain, inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertTransactionAtomicFunctionName,
+		newTestTypeAssertTransactionAtomicFunction(
+			t.assertTransactionAtomicFunctionType,
+			blockchain,
+			inter,
+			compositeValue,
+		),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertTransactionIdempotentFunctionName,
+		newTestTypeAssertTransactionIdempotentFunction(
+			t.assertTransactionIdempotentFunctionType,
+			blockchain,
+			inter,
+			compositeValue,
+		),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertMigrationIdempotentFunctionName,
+		newTestTypeAssertMigrationIdempotentFunction(blockchain, inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertMigrationsCommuteFunctionName,
+		newTestTypeAssertMigrationsCommuteFunction(blockchain, inter, compositeValue),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertGoldenOutputFunctionName,
+		newTestTypeAssertGoldenOutputFunction(
+			t.assertGoldenOutputFunctionType,
+			testFramework,
+			inter,
+			compositeValue,
+		),
+	)
+	compositeValue.Functions.Set(
+		testTypeAssertDeterministicFunctionName,
+		newTestTypeAssertDeterministicFunction(blockchain, inter, compositeValue),
+	)
 
 	// Inject natively implemented matchers
 	compositeValue.Functions.Set(testTypeNewMatcherFunctionName, t.newMatcherFunction(inter, compositeValue))
@@ -21,10 +21,13 @@ package stdlib
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -36,21 +39,38 @@ import (
 const testEmulatorBackendTypeName = "EmulatorBackend"
 
 type testEmulatorBackendType struct {
-	compositeType                      *sema.CompositeType
-	executeScriptFunctionType          *sema.FunctionType
-	createAccountFunctionType          *sema.FunctionType
-	addTransactionFunctionType         *sema.FunctionType
-	executeNextTransactionFunctionType *sema.FunctionType
-	commitBlockFunctionType            *sema.FunctionType
-	deployContractFunctionType         *sema.FunctionType
-	logsFunctionType                   *sema.FunctionType
-	serviceAccountFunctionType         *sema.FunctionType
-	eventsFunctionType                 *sema.FunctionType
-	resetFunctionType                  *sema.FunctionType
-	moveTimeFunctionType               *sema.FunctionType
-	createSnapshotFunctionType         *sema.FunctionType
-	loadSnapshotFunctionType           *sema.FunctionType
-	getAccountFunctionType             *sema.FunctionType
+	compositeType                            *sema.CompositeType
+	executeScriptFunctionType                *sema.FunctionType
+	createAccountFunctionType                *sema.FunctionType
+	addTransactionFunctionType               *sema.FunctionType
+	executeNextTransactionFunctionType       *sema.FunctionType
+	commitBlockFunctionType                  *sema.FunctionType
+	deployContractFunctionType               *sema.FunctionType
+	logsFunctionType                         *sema.FunctionType
+	serviceAccountFunctionType               *sema.FunctionType
+	eventsFunctionType                       *sema.FunctionType
+	resetFunctionType                        *sema.FunctionType
+	moveTimeFunctionType                     *sema.FunctionType
+	createSnapshotFunctionType               *sema.FunctionType
+	loadSnapshotFunctionType                 *sema.FunctionType
+	getAccountFunctionType                   *sema.FunctionType
+	storagePathExistsFunctionType            *sema.FunctionType
+	executeScriptAtHeightFunctionType        *sema.FunctionType
+	getContractInitCountFunctionType         *sema.FunctionType
+	getContractConstantFunctionType          *sema.FunctionType
+	deployContractWithCostFunctionType       *sema.FunctionType
+	deployContractWithCodeFunctionType       *sema.FunctionType
+	executeScriptWithAccountsFunctionType    *sema.FunctionType
+	getCapabilityControllerCountFunctionType *sema.FunctionType
+	capabilityControllersFunctionType        *sema.FunctionType
+	getCollectionSizeFunctionType            *sema.FunctionType
+	addTransactionWithPayerFunctionType      *sema.FunctionType
+	randomHistoryFunctionType                *sema.FunctionType
+	currentBlockFunctionType                 *sema.FunctionType
+	createAccountWithKeysFunctionType        *sema.FunctionType
+	setComputationLimitFunctionType          *sema.FunctionType
+	allCapabilityControllersFunctionType     *sema.FunctionType
+	findDeprecatedValuesFunctionType         *sema.FunctionType
 }
 
 func newTestEmulatorBackendType(
@@ -126,6 +146,91 @@ func newTestEmulatorBackendType(
 		testEmulatorBackendTypeGetAccountFunctionName,
 	)
 
+	storagePathExistsFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeStoragePathExistsFunctionName,
+	)
+
+	executeScriptAtHeightFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeExecuteScriptAtHeightFunctionName,
+	)
+
+	getContractInitCountFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeGetContractInitCountFunctionName,
+	)
+
+	getContractConstantFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeGetContractConstantFunctionName,
+	)
+
+	deployContractWithCostFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeDeployContractWithCostFunctionName,
+	)
+
+	deployContractWithCodeFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeDeployContractWithCodeFunctionName,
+	)
+
+	executeScriptWithAccountsFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeExecuteScriptWithAccountsFunctionName,
+	)
+
+	getCapabilityControllerCountFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeGetCapabilityControllerCountFunctionName,
+	)
+
+	capabilityControllersFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeCapabilityControllersFunctionName,
+	)
+
+	getCollectionSizeFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeGetCollectionSizeFunctionName,
+	)
+
+	addTransactionWithPayerFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeAddTransactionWithPayerFunctionName,
+	)
+
+	randomHistoryFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeRandomHistoryFunctionName,
+	)
+
+	currentBlockFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeCurrentBlockFunctionName,
+	)
+
+	createAccountWithKeysFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeCreateAccountWithKeysFunctionName,
+	)
+
+	setComputationLimitFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeSetComputationLimitFunctionName,
+	)
+
+	allCapabilityControllersFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeAllCapabilityControllersFunctionName,
+	)
+
+	findDeprecatedValuesFunctionType := interfaceFunctionType(
+		blockchainBackendInterfaceType,
+		testEmulatorBackendTypeFindDeprecatedValuesFunctionName,
+	)
+
 	compositeType := &sema.CompositeType{
 		Identifier: testEmulatorBackendTypeName,
 		Kind:       common.CompositeKindStructure,
@@ -220,27 +325,146 @@ func newTestEmulatorBackendType(
 			getAccountFunctionType,
 			testEmulatorBackendTypeGetAccountFunctionDocString,
 		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeStoragePathExistsFunctionName,
+			storagePathExistsFunctionType,
+			testEmulatorBackendTypeStoragePathExistsFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeExecuteScriptAtHeightFunctionName,
+			executeScriptAtHeightFunctionType,
+			testEmulatorBackendTypeExecuteScriptAtHeightFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeGetContractInitCountFunctionName,
+			getContractInitCountFunctionType,
+			testEmulatorBackendTypeGetContractInitCountFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeGetContractConstantFunctionName,
+			getContractConstantFunctionType,
+			testEmulatorBackendTypeGetContractConstantFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeDeployContractWithCostFunctionName,
+			deployContractWithCostFunctionType,
+			testEmulatorBackendTypeDeployContractWithCostFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeDeployContractWithCodeFunctionName,
+			deployContractWithCodeFunctionType,
+			testEmulatorBackendTypeDeployContractWithCodeFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeExecuteScriptWithAccountsFunctionName,
+			executeScriptWithAccountsFunctionType,
+			testEmulatorBackendTypeExecuteScriptWithAccountsFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeGetCapabilityControllerCountFunctionName,
+			getCapabilityControllerCountFunctionType,
+			testEmulatorBackendTypeGetCapabilityControllerCountFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeCapabilityControllersFunctionName,
+			capabilityControllersFunctionType,
+			testEmulatorBackendTypeCapabilityControllersFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeGetCollectionSizeFunctionName,
+			getCollectionSizeFunctionType,
+			testEmulatorBackendTypeGetCollectionSizeFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeAddTransactionWithPayerFunctionName,
+			addTransactionWithPayerFunctionType,
+			testEmulatorBackendTypeAddTransactionWithPayerFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeRandomHistoryFunctionName,
+			randomHistoryFunctionType,
+			testEmulatorBackendTypeRandomHistoryFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeCurrentBlockFunctionName,
+			currentBlockFunctionType,
+			testEmulatorBackendTypeCurrentBlockFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeCreateAccountWithKeysFunctionName,
+			createAccountWithKeysFunctionType,
+			testEmulatorBackendTypeCreateAccountWithKeysFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeSetComputationLimitFunctionName,
+			setComputationLimitFunctionType,
+			testEmulatorBackendTypeSetComputationLimitFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeAllCapabilityControllersFunctionName,
+			allCapabilityControllersFunctionType,
+			testEmulatorBackendTypeAllCapabilityControllersFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			compositeType,
+			testEmulatorBackendTypeFindDeprecatedValuesFunctionName,
+			findDeprecatedValuesFunctionType,
+			testEmulatorBackendTypeFindDeprecatedValuesFunctionDocString,
+		),
 	}
 
 	compositeType.Members = sema.MembersAsMap(members)
 	compositeType.Fields = sema.MembersFieldNames(members)
 
 	return &testEmulatorBackendType{
-		compositeType:                      compositeType,
-		executeScriptFunctionType:          executeScriptFunctionType,
-		createAccountFunctionType:          createAccountFunctionType,
-		addTransactionFunctionType:         addTransactionFunctionType,
-		executeNextTransactionFunctionType: executeNextTransactionFunctionType,
-		commitBlockFunctionType:            commitBlockFunctionType,
-		deployContractFunctionType:         deployContractFunctionType,
-		logsFunctionType:                   logsFunctionType,
-		serviceAccountFunctionType:         serviceAccountFunctionType,
-		eventsFunctionType:                 eventsFunctionType,
-		resetFunctionType:                  resetFunctionType,
-		moveTimeFunctionType:               moveTimeFunctionType,
-		createSnapshotFunctionType:         createSnapshotFunctionType,
-		loadSnapshotFunctionType:           loadSnapshotFunctionType,
-		getAccountFunctionType:             getAccountFunctionType,
+		compositeType:                            compositeType,
+		executeScriptFunctionType:                executeScriptFunctionType,
+		createAccountFunctionType:                createAccountFunctionType,
+		addTransactionFunctionType:               addTransactionFunctionType,
+		executeNextTransactionFunctionType:       executeNextTransactionFunctionType,
+		commitBlockFunctionType:                  commitBlockFunctionType,
+		deployContractFunctionType:               deployContractFunctionType,
+		logsFunctionType:                         logsFunctionType,
+		serviceAccountFunctionType:               serviceAccountFunctionType,
+		eventsFunctionType:                       eventsFunctionType,
+		resetFunctionType:                        resetFunctionType,
+		moveTimeFunctionType:                     moveTimeFunctionType,
+		createSnapshotFunctionType:               createSnapshotFunctionType,
+		loadSnapshotFunctionType:                 loadSnapshotFunctionType,
+		getAccountFunctionType:                   getAccountFunctionType,
+		storagePathExistsFunctionType:            storagePathExistsFunctionType,
+		executeScriptAtHeightFunctionType:        executeScriptAtHeightFunctionType,
+		getContractInitCountFunctionType:         getContractInitCountFunctionType,
+		getContractConstantFunctionType:          getContractConstantFunctionType,
+		deployContractWithCostFunctionType:       deployContractWithCostFunctionType,
+		deployContractWithCodeFunctionType:       deployContractWithCodeFunctionType,
+		executeScriptWithAccountsFunctionType:    executeScriptWithAccountsFunctionType,
+		getCapabilityControllerCountFunctionType: getCapabilityControllerCountFunctionType,
+		capabilityControllersFunctionType:        capabilityControllersFunctionType,
+		getCollectionSizeFunctionType:            getCollectionSizeFunctionType,
+		addTransactionWithPayerFunctionType:      addTransactionWithPayerFunctionType,
+		randomHistoryFunctionType:                randomHistoryFunctionType,
+		currentBlockFunctionType:                 currentBlockFunctionType,
+		createAccountWithKeysFunctionType:        createAccountWithKeysFunctionType,
+		setComputationLimitFunctionType:          setComputationLimitFunctionType,
+		allCapabilityControllersFunctionType:     allCapabilityControllersFunctionType,
+		findDeprecatedValuesFunctionType:         findDeprecatedValuesFunctionType,
 	}
 }
 
@@ -426,58 +650,15 @@ func (t *testEmulatorBackendType) newAddTransactionFunction(
 			inter := invocation.Interpreter
 			locationRange := invocation.LocationRange
 
-			transactionValue, ok := invocation.Arguments[0].(interpreter.MemberAccessibleValue)
-			if !ok {
-				panic(errors.NewUnreachableError())
-			}
-
-			// Get transaction code
-			codeValue := transactionValue.GetMember(
-				inter,
-				locationRange,
-				testTransactionTypeCodeFieldName,
-			)
-			code, ok := codeValue.(*interpreter.StringValue)
-			if !ok {
-				panic(errors.NewUnreachableError())
-			}
-
-			// Get authorizers
-			authorizerValue := transactionValue.GetMember(
-				inter,
-				locationRange,
-				testTransactionTypeAuthorizersFieldName,
-			)
-
-			authorizers := addressArrayValueToSlice(inter, authorizerValue, locationRange)
-
-			// Get signers
-			signersValue := transactionValue.GetMember(
-				inter,
-				locationRange,
-				testTransactionTypeSignersFieldName,
-			)
-
-			signerAccounts := accountsArrayValueToSlice(
-				inter,
-				signersValue,
-				locationRange,
-			)
-
-			// Get arguments
-			argsValue := transactionValue.GetMember(
+			code, authorizers, signerAccounts, args := transactionFromValue(
 				inter,
+				invocation.Arguments[0],
 				locationRange,
-				testTransactionTypeArgumentsFieldName,
 			)
-			args, err := arrayValueToSlice(inter, argsValue, locationRange)
-			if err != nil {
-				panic(errors.NewUnexpectedErrorFromCause(err))
-			}
 
-			err = blockchain.AddTransaction(
+			err := blockchain.AddTransaction(
 				inter,
-				code.Str,
+				code,
 				authorizers,
 				signerAccounts,
 				args,
@@ -492,6 +673,152 @@ func (t *testEmulatorBackendType) newAddTransactionFunction(
 	)
 }
 
+// transactionFromValue returns the code, the authorizers, the signers, and the arguments
+// of the given `Test.Transaction` value.
+// The arguments are checked against the parameters of the transaction.
+func transactionFromValue(
+	inter *interpreter.Interpreter,
+	value interpreter.Value,
+	locationRange interpreter.LocationRange,
+) (
+	string,
+	[]common.Address,
+	[]*Account,
+	[]interpreter.Value,
+) {
+	transactionValue, ok := value.(interpreter.MemberAccessibleValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	// Get transaction code
+	codeValue := transactionValue.GetMember(
+		inter,
+		locationRange,
+		testTransactionTypeCodeFieldName,
+	)
+	code, ok := codeValue.(*interpreter.StringValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	// Get authorizers
+	authorizerValue := transactionValue.GetMember(
+		inter,
+		locationRange,
+		testTransactionTypeAuthorizersFieldName,
+	)
+
+	authorizers := addressArrayValueToSlice(inter, authorizerValue, locationRange)
+
+	// Get signers
+	signersValue := transactionValue.GetMember(
+		inter,
+		locationRange,
+		testTransactionTypeSignersFieldName,
+	)
+
+	signerAccounts := accountsArrayValueToSlice(
+		inter,
+		signersValue,
+		locationRange,
+	)
+
+	// Get arguments
+	argsValue := transactionValue.GetMember(
+		inter,
+		locationRange,
+		testTransactionTypeArgumentsFieldName,
+	)
+	args, err := arrayValueToSlice(inter, argsValue, locationRange)
+	if err != nil {
+		panic(errors.NewUnexpectedErrorFromCause(err))
+	}
+
+	checkTransactionArguments(inter, code.Str, args)
+
+	return code.Str, authorizers, signerAccounts, args
+}
+
+// checkTransactionArguments checks that the given arguments match
+// the parameters of the transaction declared in the given code.
+//
+// Parameters whose types cannot be resolved without the transaction's imports,
+// e.g. types declared in contracts, are only checked for their presence.
+// Code that cannot be parsed is left to the blockchain to report.
+func checkTransactionArguments(
+	inter *interpreter.Interpreter,
+	code string,
+	arguments []interpreter.Value,
+) {
+	program, err := parser.ParseProgram(inter, []byte(code), parser.Config{})
+	if err != nil {
+		return
+	}
+
+	transactionDeclaration := program.SoleTransactionDeclaration()
+	if transactionDeclaration == nil {
+		return
+	}
+
+	var parameters []*ast.Parameter
+	if transactionDeclaration.ParameterList != nil {
+		parameters = transactionDeclaration.ParameterList.Parameters
+	}
+
+	if len(arguments) != len(parameters) {
+		panic(errors.NewDefaultUserError(
+			"invalid transaction argument count: expected %d, got %d",
+			len(parameters),
+			len(arguments),
+		))
+	}
+
+	checker, err := sema.NewChecker(
+		program,
+		common.TransactionLocation{},
+		inter,
+		&sema.Config{
+			AccessCheckMode: sema.AccessCheckModeNotSpecifiedUnrestricted,
+		},
+	)
+	if err != nil {
+		panic(errors.NewUnexpectedErrorFromCause(err))
+	}
+
+	reportedErrorCount := func() int {
+		checkerErr := checker.CheckerError()
+		if checkerErr == nil {
+			return 0
+		}
+		return len(checkerErr.Errors)
+	}
+
+	for index, parameter := range parameters {
+		errorCount := reportedErrorCount()
+
+		parameterType := checker.ConvertType(parameter.TypeAnnotation.Type)
+
+		// Skip parameters whose types could not be resolved
+		if reportedErrorCount() > errorCount || parameterType.IsInvalidType() {
+			continue
+		}
+
+		argument := arguments[index]
+		argumentType := argument.StaticType(inter)
+
+		if !inter.IsSubTypeOfSemaType(argumentType, parameterType) {
+			panic(errors.NewDefaultUserError(
+				"invalid transaction argument at index %d (parameter `%s`): expected type `%s`, got `%s`",
+				index,
+				parameter.Identifier.Identifier,
+				parameterType.QualifiedString(),
+				inter.MustConvertStaticToSemaType(argumentType).QualifiedString(),
+			))
+		}
+	}
+}
+
 // 'EmulatorBackend.executeNextTransaction' function
 
 const testEmulatorBackendTypeExecuteNextTransactionFunctionName = "executeNextTransaction"
@@ -605,6 +932,70 @@ func (t *testEmulatorBackendType) newDeployContractFunction(
 	)
 }
 
+// 'EmulatorBackend.deployContractWithCode' function
+
+const testEmulatorBackendTypeDeployContractWithCodeFunctionName = "deployContractWithCode"
+
+const testEmulatorBackendTypeDeployContractWithCodeFunctionDocString = `
+Deploys the given contract code to the given account,
+and initializes it with the provided arguments.
+`
+
+func (t *testEmulatorBackendType) newDeployContractWithCodeFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.deployContractWithCodeFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			// Contract name
+			name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// Contract code
+			code, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// Account to deploy to
+			accountValue, ok := invocation.Arguments[2].(interpreter.MemberAccessibleValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			account := accountFromValue(inter, accountValue, locationRange)
+
+			// Contract init arguments
+			args, err := arrayValueToSlice(
+				inter,
+				invocation.Arguments[3],
+				locationRange,
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			err = blockchain.DeployContractWithCode(
+				inter,
+				name.Str,
+				code.Str,
+				account,
+				args,
+			)
+
+			return newErrorValue(inter, err)
+		},
+	)
+}
+
 // 'EmulatorBackend.logs' function
 
 const testEmulatorBackendTypeLogsFunctionName = "logs"
@@ -624,34 +1015,11 @@ func (t *testEmulatorBackendType) newLogsFunction(
 		t.logsFunctionType,
 		func(invocation interpreter.Invocation) interpreter.Value {
 			logs := blockchain.Logs()
-			inter := invocation.Interpreter
-
-			arrayType := interpreter.NewVariableSizedStaticType(
-				inter,
-				interpreter.NewPrimitiveStaticType(
-					inter,
-					interpreter.PrimitiveStaticTypeString,
-				),
-			)
-
-			values := make([]interpreter.Value, len(logs))
-			for i, log := range logs {
-				memoryUsage := common.NewStringMemoryUsage(len(log))
-				values[i] = interpreter.NewStringValue(
-					inter,
-					memoryUsage,
-					func() string {
-						return log
-					},
-				)
-			}
 
-			return interpreter.NewArrayValue(
-				inter,
+			return stringSliceToArrayValue(
+				invocation.Interpreter,
 				invocation.LocationRange,
-				arrayType,
-				common.ZeroAddress,
-				values...,
+				logs,
 			)
 		},
 	)
@@ -848,8 +1216,897 @@ func (t *testEmulatorBackendType) newLoadSnapshotFunction(
 	)
 }
 
-func (t *testEmulatorBackendType) newEmulatorBackend(
-	inter *interpreter.Interpreter,
+// 'EmulatorBackend.storagePathExists' function
+
+const testEmulatorBackendTypeStoragePathExistsFunctionName = "storagePathExists"
+
+const testEmulatorBackendTypeStoragePathExistsFunctionDocString = `
+Returns true if a value is stored at the given storage path
+of the given account, without reading the value.
+`
+
+func (t *testEmulatorBackendType) newStoragePathExistsFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.storagePathExistsFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			path, ok := invocation.Arguments[1].(interpreter.PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			exists, err := blockchain.StoragePathExists(common.Address(address), path)
+			if err != nil {
+				panic(err)
+			}
+
+			return interpreter.AsBoolValue(exists)
+		},
+	)
+}
+
+// 'EmulatorBackend.executeScriptAtHeight' function
+
+const testEmulatorBackendTypeExecuteScriptAtHeightFunctionName = "executeScriptAtHeight"
+
+const testEmulatorBackendTypeExecuteScriptAtHeightFunctionDocString = `
+Executes a script against the state of the blockchain at the given block height,
+and returns the script return value and the status.
+The 'returnValue' field of the result will be nil if the script failed.
+`
+
+func (t *testEmulatorBackendType) newExecuteScriptAtHeightFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.executeScriptAtHeightFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+
+			script, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			args, err := arrayValueToSlice(
+				inter,
+				invocation.Arguments[1],
+				invocation.LocationRange,
+			)
+			if err != nil {
+				panic(errors.NewUnexpectedErrorFromCause(err))
+			}
+
+			height, ok := invocation.Arguments[2].(interpreter.UInt64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			result := blockchain.RunScriptAtHeight(inter, script.Str, args, uint64(height))
+
+			return newScriptResult(inter, result.Value, result)
+		},
+	)
+}
+
+// 'EmulatorBackend.getContractInitCount' function
+
+const testEmulatorBackendTypeGetContractInitCountFunctionName = "getContractInitCount"
+
+const testEmulatorBackendTypeGetContractInitCountFunctionDocString = `
+Returns the number of times the initializer of the contract
+with the given name has run.
+`
+
+func (t *testEmulatorBackendType) newGetContractInitCountFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.getContractInitCountFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			count, err := blockchain.ContractInitCount(name.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			return interpreter.NewUInt64Value(
+				invocation.Interpreter,
+				func() uint64 {
+					return count
+				},
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.getContractConstant' function
+
+const testEmulatorBackendTypeGetContractConstantFunctionName = "getContractConstant"
+
+const testEmulatorBackendTypeGetContractConstantFunctionDocString = `
+Returns the value of the constant field with the given name
+of the deployed contract with the given name.
+`
+
+func (t *testEmulatorBackendType) newGetContractConstantFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.getContractConstantFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			fieldName, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			value, err := blockchain.ContractConstant(
+				invocation.Interpreter,
+				name.Str,
+				fieldName.Str,
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			return value
+		},
+	)
+}
+
+// 'EmulatorBackend.deployContractWithCost' function
+
+const testEmulatorBackendTypeDeployContractWithCostFunctionName = "deployContractWithCost"
+
+const testEmulatorBackendTypeDeployContractWithCostFunctionDocString = `
+Deploys a given contract, and initializes it with the arguments.
+Returns the result of the deployment, which includes
+the computation and storage used by the deployment.
+`
+
+func (t *testEmulatorBackendType) newDeployContractWithCostFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.deployContractWithCostFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+
+			// Contract name
+			name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// Contract file path
+			path, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// Contract init arguments
+			args, err := arrayValueToSlice(
+				inter,
+				invocation.Arguments[2],
+				invocation.LocationRange,
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			result := blockchain.DeployContractWithCost(
+				inter,
+				name.Str,
+				path.Str,
+				args,
+			)
+
+			return newDeploymentResult(inter, result)
+		},
+	)
+}
+
+// 'EmulatorBackend.executeScriptWithAccounts' function
+
+const testEmulatorBackendTypeExecuteScriptWithAccountsFunctionName = "executeScriptWithAccounts"
+
+const testEmulatorBackendTypeExecuteScriptWithAccountsFunctionDocString = `
+Executes a script against the given mocked accounts,
+and returns the script return value and the status.
+The 'returnValue' field of the result will be nil if the script failed.
+`
+
+func (t *testEmulatorBackendType) newExecuteScriptWithAccountsFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.executeScriptWithAccountsFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			script, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			args, err := arrayValueToSlice(
+				inter,
+				invocation.Arguments[1],
+				locationRange,
+			)
+			if err != nil {
+				panic(errors.NewUnexpectedErrorFromCause(err))
+			}
+
+			accounts := mockedAccountsArrayValueToSlice(
+				inter,
+				invocation.Arguments[2],
+				locationRange,
+			)
+
+			result := blockchain.RunScriptWithAccounts(inter, script.Str, args, accounts)
+
+			return newScriptResult(inter, result.Value, result)
+		},
+	)
+}
+
+// 'EmulatorBackend.getCapabilityControllerCount' function
+
+const testEmulatorBackendTypeGetCapabilityControllerCountFunctionName = "getCapabilityControllerCount"
+
+const testEmulatorBackendTypeGetCapabilityControllerCountFunctionDocString = `
+Returns the number of storage capability controllers
+of the account with the given address which target the given storage path.
+`
+
+func (t *testEmulatorBackendType) newGetCapabilityControllerCountFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.getCapabilityControllerCountFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			path, ok := invocation.Arguments[1].(interpreter.PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			count, err := blockchain.CapabilityControllerCount(common.Address(address), path)
+			if err != nil {
+				panic(err)
+			}
+
+			return interpreter.NewIntValueFromInt64(
+				invocation.Interpreter,
+				int64(count),
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.capabilityControllers' function
+
+const testEmulatorBackendTypeCapabilityControllersFunctionName = "capabilityControllers"
+
+const testEmulatorBackendTypeCapabilityControllersFunctionDocString = `
+Returns the storage capability controllers
+of the account with the given address which target the given storage path,
+ordered by capability ID.
+`
+
+func (t *testEmulatorBackendType) newCapabilityControllersFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.capabilityControllersFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			path, ok := invocation.Arguments[1].(interpreter.PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			controllers, err := blockchain.CapabilityControllers(common.Address(address), path)
+			if err != nil {
+				panic(err)
+			}
+
+			// All returned controllers target the given path
+			targetingControllers := make([]CapabilityController, 0, len(controllers))
+			for _, controller := range controllers {
+				controller.TargetPath = path
+				targetingControllers = append(targetingControllers, controller)
+			}
+
+			return t.newCapabilityControllersValue(
+				invocation.Interpreter,
+				invocation.LocationRange,
+				t.capabilityControllersFunctionType,
+				targetingControllers,
+			)
+		},
+	)
+}
+
+func (t *testEmulatorBackendType) newCapabilityControllersValue(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	functionType *sema.FunctionType,
+	controllers []CapabilityController,
+) *interpreter.ArrayValue {
+
+	// Create the 'CapabilityController' values by calling the constructor.
+	controllerConstructor := getConstructor(inter, testCapabilityControllerTypeName)
+
+	values := make([]interpreter.Value, 0, len(controllers))
+	for _, controller := range controllers {
+		value, err := inter.InvokeExternally(
+			controllerConstructor,
+			controllerConstructor.Type,
+			[]interpreter.Value{
+				interpreter.NewUnmeteredUInt64Value(controller.CapabilityID),
+				interpreter.NewTypeValue(inter, controller.BorrowType),
+				controller.TargetPath,
+			},
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		values = append(values, value)
+	}
+
+	arrayType := interpreter.ConvertSemaArrayTypeToStaticArrayType(
+		inter,
+		functionType.ReturnTypeAnnotation.Type.(sema.ArrayType),
+	)
+
+	return interpreter.NewArrayValue(
+		inter,
+		locationRange,
+		arrayType,
+		common.ZeroAddress,
+		values...,
+	)
+}
+
+// 'EmulatorBackend.getCollectionSize' function
+
+const testEmulatorBackendTypeGetCollectionSizeFunctionName = "getCollectionSize"
+
+const testEmulatorBackendTypeGetCollectionSizeFunctionDocString = `
+Returns the number of elements in the resource collection
+stored at the given storage path of the account with the given address.
+`
+
+func (t *testEmulatorBackendType) newGetCollectionSizeFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.getCollectionSizeFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			path, ok := invocation.Arguments[1].(interpreter.PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			size, err := blockchain.CollectionSize(common.Address(address), path)
+			if err != nil {
+				panic(err)
+			}
+
+			return interpreter.NewIntValueFromInt64(
+				invocation.Interpreter,
+				int64(size),
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.addTransactionWithPayer' function
+
+const testEmulatorBackendTypeAddTransactionWithPayerFunctionName = "addTransactionWithPayer"
+
+const testEmulatorBackendTypeAddTransactionWithPayerFunctionDocString = `
+Add a transaction to the current block,
+with a proposer and a payer which are distinct from the authorizers.
+`
+
+func (t *testEmulatorBackendType) newAddTransactionWithPayerFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.addTransactionWithPayerFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			code, authorizers, signerAccounts, args := transactionFromValue(
+				inter,
+				invocation.Arguments[0],
+				locationRange,
+			)
+
+			accountValue := func(argument interpreter.Value) *Account {
+				value, ok := argument.(interpreter.MemberAccessibleValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				return accountFromValue(inter, value, locationRange)
+			}
+
+			proposer := accountValue(invocation.Arguments[1])
+			payer := accountValue(invocation.Arguments[2])
+
+			err := blockchain.AddTransactionWithPayer(
+				inter,
+				code,
+				authorizers,
+				signerAccounts,
+				proposer,
+				payer,
+				args,
+			)
+
+			if err != nil {
+				panic(err)
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'EmulatorBackend.randomHistory' function
+
+const testEmulatorBackendTypeRandomHistoryFunctionName = "randomHistory"
+
+const testEmulatorBackendTypeRandomHistoryFunctionDocString = `
+Returns the random bytes read from the random source of the blockchain
+by the last executed script or transaction, one entry per read, in order.
+`
+
+func (t *testEmulatorBackendType) newRandomHistoryFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.randomHistoryFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+
+			history := blockchain.RandomHistory()
+
+			values := make([]interpreter.Value, 0, len(history))
+			for _, bytes := range history {
+				values = append(
+					values,
+					interpreter.ByteSliceToByteArrayValue(inter, bytes),
+				)
+			}
+
+			return interpreter.NewArrayValue(
+				inter,
+				invocation.LocationRange,
+				interpreter.NewVariableSizedStaticType(
+					inter,
+					interpreter.ByteArrayStaticType,
+				),
+				common.ZeroAddress,
+				values...,
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.currentBlock' function
+
+const testEmulatorBackendTypeCurrentBlockFunctionName = "currentBlock"
+
+const testEmulatorBackendTypeCurrentBlockFunctionDocString = `
+Returns the current block of the blockchain,
+which reflects the time moved by moveTime.
+`
+
+func (t *testEmulatorBackendType) newCurrentBlockFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.currentBlockFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			block, err := blockchain.CurrentBlock()
+			if err != nil {
+				panic(err)
+			}
+
+			return NewBlockValue(
+				invocation.Interpreter,
+				invocation.LocationRange,
+				block,
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.createAccountWithKeys' function
+
+const testEmulatorBackendTypeCreateAccountWithKeysFunctionName = "createAccountWithKeys"
+
+const testEmulatorBackendTypeCreateAccountWithKeysFunctionDocString = `
+Creates an account with one key per given weight,
+by submitting an account creation transaction.
+The account signs transactions with all of its keys.
+`
+
+func (t *testEmulatorBackendType) newCreateAccountWithKeysFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.createAccountWithKeysFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			weightValues, err := arrayValueToSlice(
+				inter,
+				invocation.Arguments[0],
+				locationRange,
+			)
+			if err != nil {
+				panic(errors.NewUnexpectedErrorFromCause(err))
+			}
+
+			keyWeights := make([]int, 0, len(weightValues))
+			for _, weightValue := range weightValues {
+				weight, ok := weightValue.(interpreter.UFix64Value)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				keyWeights = append(keyWeights, weight.ToInt(locationRange))
+			}
+
+			account, err := blockchain.CreateAccountWithKeys(keyWeights)
+			if err != nil {
+				panic(err)
+			}
+
+			return newTestAccountValue(
+				inter,
+				locationRange,
+				account,
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.setComputationLimit' function
+
+const testEmulatorBackendTypeSetComputationLimitFunctionName = "setComputationLimit"
+
+const testEmulatorBackendTypeSetComputationLimitFunctionDocString = `
+Sets the computation limit for subsequently executed
+transactions and scripts.
+`
+
+func (t *testEmulatorBackendType) newSetComputationLimitFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.setComputationLimitFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			limit, ok := invocation.Arguments[0].(interpreter.UInt64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			blockchain.SetComputationLimit(uint64(limit))
+			return interpreter.Void
+		},
+	)
+}
+
+// 'EmulatorBackend.allCapabilityControllers' function
+
+const testEmulatorBackendTypeAllCapabilityControllersFunctionName = "allCapabilityControllers"
+
+const testEmulatorBackendTypeAllCapabilityControllersFunctionDocString = `
+Returns all storage capability controllers
+of the account with the given address, ordered by capability ID.
+`
+
+func (t *testEmulatorBackendType) newAllCapabilityControllersFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.allCapabilityControllersFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			controllers, err := blockchain.AllCapabilityControllers(common.Address(address))
+			if err != nil {
+				panic(err)
+			}
+
+			return t.newCapabilityControllersValue(
+				invocation.Interpreter,
+				invocation.LocationRange,
+				t.allCapabilityControllersFunctionType,
+				controllers,
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.findDeprecatedValues' function
+
+const testEmulatorBackendTypeFindDeprecatedValuesFunctionName = "findDeprecatedValues"
+
+const testEmulatorBackendTypeFindDeprecatedValuesFunctionDocString = `
+Returns the values stored in the account with the given address
+which are deprecated and must be migrated, ordered by storage path.
+`
+
+func (t *testEmulatorBackendType) newFindDeprecatedValuesFunction(
+	inter *interpreter.Interpreter,
+	emulatorBackend interpreter.MemberAccessibleValue,
+	blockchain Blockchain,
+) interpreter.BoundFunctionValue {
+	return interpreter.NewUnmeteredBoundHostFunctionValue(
+		inter,
+		emulatorBackend,
+		t.findDeprecatedValuesFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			storedValues, err := blockchain.StoredValues(inter, common.Address(address))
+			if err != nil {
+				panic(err)
+			}
+
+			paths := make([]string, 0, len(storedValues))
+			for path := range storedValues { //nolint:maprange
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+
+			// Create the 'DeprecatedValue' values by calling the constructor.
+			deprecatedValueConstructor := getConstructor(inter, testDeprecatedValueTypeName)
+
+			var values []interpreter.Value
+			for _, path := range paths {
+				reason := deprecatedValueReason(inter, locationRange, storedValues[path])
+				if reason == "" {
+					continue
+				}
+
+				value, err := inter.InvokeExternally(
+					deprecatedValueConstructor,
+					deprecatedValueConstructor.Type,
+					[]interpreter.Value{
+						interpreter.NewUnmeteredStringValue(path),
+						interpreter.NewUnmeteredStringValue(reason),
+					},
+				)
+				if err != nil {
+					panic(err)
+				}
+
+				values = append(values, value)
+			}
+
+			arrayType := interpreter.ConvertSemaArrayTypeToStaticArrayType(
+				inter,
+				t.findDeprecatedValuesFunctionType.ReturnTypeAnnotation.Type.(sema.ArrayType),
+			)
+
+			return interpreter.NewArrayValue(
+				inter,
+				locationRange,
+				arrayType,
+				common.ZeroAddress,
+				values...,
+			)
+		},
+	)
+}
+
+// deprecatedValueReason returns why the given value is deprecated,
+// or an empty string if neither the value nor any of its nested values is deprecated.
+func deprecatedValueReason(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	value interpreter.Value,
+) string {
+	switch value := value.(type) {
+	case interpreter.LinkValue: //nolint:staticcheck
+		return "un-migrated link"
+
+	case *interpreter.PathCapabilityValue: //nolint:staticcheck
+		return "un-migrated path capability"
+
+	case interpreter.TypeValue:
+		if value.Type != nil {
+			reason := deprecatedTypeReason(value.Type)
+			if reason != "" {
+				return reason
+			}
+		}
+	}
+
+	reason := deprecatedTypeReason(value.StaticType(inter))
+	if reason != "" {
+		return reason
+	}
+
+	value.Walk(
+		inter,
+		func(child interpreter.Value) {
+			if reason == "" {
+				reason = deprecatedValueReason(inter, locationRange, child)
+			}
+		},
+		locationRange,
+	)
+
+	return reason
+}
+
+// deprecatedTypeReason returns why the given static type is deprecated,
+// or an empty string if it is not deprecated.
+func deprecatedTypeReason(staticType interpreter.StaticType) string {
+	legacyRestrictedType := findLegacyRestrictedType(staticType)
+	if legacyRestrictedType != nil {
+		// Render the type in the restricted type syntax, e.g. `R{I}`
+		return fmt.Sprintf(
+			"legacy restricted type: %s%s",
+			legacyRestrictedType.LegacyType,
+			legacyRestrictedType,
+		)
+	}
+
+	if staticType.IsDeprecated() {
+		return fmt.Sprintf("deprecated type: %s", staticType)
+	}
+
+	return ""
+}
+
+// findLegacyRestrictedType returns the intersection type in the given static type
+// which was decoded from a restricted type, e.g. `R{I}`, if any.
+func findLegacyRestrictedType(staticType interpreter.StaticType) *interpreter.IntersectionStaticType {
+	switch staticType := staticType.(type) {
+	case *interpreter.IntersectionStaticType:
+		if staticType.LegacyType != nil {
+			return staticType
+		}
+
+	case *interpreter.OptionalStaticType:
+		return findLegacyRestrictedType(staticType.Type)
+
+	case *interpreter.VariableSizedStaticType:
+		return findLegacyRestrictedType(staticType.Type)
+
+	case *interpreter.ConstantSizedStaticType:
+		return findLegacyRestrictedType(staticType.Type)
+
+	case *interpreter.DictionaryStaticType:
+		legacyRestrictedType := findLegacyRestrictedType(staticType.KeyType)
+		if legacyRestrictedType != nil {
+			return legacyRestrictedType
+		}
+		return findLegacyRestrictedType(staticType.ValueType)
+
+	case *interpreter.ReferenceStaticType:
+		return findLegacyRestrictedType(staticType.ReferencedType)
+
+	case *interpreter.CapabilityStaticType:
+		if staticType.BorrowType != nil {
+			return findLegacyRestrictedType(staticType.BorrowType)
+		}
+	}
+
+	return nil
+}
+
+func (t *testEmulatorBackendType) newEmulatorBackend(
+	inter *interpreter.Interpreter,
 	blockchain Blockchain,
 	locationRange interpreter.LocationRange,
 ) *interpreter.CompositeValue {
@@ -921,6 +2178,74 @@ func (t *testEmulatorBackendType) newEmulatorBackend(
 			Name:  testEmulatorBackendTypeGetAccountFunctionName,
 			Value: t.newGetAccountFunction(inter, emulatorBackend, blockchain),
 		},
+		{
+			Name:  testEmulatorBackendTypeStoragePathExistsFunctionName,
+			Value: t.newStoragePathExistsFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeExecuteScriptAtHeightFunctionName,
+			Value: t.newExecuteScriptAtHeightFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeGetContractInitCountFunctionName,
+			Value: t.newGetContractInitCountFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeGetContractConstantFunctionName,
+			Value: t.newGetContractConstantFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeDeployContractWithCostFunctionName,
+			Value: t.newDeployContractWithCostFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeDeployContractWithCodeFunctionName,
+			Value: t.newDeployContractWithCodeFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeExecuteScriptWithAccountsFunctionName,
+			Value: t.newExecuteScriptWithAccountsFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeGetCapabilityControllerCountFunctionName,
+			Value: t.newGetCapabilityControllerCountFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeCapabilityControllersFunctionName,
+			Value: t.newCapabilityControllersFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeGetCollectionSizeFunctionName,
+			Value: t.newGetCollectionSizeFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeAddTransactionWithPayerFunctionName,
+			Value: t.newAddTransactionWithPayerFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeRandomHistoryFunctionName,
+			Value: t.newRandomHistoryFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeCurrentBlockFunctionName,
+			Value: t.newCurrentBlockFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeCreateAccountWithKeysFunctionName,
+			Value: t.newCreateAccountWithKeysFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeSetComputationLimitFunctionName,
+			Value: t.newSetComputationLimitFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeAllCapabilityControllersFunctionName,
+			Value: t.newAllCapabilityControllersFunction(inter, emulatorBackend, blockchain),
+		},
+		{
+			Name:  testEmulatorBackendTypeFindDeprecatedValuesFunctionName,
+			Value: t.newFindDeprecatedValuesFunction(inter, emulatorBackend, blockchain),
+		},
 	}
 
 	for _, field := range fields {
@@ -20,10 +20,17 @@
 package stdlib
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/onflow/atree"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -52,48 +59,88 @@ func newTestContractInterpreterWithTestFramework(
 	code string,
 	testFramework TestFramework,
 ) (*interpreter.Interpreter, error) {
-	program, err := parser.ParseProgram(
-		nil,
-		[]byte(code),
-		parser.Config{},
-	)
-	require.NoError(t, err)
+	return newTestContractInterpreterWithImports(t, code, testFramework, nil)
+}
 
+// newTestContractInterpreterWithImports is like newTestContractInterpreterWithTestFramework,
+// but the program may additionally import the given programs, keyed by location
+func newTestContractInterpreterWithImports(
+	t *testing.T,
+	code string,
+	testFramework TestFramework,
+	imports map[common.Location]string,
+) (*interpreter.Interpreter, error) {
 	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
 	baseValueActivation.DeclareValue(AssertFunction)
 	baseValueActivation.DeclareValue(PanicFunction)
 
-	checker, err := sema.NewChecker(
-		program,
-		utils.TestLocation,
-		nil,
-		&sema.Config{
-			BaseValueActivationHandler: func(_ common.Location) *sema.VariableActivation {
-				return baseValueActivation
-			},
-			AccessCheckMode: sema.AccessCheckModeStrict,
-			ImportHandler: func(
-				checker *sema.Checker,
-				importedLocation common.Location,
-				importRange ast.Range,
-			) (
-				sema.Import,
-				error,
-			) {
-				if importedLocation == TestContractLocation {
-					return sema.ElaborationImport{
-						Elaboration: GetTestContractType().Checker.Elaboration,
-					}, nil
-				}
+	importedCheckers := map[common.Location]*sema.Checker{}
 
+	var checkerConfig *sema.Config
+
+	newChecker := func(code string, location common.Location) *sema.Checker {
+		program, err := parser.ParseProgram(
+			nil,
+			[]byte(code),
+			parser.Config{},
+		)
+		require.NoError(t, err)
+
+		checker, err := sema.NewChecker(
+			program,
+			location,
+			nil,
+			checkerConfig,
+		)
+		require.NoError(t, err)
+
+		return checker
+	}
+
+	checkerConfig = &sema.Config{
+		BaseValueActivationHandler: func(_ common.Location) *sema.VariableActivation {
+			return baseValueActivation
+		},
+		AccessCheckMode: sema.AccessCheckModeStrict,
+		ImportHandler: func(
+			checker *sema.Checker,
+			importedLocation common.Location,
+			importRange ast.Range,
+		) (
+			sema.Import,
+			error,
+		) {
+			if importedLocation == TestContractLocation {
+				return sema.ElaborationImport{
+					Elaboration: GetTestContractType().Checker.Elaboration,
+				}, nil
+			}
+
+			importedCode, ok := imports[importedLocation]
+			if !ok {
 				return nil, errors.New("invalid import")
-			},
-			ContractValueHandler: TestCheckerContractValueHandler,
+			}
+
+			importedChecker, ok := importedCheckers[importedLocation]
+			if !ok {
+				importedChecker = newChecker(importedCode, importedLocation)
+				err := importedChecker.Check()
+				if err != nil {
+					return nil, err
+				}
+				importedCheckers[importedLocation] = importedChecker
+			}
+
+			return sema.ElaborationImport{
+				Elaboration: importedChecker.Elaboration,
+			}, nil
 		},
-	)
-	require.NoError(t, err)
+		ContractValueHandler: TestCheckerContractValueHandler,
+	}
 
-	err = checker.Check()
+	checker := newChecker(code, utils.TestLocation)
+
+	err := checker.Check()
 	if err != nil {
 		return nil, err
 	}
@@ -115,18 +162,22 @@ func newTestContractInterpreterWithTestFramework(
 				return baseActivation
 			},
 			ImportLocationHandler: func(inter *interpreter.Interpreter, location common.Location) interpreter.Import {
+				var program *interpreter.Program
 				if location == TestContractLocation {
-					program := interpreter.ProgramFromChecker(GetTestContractType().Checker)
-					subInterpreter, err := inter.NewSubInterpreter(program, location)
-					if err != nil {
-						panic(err)
-					}
-					return interpreter.InterpreterImport{
-						Interpreter: subInterpreter,
-					}
+					program = interpreter.ProgramFromChecker(GetTestContractType().Checker)
+				} else if importedChecker, ok := importedCheckers[location]; ok {
+					program = interpreter.ProgramFromChecker(importedChecker)
+				} else {
+					return nil
 				}
 
-				return nil
+				subInterpreter, err := inter.NewSubInterpreter(program, location)
+				if err != nil {
+					panic(err)
+				}
+				return interpreter.InterpreterImport{
+					Interpreter: subInterpreter,
+				}
 			},
 			ContractValueHandler: NewTestInterpreterContractValueHandler(testFramework),
 			UUIDHandler: func() (uint64, error) {
@@ -964,10 +1015,25 @@ func TestAssertEqual(t *testing.T) {
 		_, err = inter.Invoke("testNotEqual")
 		require.Error(t, err)
 		assert.ErrorAs(t, err, &AssertionError{})
-		assert.ErrorContains(
-			t,
-			err,
-			"not equal: expected: {1: true, 2: false}, actual: {2: true, 1: true}",
+
+		// The order of the entries in the message is the iteration order
+		// of the dictionaries, which is not defined, so check each entry
+		message := err.Error()
+		const prefix = "not equal: expected: "
+		require.Contains(t, message, prefix)
+		message = message[strings.Index(message, prefix)+len(prefix):]
+
+		expectedMessage, actualMessage, ok := strings.Cut(message, ", actual: ")
+		require.True(t, ok)
+		actualMessage, _, _ = strings.Cut(actualMessage, "\n")
+
+		assert.ElementsMatch(t,
+			[]string{"1: true", "2: false"},
+			strings.Split(strings.Trim(expectedMessage, "{}"), ", "),
+		)
+		assert.ElementsMatch(t,
+			[]string{"1: true", "2: true"},
+			strings.Split(strings.Trim(actualMessage, "{}"), ", "),
 		)
 	})
 
@@ -1046,109 +1112,121 @@ func TestAssertEqual(t *testing.T) {
 	})
 }
 
-func TestTestBeSucceededMatcher(t *testing.T) {
+func TestAssertSameReference(t *testing.T) {
 
 	t.Parallel()
 
-	t.Run("matcher beSucceeded with ScriptResult", func(t *testing.T) {
+	t.Run("same resource", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun testMatch(): Bool {
-                let successful = Test.beSucceeded()
-
-                let scriptResult = Test.ScriptResult(
-                    status: Test.ResultStatus.succeeded,
-                    returnValue: 42,
-                    error: nil
-                )
-
-                return successful.test(scriptResult)
-            }
+            resource R {}
 
             access(all)
-            fun testNoMatch(): Bool {
-                let successful = Test.beSucceeded()
+            fun test() {
+                let r <- create R()
+                let ref1 = &r as &R
+                let ref2 = &r as &R
 
-                let scriptResult = Test.ScriptResult(
-                    status: Test.ResultStatus.failed,
-                    returnValue: nil,
-                    error: Test.Error("Exceeding limit")
-                )
+                Test.assertSameReference(ref1, ref2)
 
-                return successful.test(scriptResult)
+                destroy r
             }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		result, err := inter.Invoke("testMatch")
-		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
-
-		result, err = inter.Invoke("testNoMatch")
+		_, err = inter.Invoke("test")
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
 	})
 
-	t.Run("matcher beSucceeded with TransactionResult", func(t *testing.T) {
+	t.Run("same array element", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun testMatch(): Bool {
-                let successful = Test.beSucceeded()
-
-                let transactionResult = Test.TransactionResult(
-                    status: Test.ResultStatus.succeeded,
-                    error: nil
-                )
-
-                return successful.test(transactionResult)
-            }
+            resource R {}
 
             access(all)
-            fun testNoMatch(): Bool {
-                let successful = Test.beSucceeded()
+            fun test() {
+                let rs <- [<-create R()]
+                let ref1 = &rs[0] as &R
+                let ref2 = &rs as &[R]
 
-                let transactionResult = Test.TransactionResult(
-                    status: Test.ResultStatus.failed,
-                    error: Test.Error("Exceeded Limit")
-                )
+                Test.assertSameReference(ref1, ref2[0])
 
-                return successful.test(transactionResult)
+                destroy rs
             }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		result, err := inter.Invoke("testMatch")
+		_, err = inter.Invoke("test")
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
+	})
 
-		result, err = inter.Invoke("testNoMatch")
+	t.Run("distinct resources", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            resource R {}
+
+            access(all)
+            fun test() {
+                let r1 <- create R()
+                let r2 <- create R()
+
+                Test.assertSameReference(&r1 as &R, &r2 as &R)
+
+                destroy r1
+                destroy r2
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(t, err, "assertion failed: not the same reference")
 	})
 
-	t.Run("matcher beSucceeded with type mismatch", func(t *testing.T) {
+	t.Run("equal but distinct structs", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun test(): Bool {
-                let successful = Test.beSucceeded()
+            struct S {
+                access(all)
+                let x: Int
 
-                return successful.test("hello")
+                init(x: Int) {
+                    self.x = x
+                }
+            }
+
+            access(all)
+            fun test() {
+                let s1 = S(x: 1)
+                let s2 = S(x: 1)
+                let ref1 = &s1 as &S
+                let ref2 = &s2 as &S
+
+                Test.assertEqual(ref1.x, ref2.x)
+                Test.assertSameReference(ref1, ref2)
             }
         `
 
@@ -1157,548 +1235,739 @@ func TestTestBeSucceededMatcher(t *testing.T) {
 
 		_, err = inter.Invoke("test")
 		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(t, err, "assertion failed: not the same reference")
 	})
 }
 
-func TestTestBeFailedMatcher(t *testing.T) {
+func TestAssertAllMoved(t *testing.T) {
 
 	t.Parallel()
 
-	t.Run("matcher beFailed with ScriptResult", func(t *testing.T) {
-		t.Parallel()
+	const contract = `
+        access(all)
+        resource R {}
 
-		script := `
-            import Test
+        access(all)
+        resource Collection {
 
             access(all)
-            fun testMatch(): Bool {
-                let failed = Test.beFailed()
+            var items: @[R]
 
-                let scriptResult = Test.ScriptResult(
-                    status: Test.ResultStatus.failed,
-                    returnValue: nil,
-                    error: Test.Error("Exceeding limit")
-                )
+            init() {
+                self.items <- []
+            }
 
-                return failed.test(scriptResult)
+            access(all)
+            fun deposit(_ item: @R) {
+                self.items.append(<-item)
             }
+        }
+    `
+
+	t.Run("all moved", func(t *testing.T) {
+		t.Parallel()
 
+		script := `
+            import Test
+        ` + contract + `
             access(all)
-            fun testNoMatch(): Bool {
-                let failed = Test.beFailed()
+            fun test() {
+                let first <- create R()
+                let second <- create R()
+                let third <- create R()
 
-                let scriptResult = Test.ScriptResult(
-                    status: Test.ResultStatus.succeeded,
-                    returnValue: 42,
-                    error: nil
-                )
+                let references: [&AnyResource] = [&first as &R, &second as &R, &third as &R]
 
-                return failed.test(scriptResult)
+                let collection <- create Collection()
+                collection.deposit(<-first)
+                collection.deposit(<-second)
+                destroy third
+
+                Test.assertAllMoved(references)
+
+                destroy collection
             }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		result, err := inter.Invoke("testMatch")
-		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
-
-		result, err = inter.Invoke("testNoMatch")
+		_, err = inter.Invoke("test")
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
 	})
 
-	t.Run("matcher beFailed with TransactionResult", func(t *testing.T) {
+	t.Run("not all moved", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
-
+        ` + contract + `
             access(all)
-            fun testMatch(): Bool {
-                let failed = Test.beFailed()
-
-                let transactionResult = Test.TransactionResult(
-                    status: Test.ResultStatus.failed,
-                    error: Test.Error("Exceeding limit")
-                )
+            fun test() {
+                let first <- create R()
+                let second <- create R()
+                let third <- create R()
 
-                return failed.test(transactionResult)
-            }
+                let references: [&AnyResource] = [&first as &R, &second as &R, &third as &R]
 
-            access(all)
-            fun testNoMatch(): Bool {
-                let failed = Test.beFailed()
+                let collection <- create Collection()
+                collection.deposit(<-first)
+                collection.deposit(<-third)
 
-                let transactionResult = Test.TransactionResult(
-                    status: Test.ResultStatus.succeeded,
-                    error: nil
-                )
+                Test.assertAllMoved(references)
 
-                return failed.test(transactionResult)
+                destroy collection
+                destroy second
             }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		result, err := inter.Invoke("testMatch")
-		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
-
-		result, err = inter.Invoke("testNoMatch")
-		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
+		_, err = inter.Invoke("test")
+		require.ErrorContains(t, err, "assertion failed: 1 of 3 resources were not moved: 1 (R)")
 	})
 
-	t.Run("matcher beFailed with type mismatch", func(t *testing.T) {
+	t.Run("borrowed references", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
-
+        ` + contract + `
             access(all)
-            fun test(): Bool {
-                let failed = Test.beFailed()
+            fun createR(): @R {
+                return <- create R()
+            }
 
-                return failed.test([])
+            access(all)
+            fun test(_ references: [&AnyResource]) {
+                Test.assertAllMoved(references)
             }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		_, err = inter.Invoke("test")
-		require.Error(t, err)
+		resource, err := inter.Invoke("createR")
+		require.NoError(t, err)
+
+		address := common.MustBytesToAddress([]byte{0x1})
+		storageDomain := common.PathDomainStorage.Identifier()
+
+		resource = resource.Transfer(
+			inter,
+			interpreter.EmptyLocationRange,
+			atree.Address(address),
+			true,
+			nil,
+			nil,
+			true,
+		)
+		inter.WriteStored(address, storageDomain, interpreter.StringStorageMapKey("r"), resource)
+
+		resourceType := inter.Program.Elaboration.CompositeType(utils.TestLocation.TypeID(nil, "R"))
+
+		newReferences := func() *interpreter.ArrayValue {
+			return interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: interpreter.NewReferenceStaticType(
+						nil,
+						interpreter.UnauthorizedAccess,
+						interpreter.PrimitiveStaticTypeAnyResource,
+					),
+				},
+				common.ZeroAddress,
+				interpreter.NewUnmeteredStorageReferenceValue(
+					interpreter.UnauthorizedAccess,
+					address,
+					interpreter.NewUnmeteredPathValue(common.PathDomainStorage, "r"),
+					resourceType,
+				),
+				interpreter.NewUnmeteredStorageReferenceValue(
+					interpreter.UnauthorizedAccess,
+					address,
+					interpreter.NewUnmeteredPathValue(common.PathDomainStorage, "empty"),
+					resourceType,
+				),
+			)
+		}
+
+		_, err = inter.Invoke("test", newReferences())
+		require.ErrorContains(t, err, "assertion failed: 1 of 2 resources were not moved: 0 (R)")
+
+		inter.WriteStored(address, storageDomain, interpreter.StringStorageMapKey("r"), nil)
+
+		_, err = inter.Invoke("test", newReferences())
+		require.NoError(t, err)
 	})
 }
 
-func TestTestAssertErrorMatcher(t *testing.T) {
+func TestDescribe(t *testing.T) {
 
 	t.Parallel()
 
-	t.Run("with ScriptResult", func(t *testing.T) {
+	t.Run("nested resource", func(t *testing.T) {
 		t.Parallel()
 
-		const script = `
+		script := `
             import Test
 
             access(all)
-            fun testMatch() {
-                let result = Test.ScriptResult(
-                    status: Test.ResultStatus.failed,
-                    returnValue: nil,
-                    error: Test.Error("computation exceeding limit")
-                )
+            resource Inner {
+                access(all)
+                let name: String
 
-                Test.assertError(result, errorMessage: "exceeding limit")
+                init(name: String) {
+                    self.name = name
+                }
             }
 
             access(all)
-            fun testNoMatch() {
-                let result = Test.ScriptResult(
-                    status: Test.ResultStatus.failed,
-                    returnValue: nil,
-                    error: Test.Error("computation exceeding memory")
-                )
+            resource Outer {
+                access(all)
+                let balance: UFix64
 
-                Test.assertError(result, errorMessage: "exceeding limit")
+                access(all)
+                let inner: @Inner
+
+                access(all)
+                let items: @[Inner]
+
+                init() {
+                    self.balance = 1.5
+                    self.inner <- create Inner(name: "nested")
+                    self.items <- [<-create Inner(name: "item")]
+                }
             }
 
             access(all)
-            fun testNoError() {
-                let result = Test.ScriptResult(
-                    status: Test.ResultStatus.succeeded,
-                    returnValue: 42,
-                    error: nil
-                )
+            fun test() {
+                let outer <- create Outer()
 
-                Test.assertError(result, errorMessage: "exceeding limit")
+                let description = Test.describe(&outer as &Outer)
+
+                Test.assertEqual(1.5, description["balance"]! as! UFix64)
+
+                let inner = description["inner"]! as! {String: AnyStruct}
+                Test.assertEqual("nested", inner["name"]! as! String)
+
+                let items = description["items"]! as! [AnyStruct]
+                let item = items[0] as! {String: AnyStruct}
+                Test.assertEqual("item", item["name"]! as! String)
+
+                // The resource was not moved
+                Test.assertEqual("nested", outer.inner.name)
+
+                destroy outer
             }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		_, err = inter.Invoke("testMatch")
+		_, err = inter.Invoke("test")
 		require.NoError(t, err)
+	})
 
-		_, err = inter.Invoke("testNoMatch")
-		require.Error(t, err)
-		assert.ErrorContains(t, err, "the error message did not contain the given sub-string")
-
-		_, err = inter.Invoke("testNoError")
-		require.Error(t, err)
-		assert.ErrorContains(t, err, "no error was found")
-	})
-
-	t.Run("with TransactionResult", func(t *testing.T) {
+	t.Run("non-composite resource", func(t *testing.T) {
 		t.Parallel()
 
-		const script = `
+		script := `
             import Test
 
             access(all)
-            fun testMatch() {
-                let result = Test.TransactionResult(
-                    status: Test.ResultStatus.failed,
-                    error: Test.Error("computation exceeding limit")
-                )
+            resource R {}
 
-                Test.assertError(result, errorMessage: "exceeding limit")
+            access(all)
+            fun test() {
+                let rs <- [<-create R()]
+                Test.describe(&rs as &[R])
+                destroy rs
             }
+        `
 
-            access(all)
-            fun testNoMatch() {
-                let result = Test.TransactionResult(
-                    status: Test.ResultStatus.failed,
-                    error: Test.Error("computation exceeding memory")
-                )
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
 
-                Test.assertError(result, errorMessage: "exceeding limit")
-            }
+		_, err = inter.Invoke("test")
+		require.ErrorContains(t, err, "cannot describe value of type [R]: expected a composite resource")
+	})
+}
 
-            access(all)
-            fun testNoError() {
-                let result = Test.TransactionResult(
-                    status: Test.ResultStatus.succeeded,
-                    error: nil
-                )
+func TestAssertCapabilityType(t *testing.T) {
 
-                Test.assertError(result, errorMessage: "exceeding limit")
-            }
-        `
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        access(all)
+        fun testInt(_ cap: Capability) {
+            Test.assertCapabilityType(cap, Type<&Int>())
+        }
+
+        access(all)
+        fun testArray(_ cap: Capability) {
+            Test.assertCapabilityType(cap, Type<auth(Mutate) &[Int]>())
+        }
+    `
+
+	newCapability := func(borrowType interpreter.StaticType) *interpreter.IDCapabilityValue {
+		return interpreter.NewUnmeteredCapabilityValue(
+			1,
+			interpreter.AddressValue{0x1},
+			borrowType,
+		)
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		t.Parallel()
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		_, err = inter.Invoke("testMatch")
+		_, err = inter.Invoke(
+			"testInt",
+			newCapability(
+				interpreter.NewReferenceStaticType(
+					nil,
+					interpreter.UnauthorizedAccess,
+					interpreter.PrimitiveStaticTypeInt,
+				),
+			),
+		)
 		require.NoError(t, err)
+	})
 
-		_, err = inter.Invoke("testNoMatch")
+	t.Run("mismatched", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke(
+			"testInt",
+			newCapability(
+				interpreter.NewReferenceStaticType(
+					nil,
+					interpreter.UnauthorizedAccess,
+					interpreter.PrimitiveStaticTypeString,
+				),
+			),
+		)
 		require.Error(t, err)
-		assert.ErrorContains(t, err, "the error message did not contain the given sub-string")
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: capability borrow type mismatch: expected: &Int, actual: &String",
+		)
+	})
 
-		_, err = inter.Invoke("testNoError")
+	t.Run("mismatched authorization", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke(
+			"testArray",
+			newCapability(
+				interpreter.NewReferenceStaticType(
+					nil,
+					interpreter.UnauthorizedAccess,
+					interpreter.NewVariableSizedStaticType(nil, interpreter.PrimitiveStaticTypeInt),
+				),
+			),
+		)
 		require.Error(t, err)
-		assert.ErrorContains(t, err, "no error was found")
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: capability borrow type mismatch: expected: auth(Mutate)&[Int], actual: &[Int]",
+		)
 	})
 }
 
-func TestTestBeNilMatcher(t *testing.T) {
+func TestEventField(t *testing.T) {
 
 	t.Parallel()
 
-	t.Run("matcher beNil", func(t *testing.T) {
+	newTestFramework := func() *mockedTestFramework {
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					events: func(inter *interpreter.Interpreter, eventType interpreter.StaticType) interpreter.Value {
+						event := interpreter.NewCompositeValue(
+							inter,
+							interpreter.EmptyLocationRange,
+							utils.TestLocation,
+							"Deposit",
+							common.CompositeKindEvent,
+							[]interpreter.CompositeField{
+								interpreter.NewUnmeteredCompositeField(
+									"amount",
+									interpreter.NewUnmeteredUFix64Value(10_50000000),
+								),
+								interpreter.NewUnmeteredCompositeField(
+									"to",
+									interpreter.AddressValue(common.MustBytesToAddress([]byte{0x1})),
+								),
+							},
+							common.ZeroAddress,
+						)
+
+						return interpreter.NewArrayValue(
+							inter,
+							interpreter.EmptyLocationRange,
+							interpreter.NewVariableSizedStaticType(inter, interpreter.PrimitiveStaticTypeAnyStruct),
+							common.ZeroAddress,
+							event,
+						)
+					},
+				}
+			},
+		}
+	}
+
+	t.Run("field", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun testMatch(): Bool {
-                let isNil = Test.beNil()
-
-                return isNil.test(nil)
-            }
+            event Deposit(amount: UFix64, to: Address)
 
             access(all)
-            fun testNoMatch(): Bool {
-                let isNil = Test.beNil()
-
-                return isNil.test([1, 2])
+            fun test() {
+                let deposit = Test.eventsOfType(Type<Deposit>())[0]
+                let amount: UFix64 = Test.eventField<UFix64>(deposit, "amount")
+                Test.assertEqual(10.5, amount)
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
-		require.NoError(t, err)
-
-		result, err := inter.Invoke("testMatch")
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
 
-		result, err = inter.Invoke("testNoMatch")
+		_, err = inter.Invoke("test")
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
 	})
-}
-
-func TestTestBeEmptyMatcher(t *testing.T) {
 
-	t.Parallel()
-
-	t.Run("matcher beEmpty with Array", func(t *testing.T) {
+	t.Run("wrong type", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun testMatch(): Bool {
-                let emptyArray = Test.beEmpty()
-
-                return emptyArray.test([])
-            }
+            event Deposit(amount: UFix64, to: Address)
 
             access(all)
-            fun testNoMatch(): Bool {
-                let emptyArray = Test.beEmpty()
-
-                return emptyArray.test([42, 23, 31])
+            fun test() {
+                let deposit = Test.eventsOfType(Type<Deposit>())[0]
+                Test.eventField<String>(deposit, "amount")
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
-		require.NoError(t, err)
-
-		result, err := inter.Invoke("testMatch")
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
 
-		result, err = inter.Invoke("testNoMatch")
-		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: field `amount` of event S.test.Deposit has type UFix64, expected type String",
+		)
 	})
 
-	t.Run("matcher beEmpty with Dictionary", func(t *testing.T) {
+	t.Run("missing field", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun testMatch(): Bool {
-                let emptyDict = Test.beEmpty()
-                let dict: {Bool: Int} = {}
-
-                return emptyDict.test(dict)
-            }
+            event Deposit(amount: UFix64, to: Address)
 
             access(all)
-            fun testNoMatch(): Bool {
-                let emptyDict = Test.beEmpty()
-                let dict: {Bool: Int} = {true: 1, false: 0}
-
-                return emptyDict.test(dict)
+            fun test() {
+                let deposit = Test.eventsOfType(Type<Deposit>())[0]
+                Test.eventField<Address>(deposit, "from")
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
-		require.NoError(t, err)
-
-		result, err := inter.Invoke("testMatch")
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
 
-		result, err = inter.Invoke("testNoMatch")
-		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: event S.test.Deposit has no field `from`",
+		)
 	})
 
-	t.Run("matcher beEmpty with type mismatch", func(t *testing.T) {
+	t.Run("not an event", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun test(): Bool {
-                let emptyDict = Test.beEmpty()
-
-                return emptyDict.test("empty")
+            fun test() {
+                Test.eventField<Int>(1, "amount")
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
 		require.Error(t, err)
-		assert.ErrorAs(t, err, &cdcErrors.DefaultUserError{})
-		assert.ErrorContains(t, err, "expected Array or Dictionary argument")
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(t, err, "assertion failed: not an event: 1")
 	})
 }
 
-func TestTestHaveElementCountMatcher(t *testing.T) {
+func TestAssertEventEquals(t *testing.T) {
 
 	t.Parallel()
 
-	t.Run("matcher haveElementCount with Array", func(t *testing.T) {
+	newTestFramework := func() *mockedTestFramework {
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					events: func(inter *interpreter.Interpreter, eventType interpreter.StaticType) interpreter.Value {
+						event := interpreter.NewCompositeValue(
+							inter,
+							interpreter.EmptyLocationRange,
+							utils.TestLocation,
+							"Deposit",
+							common.CompositeKindEvent,
+							[]interpreter.CompositeField{
+								interpreter.NewUnmeteredCompositeField(
+									"amount",
+									interpreter.NewUnmeteredIntValueFromInt64(10),
+								),
+								interpreter.NewUnmeteredCompositeField(
+									"to",
+									interpreter.AddressValue(common.MustBytesToAddress([]byte{0x1})),
+								),
+							},
+							common.ZeroAddress,
+						)
+
+						return interpreter.NewArrayValue(
+							inter,
+							interpreter.EmptyLocationRange,
+							interpreter.NewVariableSizedStaticType(inter, interpreter.PrimitiveStaticTypeAnyStruct),
+							common.ZeroAddress,
+							event,
+						)
+					},
+				}
+			},
+		}
+	}
+
+	t.Run("equal", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun testMatch(): Bool {
-                let hasThreeElements = Test.haveElementCount(3)
-
-                return hasThreeElements.test([42, 23, 31])
-            }
+            event Deposit(amount: Int, to: Address)
 
             access(all)
-            fun testNoMatch(): Bool {
-                let hasThreeElements = Test.haveElementCount(3)
+            event Withdraw(amount: Int, from: Address)
 
-                return hasThreeElements.test([42])
+            access(all)
+            fun test() {
+                let deposit = Test.eventsOfType(Type<Deposit>())[0]
+                Test.assertEventEquals(
+                    deposit,
+                    type: Type<Deposit>(),
+                    fields: {"amount": 10, "to": 0x01 as Address}
+                )
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
-		require.NoError(t, err)
-
-		result, err := inter.Invoke("testMatch")
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
 
-		result, err = inter.Invoke("testNoMatch")
+		_, err = inter.Invoke("test")
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
 	})
 
-	t.Run("matcher haveElementCount with Dictionary", func(t *testing.T) {
+	t.Run("differing field", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun testMatch(): Bool {
-                let hasTwoElements = Test.haveElementCount(2)
-                let dict: {Bool: Int} = {true: 1, false: 0}
-
-                return hasTwoElements.test(dict)
-            }
+            event Deposit(amount: Int, to: Address)
 
             access(all)
-            fun testNoMatch(): Bool {
-                let hasTwoElements = Test.haveElementCount(2)
-                let dict: {Bool: Int} = {}
+            event Withdraw(amount: Int, from: Address)
 
-                return hasTwoElements.test(dict)
+            access(all)
+            fun test() {
+                let deposit = Test.eventsOfType(Type<Deposit>())[0]
+                Test.assertEventEquals(
+                    deposit,
+                    type: Type<Deposit>(),
+                    fields: {"amount": 20, "to": 0x01 as Address}
+                )
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
-		require.NoError(t, err)
-
-		result, err := inter.Invoke("testMatch")
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
 
-		result, err = inter.Invoke("testNoMatch")
-		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: not equal event S.test.Deposit: not equal field `amount`: expected: 20, actual: 10",
+		)
 	})
 
-	t.Run("matcher haveElementCount with type mismatch", func(t *testing.T) {
+	t.Run("missing and unexpected fields", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun test(): Bool {
-                let hasTwoElements = Test.haveElementCount(2)
+            event Deposit(amount: Int, to: Address)
 
-                return hasTwoElements.test("two")
+            access(all)
+            event Withdraw(amount: Int, from: Address)
+
+            access(all)
+            fun test() {
+                let deposit = Test.eventsOfType(Type<Deposit>())[0]
+                Test.assertEventEquals(
+                    deposit,
+                    type: Type<Deposit>(),
+                    fields: {"amount": 10, "from": 0x01 as Address}
+                )
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
 		require.Error(t, err)
-		assert.ErrorAs(t, err, &cdcErrors.DefaultUserError{})
-		assert.ErrorContains(t, err, "expected Array or Dictionary argument")
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: not equal event S.test.Deposit: unexpected field `to`: 0x0000000000000001, missing field `from`",
+		)
 	})
-}
-
-func TestTestContainMatcher(t *testing.T) {
-
-	t.Parallel()
 
-	t.Run("matcher contain with Array", func(t *testing.T) {
+	t.Run("differing type", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun testMatch(): Bool {
-                let containsTwenty = Test.contain(20)
-
-                return containsTwenty.test([42, 20, 31])
-            }
+            event Deposit(amount: Int, to: Address)
 
             access(all)
-            fun testNoMatch(): Bool {
-                let containsTwenty = Test.contain(20)
+            event Withdraw(amount: Int, from: Address)
 
-                return containsTwenty.test([42])
+            access(all)
+            fun test() {
+                let deposit = Test.eventsOfType(Type<Deposit>())[0]
+                Test.assertEventEquals(
+                    deposit,
+                    type: Type<Withdraw>(),
+                    fields: {"amount": 10, "from": 0x01 as Address}
+                )
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
-		require.NoError(t, err)
-
-		result, err := inter.Invoke("testMatch")
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
 
-		result, err = inter.Invoke("testNoMatch")
-		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: not equal event types: expected: S.test.Withdraw, actual: S.test.Deposit",
+		)
 	})
 
-	t.Run("matcher contain with Dictionary", func(t *testing.T) {
+	t.Run("not an event", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun testMatch(): Bool {
-                let containsFalse = Test.contain(false)
-                let dict: {Bool: Int} = {true: 1, false: 0}
-
-                return containsFalse.test(dict)
-            }
+            event Deposit(amount: Int, to: Address)
 
             access(all)
-            fun testNoMatch(): Bool {
-                let containsFive = Test.contain(5)
-                let dict: {Int: Bool} = {1: true, 0: false}
+            event Withdraw(amount: Int, from: Address)
 
-                return containsFive.test(dict)
+            access(all)
+            fun test() {
+                let deposit = Test.eventsOfType(Type<Deposit>())[0]
+                Test.assertEventEquals(
+                    1,
+                    type: Type<Deposit>(),
+                    fields: {}
+                )
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
-		require.NoError(t, err)
-
-		result, err := inter.Invoke("testMatch")
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
 
-		result, err = inter.Invoke("testNoMatch")
-		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: not an event: 1",
+		)
 	})
+}
 
-	t.Run("matcher contain with type mismatch", func(t *testing.T) {
+func TestAssertNil(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun test(): Bool {
-                let containsFalse = Test.contain(false)
-
-                return containsFalse.test("false")
+            fun test() {
+                let value: Int? = nil
+                Test.assertNil(value)
             }
         `
 
@@ -1706,60 +1975,40 @@ func TestTestContainMatcher(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
-		require.Error(t, err)
-		assert.ErrorAs(t, err, &cdcErrors.DefaultUserError{})
-		assert.ErrorContains(t, err, "expected Array or Dictionary argument")
+		require.NoError(t, err)
 	})
-}
-
-func TestTestBeGreaterThanMatcher(t *testing.T) {
-
-	t.Parallel()
 
-	t.Run("matcher beGreaterThan", func(t *testing.T) {
+	t.Run("nested outer nil", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun testMatch(): Bool {
-                let greaterThanFive = Test.beGreaterThan(5)
-
-                return greaterThanFive.test(7)
-            }
-
-            access(all)
-            fun testNoMatch(): Bool {
-                let greaterThanFive = Test.beGreaterThan(5)
-
-                return greaterThanFive.test(2)
+            fun test() {
+                let value: Int?? = nil
+                Test.assertNil(value)
             }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		result, err := inter.Invoke("testMatch")
-		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
-
-		result, err = inter.Invoke("testNoMatch")
+		_, err = inter.Invoke("test")
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
 	})
 
-	t.Run("matcher beGreaterThan with type mismatch", func(t *testing.T) {
+	t.Run("nil, non-optional", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun test(): Bool {
-                let greaterThanFive = Test.beGreaterThan(5)
-
-                return greaterThanFive.test("7")
+            fun test() {
+                let inner: Int? = nil
+                let value: AnyStruct = inner
+                Test.assertNil(value)
             }
         `
 
@@ -1767,58 +2016,38 @@ func TestTestBeGreaterThanMatcher(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
-		require.Error(t, err)
+		require.NoError(t, err)
 	})
-}
-
-func TestTestBeLessThanMatcher(t *testing.T) {
-
-	t.Parallel()
 
-	t.Run("matcher beLessThan", func(t *testing.T) {
+	t.Run("not nil", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun testMatch(): Bool {
-                let lessThanSeven = Test.beLessThan(7)
-
-                return lessThanSeven.test(5)
-            }
-
-            access(all)
-            fun testNoMatch(): Bool {
-                let lessThanSeven = Test.beLessThan(7)
-
-                return lessThanSeven.test(9)
+            fun test() {
+                let value: Int? = 1
+                Test.assertNotNil(value)
             }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		result, err := inter.Invoke("testMatch")
-		require.NoError(t, err)
-		assert.Equal(t, interpreter.TrueValue, result)
-
-		result, err = inter.Invoke("testNoMatch")
+		_, err = inter.Invoke("test")
 		require.NoError(t, err)
-		assert.Equal(t, interpreter.FalseValue, result)
 	})
 
-	t.Run("matcher beLessThan with type mismatch", func(t *testing.T) {
+	t.Run("not nil, non-optional", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
             import Test
 
             access(all)
-            fun test(): Bool {
-                let lessThanSeven = Test.beLessThan(7)
-
-                return lessThanSeven.test(true)
+            fun test() {
+                Test.assertNotNil(1)
             }
         `
 
@@ -1826,24 +2055,20 @@ func TestTestBeLessThanMatcher(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
-		require.Error(t, err)
+		require.NoError(t, err)
 	})
-}
-
-func TestTestExpect(t *testing.T) {
 
-	t.Parallel()
-
-	t.Run("success", func(t *testing.T) {
+	t.Run("not nil, nested inner nil", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
-           import Test
+            import Test
 
-           access(all)
-           fun test() {
-               Test.expect("this string", Test.equal("this string"))
-           }
+            access(all)
+            fun test() {
+                let values: {String: Int?} = {"a": nil}
+                Test.assertNotNil(values["a"])
+            }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
@@ -1853,16 +2078,17 @@ func TestTestExpect(t *testing.T) {
 		require.NoError(t, err)
 	})
 
-	t.Run("fail", func(t *testing.T) {
+	t.Run("nil fails, not nil", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
-           import Test
+            import Test
 
-           access(all)
-           fun test() {
-               Test.expect("this string", Test.equal("other string"))
-           }
+            access(all)
+            fun test() {
+                let value: Int? = 1
+                Test.assertNil(value)
+            }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
@@ -1870,24 +2096,25 @@ func TestTestExpect(t *testing.T) {
 
 		_, err = inter.Invoke("test")
 		require.Error(t, err)
-
-		assertionErr := &AssertionError{}
-		assert.ErrorAs(t, err, assertionErr)
-		assert.Equal(t, "given value is: \"this string\"", assertionErr.Message)
-		assert.Equal(t, "test", assertionErr.LocationRange.Location.String())
-		assert.Equal(t, 6, assertionErr.LocationRange.StartPosition().Line)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected nil, got: Some(1)",
+		)
 	})
 
-	t.Run("different types", func(t *testing.T) {
+	t.Run("nil fails, nested not nil", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
-           import Test
+            import Test
 
-           access(all)
-           fun test() {
-               Test.expect("string", Test.equal(1))
-           }
+            access(all)
+            fun test() {
+                let value: Int?? = 1
+                Test.assertNil(value)
+            }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
@@ -1895,334 +2122,6489 @@ func TestTestExpect(t *testing.T) {
 
 		_, err = inter.Invoke("test")
 		require.Error(t, err)
-		assert.ErrorAs(t, err, &interpreter.TypeMismatchError{})
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected nil, got: Some(Some(1))",
+		)
 	})
 
-	t.Run("with explicit types", func(t *testing.T) {
+	t.Run("nil fails, non-optional", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
-           import Test
+            import Test
 
-           access(all)
-           fun test() {
-               Test.expect<String>("hello", Test.equal("hello"))
-           }
+            access(all)
+            fun test() {
+                Test.assertNil(1)
+            }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
-		require.NoError(t, err)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected nil, got non-optional value: 1",
+		)
 	})
 
-	t.Run("mismatching types", func(t *testing.T) {
+	t.Run("nil fails, nested inner nil", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
-           import Test
+            import Test
 
-           access(all)
-           fun test() {
-               Test.expect<Int>("string", Test.equal(1))
-           }
+            access(all)
+            fun test() {
+                let values: {String: Int?} = {"a": nil}
+                Test.assertNil(values["a"])
+            }
         `
 
-		_, err := newTestContractInterpreter(t, script)
-
-		errs := checker.RequireCheckerErrors(t, err, 1)
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
 
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected nil, got: Some(nil)",
+		)
 	})
 
-	t.Run("resource with resource matcher", func(t *testing.T) {
+	t.Run("not nil fails", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
-           import Test
+            import Test
 
-           access(all)
-           fun test() {
-               let f1 <- create Foo()
-               let f2 <- create Foo()
-               Test.expect(<-f1, Test.equal(<-f2))
-           }
-
-           access(all)
-           resource Foo {}
+            access(all)
+            fun test() {
+                let value: Int? = nil
+                Test.assertNotNil(value)
+            }
         `
 
-		_, err := newTestContractInterpreter(t, script)
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
 
-		errs := checker.RequireCheckerErrors(t, err, 2)
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected non-nil value, got: nil",
+		)
 	})
 
-	t.Run("resource with struct matcher", func(t *testing.T) {
+	t.Run("not nil fails, nested outer nil", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
-           import Test
-
-           access(all)
-           fun test() {
-               let foo <- create Foo()
-               let bar = Bar()
-               Test.expect(<-foo, Test.equal(bar))
-           }
+            import Test
 
-           access(all)
-           resource Foo {}
-           access(all)
-           struct Bar {}
+            access(all)
+            fun test() {
+                let value: Int?? = nil
+                Test.assertNotNil(value)
+            }
         `
 
-		_, err := newTestContractInterpreter(t, script)
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
 
-		errs := checker.RequireCheckerErrors(t, err, 1)
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected non-nil value, got: nil",
+		)
 	})
+}
 
-	t.Run("struct with resource matcher", func(t *testing.T) {
-		t.Parallel()
+func TestAssertStorageMigration(t *testing.T) {
 
-		script := `
-           import Test
+	t.Parallel()
 
-           access(all)
-           fun test() {
-               let foo = Foo()
-               let bar <- create Bar()
-               Test.expect(foo, Test.equal(<-bar))
-           }
+	newTestFramework := func() *mockedTestFramework {
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					diffSnapshots: func(
+						_ *interpreter.Interpreter,
+						before string,
+						after string,
+					) (map[string]interpreter.Value, error) {
+						if before != "before" || after != "after" {
+							return nil, fmt.Errorf("unknown snapshots: %s, %s", before, after)
+						}
+
+						return map[string]interpreter.Value{
+							"0x0000000000000001/storage/balance": interpreter.NewUnmeteredIntValueFromInt64(20),
+							"0x0000000000000001/storage/legacy":  nil,
+						}, nil
+					},
+				}
+			},
+		}
+	}
 
-           access(all)
-           struct Foo {}
-           access(all)
-           resource Bar {}
-        `
+	t.Run("expected changes", func(t *testing.T) {
+		t.Parallel()
 
-		_, err := newTestContractInterpreter(t, script)
+		const script = `
+            import Test
 
-		errs := checker.RequireCheckerErrors(t, err, 1)
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
-	})
-}
+            access(all)
+            fun test() {
+                Test.assertStorageMigration(
+                    before: "before",
+                    after: "after",
+                    expectedChanges: {
+                        "0x0000000000000001/storage/balance": 20,
+                        "0x0000000000000001/storage/legacy": nil
+                    }
+                )
+            }
+        `
 
-func TestTestExpectFailure(t *testing.T) {
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
 
-	t.Parallel()
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
 
-	t.Run("expect failure with no failure found", func(t *testing.T) {
+	t.Run("differing change", func(t *testing.T) {
 		t.Parallel()
 
-		script := `
+		const script = `
             import Test
 
             access(all)
             fun test() {
-                let foo = Foo(answer: 42)
-                Test.expectFailure(fun(): Void {
-                    foo.correctAnswer(42)
-                }, errorMessageSubstring: "wrong answer!")
+                Test.assertStorageMigration(
+                    before: "before",
+                    after: "after",
+                    expectedChanges: {
+                        "0x0000000000000001/storage/balance": 30,
+                        "0x0000000000000001/storage/legacy": 1
+                    }
+                )
             }
+        `
 
-            access(all)
-            struct Foo {
-                access(self) let answer: UInt8
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
 
-                init(answer: UInt8) {
-                    self.answer = answer
-                }
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: storage migration changes are not as expected: not equal change of `0x0000000000000001/storage/balance`: expected: 30, actual: 20, not equal change of `0x0000000000000001/storage/legacy`: expected: 1, actual: removed",
+		)
+	})
 
-                access(all)
-                fun correctAnswer(_ input: UInt8): Bool {
-                    if self.answer != input {
-                        panic("wrong answer!")
+	t.Run("unexpected and missing changes", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertStorageMigration(
+                    before: "before",
+                    after: "after",
+                    expectedChanges: {
+                        "0x0000000000000001/storage/balance": 20,
+                        "0x0000000000000001/storage/other": 1
                     }
-                    return true
-                }
+                )
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
 		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
 		assert.ErrorContains(
 			t,
 			err,
-			"Expected a failure, but found none.",
+			"assertion failed: storage migration changes are not as expected: unexpected change of `0x0000000000000001/storage/legacy`: removed, missing change of `0x0000000000000001/storage/other`",
 		)
 	})
 
-	t.Run("expect failure with matching error message", func(t *testing.T) {
+	t.Run("unknown snapshot", func(t *testing.T) {
 		t.Parallel()
 
-		script := `
+		const script = `
             import Test
 
             access(all)
             fun test() {
-                let foo = Foo(answer: 42)
-                Test.expectFailure(fun(): Void {
-                    foo.correctAnswer(43)
-                }, errorMessageSubstring: "wrong answer!")
+                Test.assertStorageMigration(
+                    before: "before",
+                    after: "unknown",
+                    expectedChanges: {}
+                )
             }
+        `
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown snapshots: before, unknown")
+	})
+}
+
+func TestAssertConformance(t *testing.T) {
+
+	t.Parallel()
+
+	const contracts = `
+        access(all)
+        contract interface Token {}
+
+        access(all)
+        contract interface Other {}
+
+        access(all)
+        contract ConformingToken: Token {}
+
+        access(all)
+        contract NonConformingToken {}
+    `
+
+	newTestFramework := func() *mockedTestFramework {
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					getContractType: func(name string) (interpreter.StaticType, error) {
+						switch name {
+						case "ConformingToken", "NonConformingToken":
+							return interpreter.NewCompositeStaticTypeComputeTypeID(
+								nil,
+								utils.TestLocation,
+								name,
+							), nil
+						default:
+							return nil, fmt.Errorf("contract not deployed: %s", name)
+						}
+					},
+				}
+			},
+		}
+	}
 
+	runTest := func(t *testing.T, code string) error {
+		script := `
+            import Test
+        ` + contracts + `
             access(all)
-            struct Foo {
-                access(self) let answer: UInt8
+            fun test() {
+                ` + code + `
+            }
+        `
 
-                init(answer: UInt8) {
-                    self.answer = answer
-                }
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
 
-                access(all)
-                fun correctAnswer(_ input: UInt8): Bool {
-                    if self.answer != input {
-                        panic("wrong answer!")
-                    }
-                    return true
-                }
+		_, err = inter.Invoke("test")
+		return err
+	}
+
+	t.Run("conforming", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertConformance("ConformingToken", Type<{Token}>())`)
+		require.NoError(t, err)
+	})
+
+	t.Run("non-conforming", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertConformance("NonConformingToken", Type<{Token}>())`)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: contract `NonConformingToken` does not conform to interface `Token`",
+		)
+	})
+
+	t.Run("conforming to other interface", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertConformance("ConformingToken", Type<{Other}>())`)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: contract `ConformingToken` does not conform to interface `Other`",
+		)
+	})
+
+	t.Run("not conforming to all interfaces of intersection", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertConformance("ConformingToken", Type<{Token, Other}>())`)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: contract `ConformingToken` does not conform to interface `Other`",
+		)
+	})
+
+	t.Run("not a contract interface", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertConformance("ConformingToken", Type<Int>())`)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "expected contract interface type, got `Int`")
+	})
+
+	t.Run("not deployed", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertConformance("Unknown", Type<{Token}>())`)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "contract not deployed: Unknown")
+	})
+}
+
+func TestAssertEventCountInRange(t *testing.T) {
+
+	t.Parallel()
+
+	runTest := func(t *testing.T, code string) error {
+		script := `
+            import Test
+
+            access(all)
+            event Deposit(amount: Int)
+
+            access(all)
+            fun test() {
+                ` + code + `
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					events: func(inter *interpreter.Interpreter, eventType interpreter.StaticType) interpreter.Value {
+						var events []interpreter.Value
+						for i := 0; i < 3; i++ {
+							events = append(
+								events,
+								interpreter.NewCompositeValue(
+									inter,
+									interpreter.EmptyLocationRange,
+									utils.TestLocation,
+									"Deposit",
+									common.CompositeKindEvent,
+									[]interpreter.CompositeField{
+										interpreter.NewUnmeteredCompositeField(
+											"amount",
+											interpreter.NewUnmeteredIntValueFromInt64(int64(i)),
+										),
+									},
+									common.ZeroAddress,
+								),
+							)
+						}
+
+						return interpreter.NewArrayValue(
+							inter,
+							interpreter.EmptyLocationRange,
+							interpreter.NewVariableSizedStaticType(inter, interpreter.PrimitiveStaticTypeAnyStruct),
+							common.ZeroAddress,
+							events...,
+						)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		return err
+	}
+
+	t.Run("in range", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertEventCountInRange(Type<Deposit>(), min: 1, max: 3)`)
+		require.NoError(t, err)
+	})
+
+	t.Run("exact", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertEventCountInRange(Type<Deposit>(), min: 3, max: 3)`)
+		require.NoError(t, err)
+	})
+
+	t.Run("below min", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertEventCountInRange(Type<Deposit>(), min: 4, max: 5)`)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected between 4 and 5 events of type S.test.Deposit, got 3",
+		)
+	})
+
+	t.Run("above max", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertEventCountInRange(Type<Deposit>(), min: 0, max: 2)`)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected between 0 and 2 events of type S.test.Deposit, got 3",
+		)
+	})
+
+	t.Run("no upper bound", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertEventCountInRange(Type<Deposit>(), min: 2, max: nil)`)
+		require.NoError(t, err)
+	})
+
+	t.Run("no upper bound, below min", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertEventCountInRange(Type<Deposit>(), min: 4, max: nil)`)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected at least 4 events of type S.test.Deposit, got 3",
+		)
+	})
+
+	t.Run("min greater than max", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertEventCountInRange(Type<Deposit>(), min: 3, max: 1)`)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid event count range: min 3 is greater than max 1")
+	})
+
+	t.Run("negative min", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertEventCountInRange(Type<Deposit>(), min: -1, max: 1)`)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid event count range: min must not be negative, got -1")
+	})
+}
+
+func TestAssertContractRemoved(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        access(all)
+        fun test() {
+            let err = Test.deployContract(
+                name: "FooContract",
+                path: "./contracts/FooContract.cdc",
+                arguments: []
+            )
+            Test.expect(err, Test.beNil())
+
+            let code = "import FooContract from 0x01 access(all) fun main(): Int { return 1 }"
+            Test.expect(Test.executeScript(code, []), Test.beSucceeded())
+
+            let tx = Test.Transaction(
+                code: "transaction { prepare(signer: auth(RemoveContract) &Account) { signer.contracts.remove(name: \"FooContract\") } }",
+                authorizers: [0x01],
+                signers: [],
+                arguments: []
+            )
+            Test.expect(Test.executeTransaction(tx), Test.beSucceeded())
+
+            Test.assertContractRemoved(0x01, "FooContract")
+            Test.expect(Test.executeScript(code, []), Test.beFailed())
+        }
+
+        access(all)
+        fun testNotRemoved() {
+            let err = Test.deployContract(
+                name: "FooContract",
+                path: "./contracts/FooContract.cdc",
+                arguments: []
+            )
+            Test.expect(err, Test.beNil())
+
+            Test.assertContractRemoved(0x01, "FooContract")
+        }
+    `
+
+	newTestFramework := func() *mockedTestFramework {
+		deployed := map[string]bool{}
+
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					deployContract: func(
+						_ *interpreter.Interpreter,
+						name string,
+						_ string,
+						_ []interpreter.Value,
+					) error {
+						deployed[name] = true
+						return nil
+					},
+					runScript: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []interpreter.Value,
+					) *ScriptResult {
+						if !deployed["FooContract"] {
+							return &ScriptResult{
+								Error: fmt.Errorf("cannot find declaration `FooContract` in `0x01.FooContract`"),
+							}
+						}
+						return &ScriptResult{
+							Value: interpreter.NewUnmeteredIntValueFromInt64(1),
+						}
+					},
+					addTransaction: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
+					) error {
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						// The only transaction removes the contract
+						delete(deployed, "FooContract")
+						return &TransactionResult{}
+					},
+					commitBlock: func() error {
+						return nil
+					},
+					getAccountContractNames: func(address common.Address) ([]string, error) {
+						assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+
+						var names []string
+						for name := range deployed {
+							names = append(names, name)
+						}
+						return names, nil
+					},
+				}
+			},
+		}
+	}
+
+	t.Run("removed", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("not removed", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testNotRemoved")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: contract `FooContract` is still deployed in account 0x0000000000000001",
+		)
+	})
+}
+
+func TestAssertEventJSON(t *testing.T) {
+
+	t.Parallel()
+
+	// encodeTestJSONValue is a minimal JSON-Cadence encoder for the values used by the test.
+	// Fields are encoded in the order of their names.
+	var encodeTestJSONValue func(inter *interpreter.Interpreter, value interpreter.Value) any
+	encodeTestJSONValue = func(inter *interpreter.Interpreter, value interpreter.Value) any {
+		switch value := value.(type) {
+		case interpreter.IntValue:
+			return map[string]any{"type": "Int", "value": value.String()}
+
+		case *interpreter.StringValue:
+			return map[string]any{"type": "String", "value": value.Str}
+
+		case *interpreter.CompositeValue:
+			var fieldNames []string
+			value.ForEachFieldName(func(fieldName string) bool {
+				fieldNames = append(fieldNames, fieldName)
+				return true
+			})
+			sort.Strings(fieldNames)
+
+			fields := make([]any, 0, len(fieldNames))
+			for _, fieldName := range fieldNames {
+				fields = append(fields, map[string]any{
+					"name":  fieldName,
+					"value": encodeTestJSONValue(inter, value.GetField(inter, interpreter.EmptyLocationRange, fieldName)),
+				})
+			}
+
+			kind := "Struct"
+			if value.Kind == common.CompositeKindEvent {
+				kind = "Event"
+			}
+
+			return map[string]any{
+				"type": kind,
+				"value": map[string]any{
+					"id":     string(value.TypeID()),
+					"fields": fields,
+				},
+			}
+
+		default:
+			panic(fmt.Errorf("unsupported value: %s", value))
+		}
+	}
+
+	runTest := func(t *testing.T, code string) error {
+		script := `
+            import Test
+
+            access(all)
+            struct Owner {
+                access(all)
+                let name: String
+
+                init(name: String) {
+                    self.name = name
+                }
+            }
+
+            access(all)
+            event Transfer(owner: Owner, amount: Int)
+
+            access(all)
+            fun test() {
+                ` + code + `
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					events: func(inter *interpreter.Interpreter, eventType interpreter.StaticType) interpreter.Value {
+						var events []interpreter.Value
+						for i, name := range []string{"alice", "bob"} {
+							owner := interpreter.NewCompositeValue(
+								inter,
+								interpreter.EmptyLocationRange,
+								utils.TestLocation,
+								"Owner",
+								common.CompositeKindStructure,
+								[]interpreter.CompositeField{
+									interpreter.NewUnmeteredCompositeField(
+										"name",
+										interpreter.NewUnmeteredStringValue(name),
+									),
+								},
+								common.ZeroAddress,
+							)
+
+							events = append(
+								events,
+								interpreter.NewCompositeValue(
+									inter,
+									interpreter.EmptyLocationRange,
+									utils.TestLocation,
+									"Transfer",
+									common.CompositeKindEvent,
+									[]interpreter.CompositeField{
+										interpreter.NewUnmeteredCompositeField(
+											"owner",
+											owner,
+										),
+										interpreter.NewUnmeteredCompositeField(
+											"amount",
+											interpreter.NewUnmeteredIntValueFromInt64(int64(i+1)*10),
+										),
+									},
+									common.ZeroAddress,
+								),
+							)
+						}
+
+						return interpreter.NewArrayValue(
+							inter,
+							interpreter.EmptyLocationRange,
+							interpreter.NewVariableSizedStaticType(inter, interpreter.PrimitiveStaticTypeAnyStruct),
+							common.ZeroAddress,
+							events...,
+						)
+					},
+				}
+			},
+			encodeJSONValue: func(inter *interpreter.Interpreter, value interpreter.Value) ([]byte, error) {
+				return json.Marshal(encodeTestJSONValue(inter, value))
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		return err
+	}
+
+	t.Run("matching event", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `
+          Test.assertEventJSON(
+              Type<Transfer>(),
+              "{\"value\": {\"id\": \"S.test.Transfer\", \"fields\": [{\"name\": \"amount\", \"value\": {\"type\": \"Int\", \"value\": \"20\"}}, {\"name\": \"owner\", \"value\": {\"type\": \"Struct\", \"value\": {\"id\": \"S.test.Owner\", \"fields\": [{\"name\": \"name\", \"value\": {\"type\": \"String\", \"value\": \"bob\"}}]}}}]}, \"type\": \"Event\"}"
+          )
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("no matching event", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `
+          Test.assertEventJSON(
+              Type<Transfer>(),
+              "{\"type\": \"Event\", \"value\": {\"id\": \"S.test.Transfer\", \"fields\": [{\"name\": \"amount\", \"value\": {\"type\": \"Int\", \"value\": \"20\"}}, {\"name\": \"owner\", \"value\": {\"type\": \"Struct\", \"value\": {\"id\": \"S.test.Owner\", \"fields\": [{\"name\": \"name\", \"value\": {\"type\": \"String\", \"value\": \"alice\"}}]}}}]}}"
+          )
+        `)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: no event of type S.test.Transfer encodes to the expected JSON",
+		)
+		assert.ErrorContains(t, err, `{"type":"String","value":"bob"}`)
+	})
+
+	t.Run("invalid expected JSON", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertEventJSON(Type<Transfer>(), "{")`)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid expected JSON")
+	})
+}
+
+func TestAssertStorageEmpty(t *testing.T) {
+
+	t.Parallel()
+
+	runTest := func(t *testing.T, code string) error {
+		script := `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.createSnapshot(name: "baseline")
+                ` + code + `
+            }
+        `
+
+		// Simulate the storage of the blockchain,
+		// keyed by address and storage path, like snapshot diffs
+		storage := map[string]interpreter.Value{
+			"0x0000000000000001/storage/existing": interpreter.NewUnmeteredIntValueFromInt64(1),
+		}
+		snapshots := map[string]map[string]interpreter.Value{}
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScript: func(
+						_ *interpreter.Interpreter,
+						code string,
+						_ []interpreter.Value,
+					) *ScriptResult {
+						// The code is the storage key to store a value at, or to remove the value from
+						if strings.HasPrefix(code, "remove ") {
+							delete(storage, strings.TrimPrefix(code, "remove "))
+						} else {
+							storage[code] = interpreter.NewUnmeteredIntValueFromInt64(2)
+						}
+						return &ScriptResult{}
+					},
+					createSnapshot: func(name string) error {
+						snapshot := make(map[string]interpreter.Value, len(storage))
+						for key, value := range storage { //nolint:maprange
+							snapshot[key] = value
+						}
+						snapshots[name] = snapshot
+						return nil
+					},
+					diffSnapshots: func(
+						_ *interpreter.Interpreter,
+						before string,
+						after string,
+					) (map[string]interpreter.Value, error) {
+						beforeSnapshot, ok := snapshots[before]
+						if !ok {
+							return nil, fmt.Errorf("unknown snapshot: %s", before)
+						}
+						afterSnapshot := snapshots[after]
+
+						changes := map[string]interpreter.Value{}
+						for key, value := range afterSnapshot { //nolint:maprange
+							if _, ok := beforeSnapshot[key]; !ok {
+								changes[key] = value
+							}
+						}
+						for key := range beforeSnapshot { //nolint:maprange
+							if _, ok := afterSnapshot[key]; !ok {
+								changes[key] = nil
+							}
+						}
+						return changes, nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		return err
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertStorageEmpty(0x01, baseline: "baseline")`)
+		require.NoError(t, err)
+	})
+
+	t.Run("removed value", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `
+            Test.executeScript("remove 0x0000000000000001/storage/existing", [])
+            Test.assertStorageEmpty(0x01, baseline: "baseline")
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("value stored in other account", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `
+            Test.executeScript("0x0000000000000002/storage/leak", [])
+            Test.assertStorageEmpty(0x01, baseline: "baseline")
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("leaked values", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `
+            Test.executeScript("0x0000000000000001/storage/leak", [])
+            Test.executeScript("0x0000000000000001/public/leak", [])
+            Test.assertStorageEmpty(0x01, baseline: "baseline")
+        `)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: storage of account 0x0000000000000001 is not empty: "+
+				"unexpected values at /public/leak, /storage/leak",
+		)
+	})
+
+	t.Run("baseline named like the check snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		// The check must not overwrite the baseline with its own snapshot of the current state
+
+		err := runTest(t, `
+            Test.createSnapshot(name: "assertStorageEmpty")
+            Test.executeScript("0x0000000000000001/storage/leak", [])
+            Test.assertStorageEmpty(0x01, baseline: "assertStorageEmpty")
+        `)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: storage of account 0x0000000000000001 is not empty: "+
+				"unexpected values at /storage/leak",
+		)
+	})
+
+	t.Run("unknown baseline", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertStorageEmpty(0x01, baseline: "unknown")`)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown snapshot: unknown")
+	})
+}
+
+func TestAssertFieldsEqual(t *testing.T) {
+
+	t.Parallel()
+
+	const declarations = `
+        import Test
+
+        access(all)
+        resource Inner {
+            access(all)
+            let label: String
+
+            init(label: String) {
+                self.label = label
+            }
+        }
+
+        access(all)
+        resource R {
+            access(all)
+            let id: Int
+
+            access(all)
+            let tags: [String]
+
+            access(all)
+            let inner: @Inner
+
+            init(id: Int, tags: [String], label: String) {
+                self.id = id
+                self.tags = tags
+                self.inner <- create Inner(label: label)
+            }
+        }
+    `
+
+	t.Run("equal values, distinct resources", func(t *testing.T) {
+		t.Parallel()
+
+		script := declarations + `
+            access(all)
+            fun test() {
+                let r1 <- create R(id: 1, tags: ["a", "b"], label: "x")
+                let r2 <- create R(id: 1, tags: ["a", "b"], label: "x")
+
+                Test.assert(r1.uuid != r2.uuid)
+                Test.assertFieldsEqual(&r1 as &R, &r2 as &R)
+
+                destroy r1
+                destroy r2
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("differing fields", func(t *testing.T) {
+		t.Parallel()
+
+		script := declarations + `
+            access(all)
+            fun test() {
+                let r1 <- create R(id: 1, tags: ["a"], label: "x")
+                let r2 <- create R(id: 2, tags: ["a"], label: "y")
+
+                Test.assertFieldsEqual(&r1 as &R, &r2 as &R)
+
+                destroy r1
+                destroy r2
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"not equal fields of S.test.R: "+
+				"not equal field `id`: expected: 1, actual: 2, "+
+				"not equal field `inner.label`: expected: \"x\", actual: \"y\"",
+		)
+	})
+
+	t.Run("differing types", func(t *testing.T) {
+		t.Parallel()
+
+		script := declarations + `
+            access(all)
+            fun test() {
+                let r <- create R(id: 1, tags: [], label: "x")
+                let inner <- create Inner(label: "x")
+
+                Test.assertFieldsEqual(&r as &R, &inner as &Inner)
+
+                destroy r
+                destroy inner
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"not equal types: expected: S.test.R, actual: S.test.Inner",
+		)
+	})
+}
+
+func TestAssertStableEncoding(t *testing.T) {
+
+	t.Parallel()
+
+	const declarations = `
+        import Test
+
+        access(all)
+        struct Point {
+            access(all)
+            let x: Int
+
+            access(all)
+            let y: Int
+
+            init(x: Int, y: Int) {
+                self.x = x
+                self.y = y
+            }
+        }
+    `
+
+	t.Run("simple struct", func(t *testing.T) {
+		t.Parallel()
+
+		script := declarations + `
+            access(all)
+            fun test() {
+                // The encoding does not depend on previously allocated values
+                let other = Point(x: 3, y: 4)
+
+                Test.assertStableEncoding(
+                    Point(x: 1, y: 2),
+                    [
+                        0x10, 0x88, 0x83, 0xd8, 0x84, 0x83, 0xd8, 0xc1, 0x64, 0x74, 0x65, 0x73,
+                        0x74, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x01, 0x02, 0x1b, 0xeb, 0xb2,
+                        0x2e, 0x1f, 0x06, 0x08, 0x0d, 0x68, 0x83, 0x00, 0x59, 0x00, 0x10, 0x12,
+                        0x0d, 0x53, 0x06, 0x45, 0x06, 0xe3, 0xf5, 0xc2, 0xf6, 0xf2, 0x3a, 0x8d,
+                        0x3a, 0xeb, 0xae, 0x99, 0x00, 0x02, 0x82, 0x61, 0x78, 0xd8, 0x98, 0xc2,
+                        0x41, 0x01, 0x82, 0x61, 0x79, 0xd8, 0x98, 0xc2, 0x41, 0x02
+                    ]
+                )
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("simple value", func(t *testing.T) {
+		t.Parallel()
+
+		script := declarations + `
+            access(all)
+            fun test() {
+                Test.assertStableEncoding("hi", [0xd8, 0x87, 0x62, 0x68, 0x69])
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("changed encoding", func(t *testing.T) {
+		t.Parallel()
+
+		script := declarations + `
+            access(all)
+            fun test() {
+                Test.assertStableEncoding("ho", [0xd8, 0x87, 0x62, 0x68, 0x69])
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"not equal encoding: expected: d887626869, actual: d88762686f",
+		)
+	})
+}
+
+func TestAssertEventSchema(t *testing.T) {
+
+	t.Parallel()
+
+	const contracts = `
+        access(all)
+        contract Token {
+
+            access(all)
+            event Transfer(amount: UFix64, to: Address?)
+
+            access(all)
+            struct NotAnEvent {}
+        }
+    `
+
+	newTestFramework := func() *mockedTestFramework {
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					getContractType: func(name string) (interpreter.StaticType, error) {
+						if name != "Token" {
+							return nil, fmt.Errorf("contract not deployed: %s", name)
+						}
+						return interpreter.NewCompositeStaticTypeComputeTypeID(
+							nil,
+							utils.TestLocation,
+							name,
+						), nil
+					},
+				}
+			},
+		}
+	}
+
+	runTest := func(t *testing.T, code string) error {
+		script := `
+            import Test
+        ` + contracts + `
+            access(all)
+            fun test() {
+                ` + code + `
+            }
+        `
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		return err
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `
+            Test.assertEventSchema("Token", "Transfer", [
+                Test.EventField(name: "amount", type: Type<UFix64>()),
+                Test.EventField(name: "to", type: Type<Address?>())
+            ])
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("mismatching type", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `
+            Test.assertEventSchema("Token", "Transfer", [
+                Test.EventField(name: "amount", type: Type<UFix64>()),
+                Test.EventField(name: "to", type: Type<Address>())
+            ])
+        `)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: event `Token.Transfer` does not match schema: "+
+				"expected: (amount: UFix64, to: Address), "+
+				"actual: (amount: UFix64, to: Address?)",
+		)
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `
+            Test.assertEventSchema("Token", "Transfer", [
+                Test.EventField(name: "amount", type: Type<UFix64>())
+            ])
+        `)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"expected: (amount: UFix64), actual: (amount: UFix64, to: Address?)",
+		)
+	})
+
+	t.Run("not an event", func(t *testing.T) {
+		t.Parallel()
+
+		err := runTest(t, `Test.assertEventSchema("Token", "NotAnEvent", [])`)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(t, err, "assertion failed: contract `Token` has no event `NotAnEvent`")
+	})
+}
+
+func TestAssertEventInTransaction(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        access(all)
+        event Deposit(amount: Int)
+
+        access(all)
+        fun executeBatch(): [Test.TransactionResult] {
+            let transactions: [Test.Transaction] = []
+            var i = 0
+            while i < 3 {
+                transactions.append(
+                    Test.Transaction(
+                        code: "transaction {}",
+                        authorizers: [],
+                        signers: [],
+                        arguments: []
+                    )
+                )
+                i = i + 1
+            }
+
+            return Test.executeTransactions(transactions)
+        }
+
+        access(all)
+        fun testEmitted() {
+            let results = executeBatch()
+            Test.assertEqual(0, results[0].events.length)
+            Test.assertEqual(1, results[1].events.length)
+            Test.assertEqual(0, results[2].events.length)
+
+            Test.assertEventInTransaction(results, 1, Type<Deposit>())
+        }
+
+        access(all)
+        fun testNotEmitted() {
+            let results = executeBatch()
+            Test.assertEventInTransaction(results, 2, Type<Deposit>())
+        }
+
+        access(all)
+        fun testOutOfBounds() {
+            let results = executeBatch()
+            Test.assertEventInTransaction(results, 3, Type<Deposit>())
+        }
+    `
+
+	newTestFramework := func() *mockedTestFramework {
+		var queue []*interpreter.Interpreter
+		index := 0
+
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					addTransaction: func(
+						inter *interpreter.Interpreter,
+						code string,
+						authorizers []common.Address,
+						signers []*Account,
+						arguments []interpreter.Value,
+					) error {
+						queue = append(queue, inter)
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						inter := queue[0]
+						queue = queue[1:]
+
+						result := &TransactionResult{
+							Index: index,
+						}
+
+						// Only the second transaction of the batch emits an event
+						if index == 1 {
+							result.Events = []interpreter.Value{
+								interpreter.NewCompositeValue(
+									inter,
+									interpreter.EmptyLocationRange,
+									utils.TestLocation,
+									"Deposit",
+									common.CompositeKindEvent,
+									[]interpreter.CompositeField{
+										interpreter.NewUnmeteredCompositeField(
+											"amount",
+											interpreter.NewUnmeteredIntValueFromInt64(10),
+										),
+									},
+									common.ZeroAddress,
+								),
+							}
+						}
+
+						index++
+						return result
+					},
+					commitBlock: func() error {
+						index = 0
+						return nil
+					},
+				}
+			},
+		}
+	}
+
+	t.Run("emitted", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testEmitted")
+		require.NoError(t, err)
+	})
+
+	t.Run("not emitted", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testNotEmitted")
+		require.Error(t, err)
+		assert.ErrorContains(
+			t,
+			err,
+			"event of type `S.test.Deposit` was not emitted by the transaction at index 2",
+		)
+	})
+
+	t.Run("index out of bounds", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testOutOfBounds")
+		require.Error(t, err)
+		assert.ErrorContains(
+			t,
+			err,
+			"transaction index out of bounds: 3, but the batch has 3 results",
+		)
+	})
+}
+
+func TestAssertEventInPhase(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        access(all)
+        event Deposit(amount: Int)
+
+        access(all)
+        event Withdraw(amount: Int)
+
+        access(all)
+        fun executeTransfer(): Test.TransactionResult {
+            let tx = Test.Transaction(
+                code: "transaction {}",
+                authorizers: [],
+                signers: [],
+                arguments: []
+            )
+            return Test.executeTransaction(tx)
+        }
+
+        access(all)
+        fun testEmitted() {
+            let result = executeTransfer()
+            Test.assertEqual(
+                [Test.TransactionPhase.execute, Test.TransactionPhase.post],
+                result.eventPhases
+            )
+
+            Test.assertEventInPhase(result, Type<Withdraw>(), Test.TransactionPhase.execute)
+            Test.assertEventInPhase(result, Type<Deposit>(), Test.TransactionPhase.post)
+        }
+
+        access(all)
+        fun testOtherPhase() {
+            let result = executeTransfer()
+            Test.assertEventInPhase(result, Type<Deposit>(), Test.TransactionPhase.execute)
+        }
+    `
+
+	newEvent := func(inter *interpreter.Interpreter, identifier string) interpreter.Value {
+		return interpreter.NewCompositeValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			utils.TestLocation,
+			identifier,
+			common.CompositeKindEvent,
+			[]interpreter.CompositeField{
+				interpreter.NewUnmeteredCompositeField(
+					"amount",
+					interpreter.NewUnmeteredIntValueFromInt64(10),
+				),
+			},
+			common.ZeroAddress,
+		)
+	}
+
+	newTestFramework := func() *mockedTestFramework {
+		var pendingInter *interpreter.Interpreter
+
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					addTransaction: func(
+						inter *interpreter.Interpreter,
+						_ string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
+					) error {
+						pendingInter = inter
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						// Simulate a transaction which withdraws in the execute phase,
+						// and deposits in a post-condition
+						return &TransactionResult{
+							Events: []interpreter.Value{
+								newEvent(pendingInter, "Withdraw"),
+								newEvent(pendingInter, "Deposit"),
+							},
+							EventPhases: []interpreter.TransactionPhase{
+								interpreter.TransactionPhaseExecute,
+								interpreter.TransactionPhasePost,
+							},
+						}
+					},
+					commitBlock: func() error {
+						return nil
+					},
+				}
+			},
+		}
+	}
+
+	t.Run("emitted", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testEmitted")
+		require.NoError(t, err)
+	})
+
+	t.Run("other phase", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testOtherPhase")
+		require.Error(t, err)
+		assert.ErrorContains(
+			t,
+			err,
+			"event of type `S.test.Deposit` was not emitted in the execute phase of the transaction",
+		)
+	})
+}
+
+func TestAssertTotalSupplyUnchanged(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        access(all)
+        fun run(_ code: String) {
+            let result = Test.executeTransaction(
+                Test.Transaction(
+                    code: code,
+                    authorizers: [],
+                    signers: [],
+                    arguments: []
+                )
+            )
+            Test.expect(result, Test.beSucceeded())
+        }
+
+        access(all)
+        fun testTransfer() {
+            Test.assertTotalSupplyUnchanged("FooToken", during: fun () {
+                run("transfer")
+            })
+        }
+
+        access(all)
+        fun testMint() {
+            Test.assertTotalSupplyUnchanged("FooToken", during: fun () {
+                run("transfer")
+                run("mint")
+            })
+        }
+    `
+
+	newTestFramework := func() *mockedTestFramework {
+		var supply uint64 = 1000_00000000
+		var pending string
+
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScript: func(
+						inter *interpreter.Interpreter,
+						code string,
+						arguments []interpreter.Value,
+					) *ScriptResult {
+						assert.Contains(t, code, `import "FooToken"`)
+						assert.Contains(t, code, "FooToken.totalSupply")
+
+						return &ScriptResult{
+							Value: interpreter.NewUnmeteredUFix64Value(supply),
+						}
+					},
+					addTransaction: func(
+						inter *interpreter.Interpreter,
+						code string,
+						authorizers []common.Address,
+						signers []*Account,
+						arguments []interpreter.Value,
+					) error {
+						pending = code
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						// The buggy mint increases the supply
+						if pending == "mint" {
+							supply += 10_00000000
+						}
+						return &TransactionResult{}
+					},
+					commitBlock: func() error {
+						return nil
+					},
+				}
+			},
+		}
+	}
+
+	t.Run("transfer preserves supply", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testTransfer")
+		require.NoError(t, err)
+	})
+
+	t.Run("mint changes supply", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testMint")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: total supply of contract `FooToken` changed: "+
+				"before: 1000.00000000, after: 1010.00000000",
+		)
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		t.Parallel()
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScript: func(
+						inter *interpreter.Interpreter,
+						code string,
+						arguments []interpreter.Value,
+					) *ScriptResult {
+						return &ScriptResult{
+							Error: errors.New("cannot find declaration `FooToken`"),
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testTransfer")
+		require.Error(t, err)
+		assert.ErrorContains(
+			t,
+			err,
+			"failed to read the total supply of contract `FooToken`: cannot find declaration `FooToken`",
+		)
+	})
+}
+
+func TestHashValue(t *testing.T) {
+
+	t.Parallel()
+
+	const declarations = `
+        import Test
+
+        access(all)
+        struct Point {
+            access(all)
+            let x: Int
+
+            access(all)
+            let y: Int
+
+            init(x: Int, y: Int) {
+                self.x = x
+                self.y = y
+            }
+        }
+    `
+
+	t.Run("equal values", func(t *testing.T) {
+		t.Parallel()
+
+		script := declarations + `
+            access(all)
+            fun test() {
+                Test.assertEqual(32, Test.hashValue(Point(x: 1, y: 2)).length)
+                Test.assertEqual(
+                    Test.hashValue(Point(x: 1, y: 2)),
+                    Test.hashValue(Point(x: 1, y: 2))
+                )
+
+                let first: {String: Int} = {}
+                first["a"] = 1
+                first["b"] = 2
+
+                let second: {String: Int} = {}
+                second["b"] = 2
+                second["a"] = 1
+
+                Test.assertEqual(Test.hashValue(first), Test.hashValue(second))
+
+                Test.assertEqual(
+                    Test.hashValue([Point(x: 1, y: 2), Point(x: 3, y: 4)]),
+                    Test.hashValue([Point(x: 1, y: 2), Point(x: 3, y: 4)])
+                )
+
+                Test.assertEqual(
+                    [
+                        0x20, 0xae, 0x9a, 0x58, 0x54, 0x52, 0x3f, 0xce,
+                        0x7e, 0x94, 0x98, 0x52, 0x05, 0x11, 0x79, 0xa1,
+                        0x17, 0x39, 0x42, 0xe1, 0x51, 0x9b, 0x79, 0x72,
+                        0x95, 0x22, 0xc2, 0xdd, 0xd1, 0xf0, 0xe2, 0x02
+                    ] as [UInt8],
+                    Test.hashValue("hi")
+                )
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("different values", func(t *testing.T) {
+		t.Parallel()
+
+		script := declarations + `
+            access(all)
+            fun test() {
+                Test.assertEqual(
+                    false,
+                    Test.hashValue(Point(x: 1, y: 2)) == Test.hashValue(Point(x: 2, y: 1))
+                )
+                Test.assertEqual(
+                    false,
+                    Test.hashValue([1, 2]) == Test.hashValue([2, 1])
+                )
+                Test.assertEqual(
+                    false,
+                    Test.hashValue({"a": 1}) == Test.hashValue({"a": 2})
+                )
+                Test.assertEqual(
+                    false,
+                    Test.hashValue(1) == Test.hashValue("1")
+                )
+                Test.assertEqual(
+                    false,
+                    Test.hashValue(1) == Test.hashValue(UInt8(1))
+                )
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            resource R {}
+
+            access(all)
+            fun test() {
+                let r <- create R()
+                Test.hashValue(<-r)
+            }
+        `
+
+		_, err := newTestContractInterpreter(t, script)
+		errs := checker.RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+func TestAssertFailsAt(t *testing.T) {
+
+	t.Parallel()
+
+	const bankContract = `
+        access(all)
+        contract Bank {
+
+            access(all)
+            fun withdraw(amount: Int) {
+                if amount > 10 {
+                    panic("insufficient balance")
+                }
+            }
+        }
+    `
+
+	bankLocation := common.StringLocation("bank")
+
+	const script = `
+        import Test
+        import Bank from "bank"
+
+        access(all)
+        fun testExpectedLocation() {
+            Test.assertFailsAt(
+                fun () {
+                    Bank().withdraw(amount: 20)
+                },
+                location: "S.bank",
+                line: 8
+            )
+        }
+
+        access(all)
+        fun testUnexpectedLine() {
+            Test.assertFailsAt(
+                fun () {
+                    Bank().withdraw(amount: 20)
+                },
+                location: "S.bank",
+                line: 5
+            )
+        }
+
+        access(all)
+        fun testUnexpectedLocation() {
+            Test.assertFailsAt(
+                fun () {
+                    panic("failure in test")
+                },
+                location: "S.bank",
+                line: 8
+            )
+        }
+
+        access(all)
+        fun testNoFailure() {
+            Test.assertFailsAt(
+                fun () {
+                    Bank().withdraw(amount: 5)
+                },
+                location: "S.bank",
+                line: 8
+            )
+        }
+    `
+
+	newInterpreter := func(t *testing.T) *interpreter.Interpreter {
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithImports(
+			t,
+			script,
+			testFramework,
+			map[common.Location]string{
+				bankLocation: bankContract,
+			},
+		)
+		require.NoError(t, err)
+
+		return inter
+	}
+
+	t.Run("expected location", func(t *testing.T) {
+		t.Parallel()
+
+		inter := newInterpreter(t)
+
+		_, err := inter.Invoke("testExpectedLocation")
+		require.NoError(t, err)
+	})
+
+	t.Run("unexpected line", func(t *testing.T) {
+		t.Parallel()
+
+		inter := newInterpreter(t)
+
+		_, err := inter.Invoke("testUnexpectedLine")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected a failure at S.bank:5, "+
+				"but it failed at S.bank:8",
+		)
+	})
+
+	t.Run("unexpected location", func(t *testing.T) {
+		t.Parallel()
+
+		inter := newInterpreter(t)
+
+		_, err := inter.Invoke("testUnexpectedLocation")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected a failure at S.bank:8, "+
+				"but it failed at S.test:31",
+		)
+	})
+
+	t.Run("no failure", func(t *testing.T) {
+		t.Parallel()
+
+		inter := newInterpreter(t)
+
+		_, err := inter.Invoke("testNoFailure")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected a failure at S.bank:8, but found none",
+		)
+	})
+}
+
+func TestAssertScriptReadOnly(t *testing.T) {
+
+	t.Parallel()
+
+	newTestFramework := func(result *ScriptResult) *mockedTestFramework {
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScript: func(
+						inter *interpreter.Interpreter,
+						code string,
+						arguments []interpreter.Value,
+					) *ScriptResult {
+						return result
+					},
+				}
+			},
+		}
+	}
+
+	t.Run("read-only", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertScriptReadOnly(
+                    "access(all) fun main(): Int { return getAccount(0x1).storage.used }"
+                )
+            }
+        `
+
+		testFramework := newTestFramework(&ScriptResult{
+			Value: interpreter.NewUnmeteredUInt64Value(100),
+		})
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("write", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertScriptReadOnly(
+                    "access(all) fun main() { getAuthAccount<auth(Storage) &Account>(0x1).storage.save(1, to: /storage/foo) }"
+                )
+            }
+        `
+
+		testFramework := newTestFramework(&ScriptResult{
+			Value:         interpreter.Void,
+			StorageWrites: []string{"/storage/foo"},
+		})
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: script is not read-only: wrote to: /storage/foo",
+		)
+	})
+
+	t.Run("rejected write", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertScriptReadOnly(
+                    "access(all) fun main() { getAccount(0x1).storage.save(1, to: /storage/foo) }"
+                )
+            }
+        `
+
+		testFramework := newTestFramework(&ScriptResult{
+			Error: errors.New("value of type `Account.Storage` has no member `save`"),
+		})
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: script failed: value of type `Account.Storage` has no member `save`",
+		)
+	})
+}
+
+func TestResultStatusCaseName(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        access(all)
+        fun test() {
+            Test.assertEqual("succeeded", Test.ResultStatus.succeeded.caseName)
+            Test.assertEqual("failed", Test.ResultStatus.failed.caseName)
+
+            Test.assertEqual(
+                Test.ResultStatus.succeeded,
+                Test.ResultStatus.fromCaseName(Test.ResultStatus.succeeded.caseName)!
+            )
+            Test.assertEqual(
+                Test.ResultStatus.failed,
+                Test.ResultStatus.fromCaseName(Test.ResultStatus.failed.caseName)!
+            )
+
+            Test.assert(Test.ResultStatus.fromCaseName("unknown") == nil)
+            Test.assert(Test.ResultStatus.fromCaseName("") == nil)
+        }
+    `
+
+	inter, err := newTestContractInterpreter(t, script)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+}
+
+func TestAssertTransactionAtomic(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        access(all)
+        fun test() {
+            let tx = Test.Transaction(
+                code: "transaction { prepare(signer: auth(Storage) &Account) { signer.storage.save(1, to: /storage/counter) } post { false: \"abort\" } }",
+                authorizers: [0x01],
+                signers: [],
+                arguments: []
+            )
+            Test.assertTransactionAtomic(tx)
+        }
+
+        access(all)
+        fun testTwice() {
+            test()
+            test()
+        }
+    `
+
+	const counterKey = "0x0000000000000001/storage/counter"
+
+	// newTestFramework simulates a blockchain which executes the transaction,
+	// which writes to storage, and then fails.
+	// If rollback is true, the write is reverted, like it should be.
+	newTestFramework := func(failing bool, rollback bool) *mockedTestFramework {
+		storage := map[string]interpreter.Value{}
+		snapshots := map[string]map[string]interpreter.Value{}
+		var queue []string
+
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					addTransaction: func(
+						_ *interpreter.Interpreter,
+						code string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
+					) error {
+						queue = append(queue, code)
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						if len(queue) == 0 {
+							return nil
+						}
+						queue = queue[1:]
+
+						storage[counterKey] = interpreter.NewUnmeteredIntValueFromInt64(1)
+
+						if !failing {
+							return &TransactionResult{}
+						}
+
+						if rollback {
+							delete(storage, counterKey)
+						}
+						return &TransactionResult{
+							Error: errors.New("post-condition failed: abort"),
+						}
+					},
+					commitBlock: func() error {
+						return nil
+					},
+					createSnapshot: func(name string) error {
+						snapshot := make(map[string]interpreter.Value, len(storage))
+						for key, value := range storage { //nolint:maprange
+							snapshot[key] = value
+						}
+						snapshots[name] = snapshot
+						return nil
+					},
+					diffSnapshots: func(
+						_ *interpreter.Interpreter,
+						before string,
+						after string,
+					) (map[string]interpreter.Value, error) {
+						beforeSnapshot := snapshots[before]
+						afterSnapshot := snapshots[after]
+
+						changes := map[string]interpreter.Value{}
+						for key, value := range afterSnapshot { //nolint:maprange
+							if _, ok := beforeSnapshot[key]; !ok {
+								changes[key] = value
+							}
+						}
+						for key := range beforeSnapshot { //nolint:maprange
+							if _, ok := afterSnapshot[key]; !ok {
+								changes[key] = nil
+							}
+						}
+						return changes, nil
+					},
+				}
+			},
+		}
+	}
+
+	t.Run("atomic", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework(true, true))
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("not atomic", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework(true, false))
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: transaction is not atomic: "+
+				"changes of the failed transaction persisted: "+counterKey,
+		)
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework(false, false))
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: expected the transaction to fail, but it succeeded",
+		)
+	})
+
+	t.Run("repeated", func(t *testing.T) {
+		t.Parallel()
+
+		testFramework := newTestFramework(true, true)
+
+		blockchain := testFramework.emulatorBackend().(*mockedBlockchain)
+		createSnapshot := blockchain.createSnapshot
+
+		var snapshotNames []string
+		blockchain.createSnapshot = func(name string) error {
+			snapshotNames = append(snapshotNames, name)
+			return createSnapshot(name)
+		}
+		testFramework.emulatorBackend = func() Blockchain {
+			return blockchain
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testTwice")
+		require.NoError(t, err)
+
+		// Each call creates new snapshots, instead of overwriting the ones of the previous call
+		require.Len(t, snapshotNames, 4)
+
+		uniqueNames := map[string]struct{}{}
+		for _, name := range snapshotNames {
+			assert.True(t, strings.HasPrefix(name, "assertTransactionAtomic."))
+			uniqueNames[name] = struct{}{}
+		}
+		assert.Len(t, uniqueNames, 4)
+	})
+}
+
+func TestAssertTransactionIdempotent(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        access(all)
+        fun test() {
+            let tx = Test.Transaction(
+                code: "transaction { prepare(signer: auth(Storage) &Account) {} }",
+                authorizers: [0x01],
+                signers: [],
+                arguments: []
+            )
+            Test.assertTransactionIdempotent(tx)
+        }
+    `
+
+	const counterKey = "0x0000000000000001/storage/counter"
+
+	// newTestFramework simulates a blockchain which executes transactions
+	// using the given function, which updates the storage
+	// and returns the error of the transaction, if any.
+	newTestFramework := func(execute func(storage map[string]int64) error) *mockedTestFramework {
+		storage := map[string]int64{}
+		snapshots := map[string]map[string]int64{}
+		var queue []string
+
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					addTransaction: func(
+						_ *interpreter.Interpreter,
+						code string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
+					) error {
+						queue = append(queue, code)
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						if len(queue) == 0 {
+							return nil
+						}
+						queue = queue[1:]
+
+						// Failed transactions are reverted
+						updatedStorage := make(map[string]int64, len(storage))
+						for key, value := range storage { //nolint:maprange
+							updatedStorage[key] = value
+						}
+
+						err := execute(updatedStorage)
+						if err != nil {
+							return &TransactionResult{
+								Error: err,
+							}
+						}
+
+						storage = updatedStorage
+						return &TransactionResult{}
+					},
+					commitBlock: func() error {
+						return nil
+					},
+					createSnapshot: func(name string) error {
+						snapshot := make(map[string]int64, len(storage))
+						for key, value := range storage { //nolint:maprange
+							snapshot[key] = value
+						}
+						snapshots[name] = snapshot
+						return nil
+					},
+					diffSnapshots: func(
+						_ *interpreter.Interpreter,
+						before string,
+						after string,
+					) (map[string]interpreter.Value, error) {
+						beforeSnapshot := snapshots[before]
+						afterSnapshot := snapshots[after]
+
+						changes := map[string]interpreter.Value{}
+						for key, value := range afterSnapshot { //nolint:maprange
+							beforeValue, ok := beforeSnapshot[key]
+							if !ok || beforeValue != value {
+								changes[key] = interpreter.NewUnmeteredIntValueFromInt64(value)
+							}
+						}
+						for key := range beforeSnapshot { //nolint:maprange
+							if _, ok := afterSnapshot[key]; !ok {
+								changes[key] = nil
+							}
+						}
+						return changes, nil
+					},
+				}
+			},
+		}
+	}
+
+	t.Run("idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		testFramework := newTestFramework(func(storage map[string]int64) error {
+			storage[counterKey] = 1
+			return nil
+		})
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("second execution fails", func(t *testing.T) {
+		t.Parallel()
+
+		testFramework := newTestFramework(func(storage map[string]int64) error {
+			if _, ok := storage[counterKey]; ok {
+				return errors.New("pre-condition failed: already initialized")
+			}
+			storage[counterKey] = 1
+			return nil
+		})
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("not idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		testFramework := newTestFramework(func(storage map[string]int64) error {
+			storage[counterKey]++
+			return nil
+		})
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: transaction is not idempotent: second execution changed: "+counterKey,
+		)
+	})
+
+	t.Run("first execution fails", func(t *testing.T) {
+		t.Parallel()
+
+		testFramework := newTestFramework(func(storage map[string]int64) error {
+			return errors.New("panic: abort")
+		})
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(t, err, "assertion failed: transaction failed: panic: abort")
+	})
+}
+
+func TestAssertMigrationsCommute(t *testing.T) {
+
+	t.Parallel()
+
+	const fooKey = "0x0000000000000001/storage/foo"
+	const barKey = "0x0000000000000001/storage/bar"
+
+	type migration func(storage map[string]interpreter.Value)
+
+	setField := func(key string, value int64) migration {
+		return func(storage map[string]interpreter.Value) {
+			storage[key] = interpreter.NewUnmeteredIntValueFromInt64(value)
+		}
+	}
+
+	copyStorage := func(storage map[string]interpreter.Value) map[string]interpreter.Value {
+		result := make(map[string]interpreter.Value, len(storage))
+		for key, value := range storage { //nolint:maprange
+			result[key] = value
+		}
+		return result
+	}
+
+	// newTestFramework simulates a blockchain with snapshots,
+	// and the given migrations which update the fields of an account's storage.
+	newTestFramework := func(migrations map[string]migration) *mockedTestFramework {
+		storage := map[string]interpreter.Value{}
+		snapshots := map[string]map[string]interpreter.Value{
+			"before": {
+				fooKey: interpreter.NewUnmeteredIntValueFromInt64(0),
+				barKey: interpreter.NewUnmeteredIntValueFromInt64(0),
+			},
+		}
+
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					createSnapshot: func(name string) error {
+						snapshots[name] = copyStorage(storage)
+						return nil
+					},
+					loadSnapshot: func(name string) error {
+						snapshot, ok := snapshots[name]
+						if !ok {
+							return fmt.Errorf("unknown snapshot: %s", name)
+						}
+						storage = copyStorage(snapshot)
+						return nil
+					},
+					applyMigration: func(name string, snapshot string) ([]string, error) {
+						migration, ok := migrations[name]
+						if !ok {
+							return nil, fmt.Errorf("unknown migration: %s", name)
+						}
+						migration(snapshots[snapshot])
+						return nil, nil
+					},
+					diffSnapshots: func(
+						inter *interpreter.Interpreter,
+						before string,
+						after string,
+					) (map[string]interpreter.Value, error) {
+						beforeSnapshot := snapshots[before]
+						afterSnapshot := snapshots[after]
+
+						changes := map[string]interpreter.Value{}
+						for key, value := range afterSnapshot { //nolint:maprange
+							beforeValue, ok := beforeSnapshot[key]
+							if !ok || !beforeValue.(interpreter.EquatableValue).Equal(
+								inter,
+								interpreter.EmptyLocationRange,
+								value,
+							) {
+								changes[key] = value
+							}
+						}
+						for key := range beforeSnapshot { //nolint:maprange
+							if _, ok := afterSnapshot[key]; !ok {
+								changes[key] = nil
+							}
+						}
+						return changes, nil
+					},
+				}
+			},
+		}
+	}
+
+	t.Run("commuting", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertMigrationsCommute("FooMigration", "BarMigration", snapshot: "before")
+            }
+        `
+
+		testFramework := newTestFramework(map[string]migration{
+			"FooMigration": setField(fooKey, 1),
+			"BarMigration": setField(barKey, 2),
+		})
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("conflicting", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertMigrationsCommute("FooMigration", "OtherFooMigration", snapshot: "before")
+            }
+        `
+
+		testFramework := newTestFramework(map[string]migration{
+			"FooMigration":      setField(fooKey, 1),
+			"OtherFooMigration": setField(fooKey, 2),
+		})
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: migrations FooMigration and OtherFooMigration do not commute: "+
+				"results differ at: "+fooKey,
+		)
+	})
+
+	t.Run("unknown snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertMigrationsCommute("FooMigration", "BarMigration", snapshot: "unknown")
+            }
+        `
+
+		testFramework := newTestFramework(map[string]migration{
+			"FooMigration": setField(fooKey, 1),
+			"BarMigration": setField(barKey, 2),
+		})
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown snapshot: unknown")
+	})
+}
+
+func TestAssertGoldenOutput(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        access(all)
+        fun test() {
+            let result = Test.executeScript("access(all) fun main(): Int { return 42 }", [])
+            Test.assertGoldenOutput(result, "answer")
+        }
+    `
+
+	run := func(
+		files map[string]string,
+		update bool,
+		result *ScriptResult,
+	) error {
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScript: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []interpreter.Value,
+					) *ScriptResult {
+						return result
+					},
+				}
+			},
+			readFile: func(path string) (string, error) {
+				content, ok := files[path]
+				if !ok {
+					return "", fmt.Errorf("file not found: %s", path)
+				}
+				return content, nil
+			},
+			writeFile: func(path string, content string) error {
+				files[path] = content
+				return nil
+			},
+			updateGolden: update,
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		return err
+	}
+
+	newGoldenFiles := func(t *testing.T) map[string]string {
+		files := map[string]string{}
+		err := run(
+			files,
+			true,
+			&ScriptResult{
+				Value: interpreter.NewUnmeteredIntValueFromInt64(42),
+			},
+		)
+		require.NoError(t, err)
+		require.Contains(t, files, "answer.golden")
+		return files
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		t.Parallel()
+
+		files := newGoldenFiles(t)
+
+		err := run(
+			files,
+			false,
+			&ScriptResult{
+				Value: interpreter.NewUnmeteredIntValueFromInt64(42),
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("differing", func(t *testing.T) {
+		t.Parallel()
+
+		files := newGoldenFiles(t)
+
+		err := run(
+			files,
+			false,
+			&ScriptResult{
+				Value: interpreter.NewUnmeteredIntValueFromInt64(43),
+			},
+		)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(t, err, "output does not match golden file `answer.golden`")
+	})
+
+	t.Run("script failed", func(t *testing.T) {
+		t.Parallel()
+
+		files := newGoldenFiles(t)
+
+		err := run(
+			files,
+			false,
+			&ScriptResult{
+				Error: errors.New("division by zero"),
+			},
+		)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(t, err, "assertion failed: script failed: division by zero")
+	})
+
+	t.Run("missing golden file", func(t *testing.T) {
+		t.Parallel()
+
+		err := run(
+			map[string]string{},
+			false,
+			&ScriptResult{
+				Value: interpreter.NewUnmeteredIntValueFromInt64(42),
+			},
+		)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "cannot read golden file `answer.golden`: file not found: answer.golden")
+	})
+}
+
+func TestAssertDeterministic(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("deterministic", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertDeterministic("access(all) fun main(): Int { return 42 }", runs: 3)
+            }
+        `
+
+		runs := 0
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScript: func(
+						inter *interpreter.Interpreter,
+						code string,
+						arguments []interpreter.Value,
+					) *ScriptResult {
+						runs++
+						return &ScriptResult{
+							Value: interpreter.NewUnmeteredIntValueFromInt64(42),
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, runs)
+	})
+
+	t.Run("nondeterministic", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertDeterministic("access(all) fun main(): UInt64 { return revertibleRandom<UInt64>() }", runs: 3)
+            }
+        `
+
+		var counter int64
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScript: func(
+						inter *interpreter.Interpreter,
+						code string,
+						arguments []interpreter.Value,
+					) *ScriptResult {
+						counter++
+						return &ScriptResult{
+							Value: interpreter.NewUnmeteredIntValueFromInt64(counter),
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"assertion failed: script is not deterministic: run 1 returned: 1, run 2 returned: 2",
+		)
+	})
+
+	t.Run("failed run", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertDeterministic("access(all) fun main() { panic(\"oops\") }", runs: 2)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScript: func(
+						inter *interpreter.Interpreter,
+						code string,
+						arguments []interpreter.Value,
+					) *ScriptResult {
+						return &ScriptResult{
+							Error: fmt.Errorf("oops"),
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(t, err, "assertion failed: script failed in run 1: oops")
+	})
+
+	t.Run("invalid number of runs", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertDeterministic("access(all) fun main(): Int { return 42 }", runs: 0)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "number of runs must be at least 1, got 0")
+	})
+}
+
+func TestLoadFixture(t *testing.T) {
+
+	t.Parallel()
+
+	// decodeIntFixture decodes JSON-Cadence encoded Int values
+	decodeIntFixture := func(_ *interpreter.Interpreter, data []byte) (interpreter.Value, error) {
+		var encoded struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		}
+		err := json.Unmarshal(data, &encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		if encoded.Type != "Int" {
+			return nil, fmt.Errorf("unsupported type: %s", encoded.Type)
+		}
+
+		value, ok := new(big.Int).SetString(encoded.Value, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid Int: %s", encoded.Value)
+		}
+
+		return interpreter.NewUnmeteredIntValueFromBigInt(value), nil
+	}
+
+	fixtures := map[string]string{
+		"fixtures/answer.json":  `{"type":"Int","value":"42"}`,
+		"fixtures/invalid.json": `{"type":"Int"`,
+	}
+
+	newTestFramework := func() *mockedTestFramework {
+		return &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScript: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []interpreter.Value,
+					) *ScriptResult {
+						return &ScriptResult{
+							Value: interpreter.NewUnmeteredIntValueFromInt64(42),
+						}
+					},
+				}
+			},
+			readFile: func(path string) (string, error) {
+				content, ok := fixtures[path]
+				if !ok {
+					return "", fmt.Errorf("file not found: %s", path)
+				}
+				return content, nil
+			},
+			decodeJSONValue: decodeIntFixture,
+		}
+	}
+
+	t.Run("matches script result", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let result = Test.executeScript("access(all) fun main(): Int { return 42 }", [])
+                Test.expect(result, Test.beSucceeded())
+
+                let expected = Test.loadFixture("fixtures/answer.json")
+                Test.assertEqual(expected, result.returnValue!)
+            }
+        `
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("missing fixture", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.loadFixture("fixtures/missing.json")
+            }
+        `
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "cannot read fixture `fixtures/missing.json`")
+	})
+
+	t.Run("invalid fixture", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.loadFixture("fixtures/invalid.json")
+            }
+        `
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "cannot decode fixture `fixtures/invalid.json`")
+	})
+}
+
+func TestTestBeSucceededMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("matcher beSucceeded with ScriptResult", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let successful = Test.beSucceeded()
+
+                let scriptResult = Test.ScriptResult(
+                    status: Test.ResultStatus.succeeded,
+                    returnValue: 42,
+                    error: nil
+                )
+
+                return successful.test(scriptResult)
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let successful = Test.beSucceeded()
+
+                let scriptResult = Test.ScriptResult(
+                    status: Test.ResultStatus.failed,
+                    returnValue: nil,
+                    error: Test.Error("Exceeding limit")
+                )
+
+                return successful.test(scriptResult)
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher beSucceeded with TransactionResult", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let successful = Test.beSucceeded()
+
+                let transactionResult = Test.TransactionResult(
+                    status: Test.ResultStatus.succeeded,
+                    error: nil
+                )
+
+                return successful.test(transactionResult)
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let successful = Test.beSucceeded()
+
+                let transactionResult = Test.TransactionResult(
+                    status: Test.ResultStatus.failed,
+                    error: Test.Error("Exceeded Limit")
+                )
+
+                return successful.test(transactionResult)
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher beSucceeded with type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test(): Bool {
+                let successful = Test.beSucceeded()
+
+                return successful.test("hello")
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+	})
+}
+
+func TestTestBeFailedMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("matcher beFailed with ScriptResult", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let failed = Test.beFailed()
+
+                let scriptResult = Test.ScriptResult(
+                    status: Test.ResultStatus.failed,
+                    returnValue: nil,
+                    error: Test.Error("Exceeding limit")
+                )
+
+                return failed.test(scriptResult)
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let failed = Test.beFailed()
+
+                let scriptResult = Test.ScriptResult(
+                    status: Test.ResultStatus.succeeded,
+                    returnValue: 42,
+                    error: nil
+                )
+
+                return failed.test(scriptResult)
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher beFailed with TransactionResult", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let failed = Test.beFailed()
+
+                let transactionResult = Test.TransactionResult(
+                    status: Test.ResultStatus.failed,
+                    error: Test.Error("Exceeding limit")
+                )
+
+                return failed.test(transactionResult)
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let failed = Test.beFailed()
+
+                let transactionResult = Test.TransactionResult(
+                    status: Test.ResultStatus.succeeded,
+                    error: nil
+                )
+
+                return failed.test(transactionResult)
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher beFailed with type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test(): Bool {
+                let failed = Test.beFailed()
+
+                return failed.test([])
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+	})
+	t.Run("matcher beFailedWithMessage", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch() {
+                let result = Test.TransactionResult(
+                    status: Test.ResultStatus.failed,
+                    error: Test.Error("panic: insufficient balance: requested 10.0")
+                )
+
+                Test.expect(result, Test.beFailedWithMessage("insufficient balance"))
+            }
+
+            access(all)
+            fun testOtherMessage() {
+                let result = Test.TransactionResult(
+                    status: Test.ResultStatus.failed,
+                    error: Test.Error("panic: unauthorized")
+                )
+
+                Test.expect(result, Test.beFailedWithMessage("insufficient balance"))
+            }
+
+            access(all)
+            fun testSucceeded(): Bool {
+                let result = Test.ScriptResult(
+                    status: Test.ResultStatus.succeeded,
+                    returnValue: 42,
+                    error: nil
+                )
+
+                return Test.beFailedWithMessage("insufficient balance").test(result)
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testMatch")
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testOtherMessage")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unauthorized")
+
+		result, err := inter.Invoke("testSucceeded")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+}
+
+func TestTestAssertErrorMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("with ScriptResult", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun testMatch() {
+                let result = Test.ScriptResult(
+                    status: Test.ResultStatus.failed,
+                    returnValue: nil,
+                    error: Test.Error("computation exceeding limit")
+                )
+
+                Test.assertError(result, errorMessage: "exceeding limit")
+            }
+
+            access(all)
+            fun testNoMatch() {
+                let result = Test.ScriptResult(
+                    status: Test.ResultStatus.failed,
+                    returnValue: nil,
+                    error: Test.Error("computation exceeding memory")
+                )
+
+                Test.assertError(result, errorMessage: "exceeding limit")
+            }
+
+            access(all)
+            fun testNoError() {
+                let result = Test.ScriptResult(
+                    status: Test.ResultStatus.succeeded,
+                    returnValue: 42,
+                    error: nil
+                )
+
+                Test.assertError(result, errorMessage: "exceeding limit")
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testMatch")
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testNoMatch")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "the error message did not contain the given sub-string")
+
+		_, err = inter.Invoke("testNoError")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "no error was found")
+	})
+
+	t.Run("with TransactionResult", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun testMatch() {
+                let result = Test.TransactionResult(
+                    status: Test.ResultStatus.failed,
+                    error: Test.Error("computation exceeding limit")
+                )
+
+                Test.assertError(result, errorMessage: "exceeding limit")
+            }
+
+            access(all)
+            fun testNoMatch() {
+                let result = Test.TransactionResult(
+                    status: Test.ResultStatus.failed,
+                    error: Test.Error("computation exceeding memory")
+                )
+
+                Test.assertError(result, errorMessage: "exceeding limit")
+            }
+
+            access(all)
+            fun testNoError() {
+                let result = Test.TransactionResult(
+                    status: Test.ResultStatus.succeeded,
+                    error: nil
+                )
+
+                Test.assertError(result, errorMessage: "exceeding limit")
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testMatch")
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testNoMatch")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "the error message did not contain the given sub-string")
+
+		_, err = inter.Invoke("testNoError")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "no error was found")
+	})
+
+}
+
+func TestTestBeNilMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("matcher beNil", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let isNil = Test.beNil()
+
+                return isNil.test(nil)
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let isNil = Test.beNil()
+
+                return isNil.test([1, 2])
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+}
+
+func TestTestBeEmptyMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("matcher beEmpty with Array", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let emptyArray = Test.beEmpty()
+
+                return emptyArray.test([])
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let emptyArray = Test.beEmpty()
+
+                return emptyArray.test([42, 23, 31])
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher beEmpty with Dictionary", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let emptyDict = Test.beEmpty()
+                let dict: {Bool: Int} = {}
+
+                return emptyDict.test(dict)
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let emptyDict = Test.beEmpty()
+                let dict: {Bool: Int} = {true: 1, false: 0}
+
+                return emptyDict.test(dict)
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher beEmpty with type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test(): Bool {
+                let emptyDict = Test.beEmpty()
+
+                return emptyDict.test("empty")
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &cdcErrors.DefaultUserError{})
+		assert.ErrorContains(t, err, "expected Array or Dictionary argument")
+	})
+}
+
+func TestTestHaveElementCountMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("matcher haveElementCount with Array", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let hasThreeElements = Test.haveElementCount(3)
+
+                return hasThreeElements.test([42, 23, 31])
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let hasThreeElements = Test.haveElementCount(3)
+
+                return hasThreeElements.test([42])
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher haveElementCount with Dictionary", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let hasTwoElements = Test.haveElementCount(2)
+                let dict: {Bool: Int} = {true: 1, false: 0}
+
+                return hasTwoElements.test(dict)
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let hasTwoElements = Test.haveElementCount(2)
+                let dict: {Bool: Int} = {}
+
+                return hasTwoElements.test(dict)
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher haveElementCount with type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test(): Bool {
+                let hasTwoElements = Test.haveElementCount(2)
+
+                return hasTwoElements.test("two")
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &cdcErrors.DefaultUserError{})
+		assert.ErrorContains(t, err, "expected Array or Dictionary argument")
+	})
+}
+
+func TestTestContainMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("matcher contain with Array", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let containsTwenty = Test.contain(20)
+
+                return containsTwenty.test([42, 20, 31])
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let containsTwenty = Test.contain(20)
+
+                return containsTwenty.test([42])
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher contain with Dictionary", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let containsFalse = Test.contain(false)
+                let dict: {Bool: Int} = {true: 1, false: 0}
+
+                return containsFalse.test(dict)
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let containsFive = Test.contain(5)
+                let dict: {Int: Bool} = {1: true, 0: false}
+
+                return containsFive.test(dict)
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher contain with type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test(): Bool {
+                let containsFalse = Test.contain(false)
+
+                return containsFalse.test("false")
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &cdcErrors.DefaultUserError{})
+		assert.ErrorContains(t, err, "expected Array or Dictionary argument")
+	})
+}
+
+func TestTestBeGreaterThanMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("matcher beGreaterThan", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let greaterThanFive = Test.beGreaterThan(5)
+
+                return greaterThanFive.test(7)
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let greaterThanFive = Test.beGreaterThan(5)
+
+                return greaterThanFive.test(2)
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher beGreaterThan with type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test(): Bool {
+                let greaterThanFive = Test.beGreaterThan(5)
+
+                return greaterThanFive.test("7")
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+	})
+}
+
+func TestTestBeLessThanMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("matcher beLessThan", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun testMatch(): Bool {
+                let lessThanSeven = Test.beLessThan(7)
+
+                return lessThanSeven.test(5)
+            }
+
+            access(all)
+            fun testNoMatch(): Bool {
+                let lessThanSeven = Test.beLessThan(7)
+
+                return lessThanSeven.test(9)
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+
+		result, err = inter.Invoke("testNoMatch")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("matcher beLessThan with type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test(): Bool {
+                let lessThanSeven = Test.beLessThan(7)
+
+                return lessThanSeven.test(true)
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+	})
+}
+
+func TestTestExpect(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           access(all)
+           fun test() {
+               Test.expect("this string", Test.equal("this string"))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           access(all)
+           fun test() {
+               Test.expect("this string", Test.equal("other string"))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+
+		assertionErr := &AssertionError{}
+		assert.ErrorAs(t, err, assertionErr)
+		assert.Equal(
+			t,
+			"given value is: \"this string\", expected: \"other string\"",
+			assertionErr.Message,
+		)
+		assert.Equal(t, "test", assertionErr.LocationRange.Location.String())
+		assert.Equal(t, 6, assertionErr.LocationRange.StartPosition().Line)
+	})
+
+	t.Run("fail with custom matcher", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           access(all)
+           fun test() {
+               let isEven = Test.newMatcher(fun (_ value: Int): Bool {
+                   return value % 2 == 0
+               })
+               Test.expect(3, isEven)
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+
+		assertionErr := &AssertionError{}
+		assert.ErrorAs(t, err, assertionErr)
+		assert.Equal(t, "given value is: 3", assertionErr.Message)
+	})
+
+	t.Run("different types", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           access(all)
+           fun test() {
+               Test.expect("string", Test.equal(1))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &interpreter.TypeMismatchError{})
+	})
+
+	t.Run("with explicit types", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           access(all)
+           fun test() {
+               Test.expect<String>("hello", Test.equal("hello"))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("mismatching types", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           access(all)
+           fun test() {
+               Test.expect<Int>("string", Test.equal(1))
+           }
+        `
+
+		_, err := newTestContractInterpreter(t, script)
+
+		errs := checker.RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("resource with resource matcher", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           access(all)
+           fun test() {
+               let f1 <- create Foo()
+               let f2 <- create Foo()
+               Test.expect(<-f1, Test.equal(<-f2))
+           }
+
+           access(all)
+           resource Foo {}
+        `
+
+		_, err := newTestContractInterpreter(t, script)
+
+		errs := checker.RequireCheckerErrors(t, err, 2)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	})
+
+	t.Run("resource with struct matcher", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           access(all)
+           fun test() {
+               let foo <- create Foo()
+               let bar = Bar()
+               Test.expect(<-foo, Test.equal(bar))
+           }
+
+           access(all)
+           resource Foo {}
+           access(all)
+           struct Bar {}
+        `
+
+		_, err := newTestContractInterpreter(t, script)
+
+		errs := checker.RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("struct with resource matcher", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           access(all)
+           fun test() {
+               let foo = Foo()
+               let bar <- create Bar()
+               Test.expect(foo, Test.equal(<-bar))
+           }
+
+           access(all)
+           struct Foo {}
+           access(all)
+           resource Bar {}
+        `
+
+		_, err := newTestContractInterpreter(t, script)
+
+		errs := checker.RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+func TestTestExpectFailure(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("expect failure with no failure found", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test() {
+                let foo = Foo(answer: 42)
+                Test.expectFailure(fun(): Void {
+                    foo.correctAnswer(42)
+                }, errorMessageSubstring: "wrong answer!")
+            }
+
+            access(all)
+            struct Foo {
+                access(self) let answer: UInt8
+
+                init(answer: UInt8) {
+                    self.answer = answer
+                }
+
+                access(all)
+                fun correctAnswer(_ input: UInt8): Bool {
+                    if self.answer != input {
+                        panic("wrong answer!")
+                    }
+                    return true
+                }
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(
+			t,
+			err,
+			"Expected a failure, but found none.",
+		)
+	})
+
+	t.Run("expect failure with matching error message", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test() {
+                let foo = Foo(answer: 42)
+                Test.expectFailure(fun(): Void {
+                    foo.correctAnswer(43)
+                }, errorMessageSubstring: "wrong answer!")
+            }
+
+            access(all)
+            struct Foo {
+                access(self) let answer: UInt8
+
+                init(answer: UInt8) {
+                    self.answer = answer
+                }
+
+                access(all)
+                fun correctAnswer(_ input: UInt8): Bool {
+                    if self.answer != input {
+                        panic("wrong answer!")
+                    }
+                    return true
+                }
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("expect failure with mismatching error message", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test() {
+                let foo = Foo(answer: 42)
+                Test.expectFailure(fun(): Void {
+                    foo.correctAnswer(43)
+                }, errorMessageSubstring: "what is wrong?")
+            }
+
+            access(all)
+            struct Foo {
+                access(self) let answer: UInt8
+
+                init(answer: UInt8) {
+                    self.answer = answer
+                }
+
+                access(all)
+                fun correctAnswer(_ input: UInt8): Bool {
+                    if self.answer != input {
+                        panic("wrong answer!")
+                    }
+                    return true
+                }
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(
+			t,
+			err,
+			"Expected error message to include: \"what is wrong?\".",
+		)
+	})
+
+	t.Run("expect failure with wrong function signature", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test() {
+                let foo = Foo(answer: 42)
+                Test.expectFailure(fun(answer: UInt64): Foo {
+                    foo.correctAnswer(42)
+                    return foo
+                }, errorMessageSubstring: "wrong answer")
+            }
+
+            access(all)
+            struct Foo {
+                access(self) let answer: UInt8
+
+                init(answer: UInt8) {
+                    self.answer = answer
+                }
+
+                access(all)
+                fun correctAnswer(_ input: UInt8): Bool {
+                    if self.answer != input {
+                        panic("wrong answer!")
+                    }
+                    return true
+                }
+            }
+        `
+
+		_, err := newTestContractInterpreter(t, script)
+		errs := checker.RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("expect failure with wrong error message type", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test() {
+                let foo = Foo(answer: 42)
+                Test.expectFailure(fun(): Void {
+                    foo.correctAnswer(42)
+                }, errorMessageSubstring: ["wrong answer"])
+            }
+
+            access(all)
+            struct Foo {
+                access(self) let answer: UInt8
+
+                init(answer: UInt8) {
+                    self.answer = answer
+                }
+
+                access(all)
+                fun correctAnswer(_ input: UInt8): Bool {
+                    if self.answer != input {
+                        panic("wrong answer!")
+                    }
+                    return true
+                }
+            }
+        `
+
+		_, err := newTestContractInterpreter(t, script)
+		errs := checker.RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("expect failure with unwrap of nil optional", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.expectFailure(fun(): Void {
+                    let answer: Int? = nil
+                    answer.unwrap(orFail: "answer was not provided")
+                }, errorMessageSubstring: "answer was not provided")
+            }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+}
+
+func TestBlockchain(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("all events, empty", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let events = Test.events()
+
+                Test.expect(events, Test.beEmpty())
+            }
+        `
+
+		eventsInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					events: func(inter *interpreter.Interpreter, eventType interpreter.StaticType) interpreter.Value {
+						eventsInvoked = true
+						assert.Nil(t, eventType)
+						return interpreter.NewArrayValue(
+							inter,
+							interpreter.EmptyLocationRange,
+							interpreter.NewVariableSizedStaticType(inter, interpreter.PrimitiveStaticTypeAnyStruct),
+							common.Address{},
+						)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.True(t, eventsInvoked)
+	})
+
+	t.Run("typed events, empty", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            struct Foo {}
+
+            access(all)
+            fun test() {
+                // 'Foo' is not an event-type.
+                // But we just need to test the API, so it doesn't really matter.
+                let typ = Type<Foo>()
+
+                let events = Test.eventsOfType(typ)
+
+                Test.expect(events, Test.beEmpty())
+            }
+        `
+
+		eventsInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					events: func(inter *interpreter.Interpreter, eventType interpreter.StaticType) interpreter.Value {
+						eventsInvoked = true
+						assert.NotNil(t, eventType)
+
+						require.IsType(t, &interpreter.CompositeStaticType{}, eventType)
+						compositeType := eventType.(*interpreter.CompositeStaticType)
+						assert.Equal(t, "Foo", compositeType.QualifiedIdentifier)
+
+						return interpreter.NewArrayValue(
+							inter,
+							interpreter.EmptyLocationRange,
+							interpreter.NewVariableSizedStaticType(inter, interpreter.PrimitiveStaticTypeAnyStruct),
+							common.Address{},
+						)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.True(t, eventsInvoked)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.reset(to: 5)
+            }
+        `
+
+		resetInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					reset: func(height uint64) {
+						resetInvoked = true
+						assert.Equal(t, uint64(5), height)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.True(t, resetInvoked)
+	})
+
+	t.Run("reset with type mismatch for height", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.reset(to: 5.5)
+            }
+        `
+
+		resetInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					reset: func(height uint64) {
+						resetInvoked = true
+					},
+				}
+			},
+		}
+
+		_, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		errs := checker.RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		assert.False(t, resetInvoked)
+	})
+
+	t.Run("moveTime forward", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun testMoveForward() {
+                // timeDelta is the representation of 35 days,
+                // in the form of seconds.
+                let timeDelta = Fix64(35 * 24 * 60 * 60)
+                Test.moveTime(by: timeDelta)
+            }
+        `
+
+		moveTimeInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					moveTime: func(timeDelta int64) {
+						moveTimeInvoked = true
+						assert.Equal(t, int64(3024000), timeDelta)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testMoveForward")
+		require.NoError(t, err)
+
+		assert.True(t, moveTimeInvoked)
+	})
+
+	t.Run("moveTime backward", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun testMoveBackward() {
+                // timeDelta is the representation of 35 days,
+                // in the form of seconds.
+                let timeDelta = Fix64(35 * 24 * 60 * 60) * -1.0
+                Test.moveTime(by: timeDelta)
+            }
+        `
+
+		moveTimeInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					moveTime: func(timeDelta int64) {
+						moveTimeInvoked = true
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testMoveBackward")
+		require.ErrorContains(t, err, "cannot move time backwards: invalid time delta -3024000.00000000")
+
+		assert.False(t, moveTimeInvoked)
+	})
+
+	t.Run("currentBlock", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let block = Test.currentBlock()
+                Test.assertEqual(1 as UInt64, block.height)
+                Test.assertEqual(1000.0, block.timestamp)
+
+                Test.moveTime(by: 60.0)
+
+                let movedBlock = Test.currentBlock()
+                Test.assertEqual(1 as UInt64, movedBlock.height)
+                Test.assertEqual(1060.0, movedBlock.timestamp)
+
+                // Committing a block keeps the moved time
+                Test.commitBlock()
+
+                let committedBlock = Test.currentBlock()
+                Test.assertEqual(2 as UInt64, committedBlock.height)
+                Test.assertEqual(1060.0, committedBlock.timestamp)
+            }
+        `
+
+		// Simulate the current block, with the timestamp in nanoseconds
+		block := Block{
+			Height:    1,
+			Timestamp: 1000 * int64(time.Second),
+		}
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					moveTime: func(timeDelta int64) {
+						block.Timestamp += timeDelta * int64(time.Second)
+					},
+					commitBlock: func() error {
+						block.Height++
+						return nil
+					},
+					currentBlock: func() (Block, error) {
+						return block, nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("moveTime with invalid time delta", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun testMoveTime() {
+                Test.moveTime(by: 3000)
+            }
+        `
+
+		moveTimeInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					moveTime: func(timeDelta int64) {
+						moveTimeInvoked = true
+					},
+				}
+			},
+		}
+
+		_, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		errs := checker.RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		assert.False(t, moveTimeInvoked)
+	})
+
+	t.Run("createSnapshot", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.createSnapshot(name: "adminCreated")
+            }
+        `
+
+		createSnapshotInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					createSnapshot: func(name string) error {
+						createSnapshotInvoked = true
+						assert.Equal(t, "adminCreated", name)
+
+						return nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.True(t, createSnapshotInvoked)
+	})
+
+	t.Run("createSnapshot failure", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.createSnapshot(name: "adminCreated")
+            }
+        `
+
+		createSnapshotInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					createSnapshot: func(name string) error {
+						createSnapshotInvoked = true
+						assert.Equal(t, "adminCreated", name)
+
+						return fmt.Errorf("failed to create snapshot: %s", name)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.ErrorContains(t, err, "panic: failed to create snapshot: adminCreated")
+
+		assert.True(t, createSnapshotInvoked)
+	})
+
+	t.Run("loadSnapshot", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.createSnapshot(name: "adminCreated")
+                Test.loadSnapshot(name: "adminCreated")
+            }
+        `
+
+		loadSnapshotInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					createSnapshot: func(name string) error {
+						assert.Equal(t, "adminCreated", name)
+
+						return nil
+					},
+					loadSnapshot: func(name string) error {
+						loadSnapshotInvoked = true
+						assert.Equal(t, "adminCreated", name)
+
+						return nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.True(t, loadSnapshotInvoked)
+	})
+
+	t.Run("loadSnapshot failure", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.createSnapshot(name: "adminCreated")
+                Test.loadSnapshot(name: "contractDeployed")
+            }
+        `
+
+		loadSnapshotInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					createSnapshot: func(name string) error {
+						assert.Equal(t, "adminCreated", name)
+
+						return nil
+					},
+					loadSnapshot: func(name string) error {
+						loadSnapshotInvoked = true
+						assert.Equal(t, "contractDeployed", name)
+
+						return fmt.Errorf("failed to create snapshot: %s", name)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.ErrorContains(t, err, "panic: failed to create snapshot: contractDeployed")
+
+		assert.True(t, loadSnapshotInvoked)
+	})
+
+	t.Run("snapshot and rollback", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let first = Test.snapshot()
+                let second = Test.snapshot()
+                Test.assertEqual(0 as UInt64, first)
+                Test.assertEqual(1 as UInt64, second)
+
+                Test.rollback(to: first)
+            }
+        `
+
+		var createdSnapshots []string
+		var loadedSnapshots []string
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					createSnapshot: func(name string) error {
+						createdSnapshots = append(createdSnapshots, name)
+						return nil
+					},
+					loadSnapshot: func(name string) error {
+						loadedSnapshots = append(loadedSnapshots, name)
+						return nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"Test.snapshot.0", "Test.snapshot.1"}, createdSnapshots)
+		assert.Equal(t, []string{"Test.snapshot.0"}, loadedSnapshots)
+	})
+
+	t.Run("rollback to unknown snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.snapshot()
+                Test.rollback(to: 1)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					createSnapshot: func(name string) error {
+						return nil
+					},
+					loadSnapshot: func(name string) error {
+						require.FailNow(t, "unexpected snapshot load")
+						return nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.ErrorContains(t, err, "cannot roll back: unknown snapshot ID 1")
+	})
+
+	t.Run("createAccount after rollback", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let snapshot = Test.snapshot()
+
+                let first = Test.createAccount()
+                Test.assertEqual(0x0000000000000005 as Address, first.address)
+
+                let second = Test.createAccount()
+                Test.assertEqual(0x0000000000000006 as Address, second.address)
+
+                Test.rollback(to: snapshot)
+
+                let again = Test.createAccount()
+                Test.assertEqual(first.address, again.address)
+            }
+        `
+
+		// Simulate the sequential address allocation of the emulator,
+		// which is part of the state of snapshots
+		nextAddress := uint64(5)
+		snapshots := map[string]uint64{}
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					createAccount: func() (*Account, error) {
+						address := common.Address(interpreter.NewUnmeteredUInt64Value(nextAddress).ToBigEndianBytes())
+						nextAddress++
+						return &Account{
+							PublicKey: &PublicKey{
+								PublicKey: []byte{1, 2, 3},
+								SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+							},
+							Address: address,
+						}, nil
+					},
+					createSnapshot: func(name string) error {
+						snapshots[name] = nextAddress
+						return nil
+					},
+					loadSnapshot: func(name string) error {
+						nextAddress = snapshots[name]
+						return nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("deployContract", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let err = Test.deployContract(
+                    name: "FooContract",
+                    path: "./contracts/FooContract.cdc",
+                    arguments: ["Hey, there!"]
+                )
+
+                Test.expect(err, Test.beNil())
+            }
+        `
+
+		deployContractInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					deployContract: func(
+						inter *interpreter.Interpreter,
+						name string,
+						path string,
+						arguments []interpreter.Value,
+					) error {
+						deployContractInvoked = true
+						assert.Equal(t, "FooContract", name)
+						assert.Equal(t, "./contracts/FooContract.cdc", path)
+						assert.Equal(t, 1, len(arguments))
+						argument := arguments[0].(*interpreter.StringValue)
+						assert.Equal(t, "Hey, there!", argument.Str)
+
+						return nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.True(t, deployContractInvoked)
+	})
+
+	t.Run("deployContract with failure", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let err = Test.deployContract(
+                    name: "FooContract",
+                    path: "./contracts/FooContract.cdc",
+                    arguments: ["Hey, there!"]
+                )
+
+                Test.assertEqual(
+                    "failed to deploy contract: FooContract",
+                    err!.message
+                )
+            }
+        `
+
+		deployContractInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					deployContract: func(
+						inter *interpreter.Interpreter,
+						name string,
+						path string,
+						arguments []interpreter.Value,
+					) error {
+						deployContractInvoked = true
+
+						return fmt.Errorf("failed to deploy contract: %s", name)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.True(t, deployContractInvoked)
+	})
+
+	t.Run("assertDeployFails", func(t *testing.T) {
+		t.Parallel()
+
+		newTestFramework := func() *mockedTestFramework {
+			deployed := map[string]struct{}{}
+
+			return &mockedTestFramework{
+				emulatorBackend: func() Blockchain {
+					return &mockedBlockchain{
+						deployContract: func(
+							_ *interpreter.Interpreter,
+							name string,
+							_ string,
+							_ []interpreter.Value,
+						) error {
+							// Simulate the rejection of a duplicate deployment
+							if _, ok := deployed[name]; ok {
+								return fmt.Errorf(
+									"cannot overwrite existing contract with name %q in account 0x01: contract already exists",
+									name,
+								)
+							}
+
+							deployed[name] = struct{}{}
+
+							return nil
+						},
+					}
+				},
+			}
+		}
+
+		t.Run("duplicate deployment", func(t *testing.T) {
+			t.Parallel()
+
+			const script = `
+                import Test
+
+                access(all)
+                fun test() {
+                    let err = Test.deployContract(
+                        name: "FooContract",
+                        path: "./contracts/FooContract.cdc",
+                        arguments: []
+                    )
+                    Test.expect(err, Test.beNil())
+
+                    Test.assertDeployFails(
+                        name: "FooContract",
+                        path: "./contracts/FooContract.cdc",
+                        arguments: [],
+                        errorMessage: "already exists"
+                    )
+                }
+            `
+
+			inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+			require.NoError(t, err)
+
+			_, err = inter.Invoke("test")
+			require.NoError(t, err)
+		})
+
+		t.Run("successful deployment", func(t *testing.T) {
+			t.Parallel()
+
+			const script = `
+                import Test
+
+                access(all)
+                fun test() {
+                    Test.assertDeployFails(
+                        name: "FooContract",
+                        path: "./contracts/FooContract.cdc",
+                        arguments: [],
+                        errorMessage: "already exists"
+                    )
+                }
+            `
+
+			inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+			require.NoError(t, err)
+
+			_, err = inter.Invoke("test")
+			require.ErrorContains(t, err, "deployment of contract `FooContract` unexpectedly succeeded")
+		})
+
+		t.Run("different error", func(t *testing.T) {
+			t.Parallel()
+
+			const script = `
+                import Test
+
+                access(all)
+                fun test() {
+                    Test.deployContract(
+                        name: "FooContract",
+                        path: "./contracts/FooContract.cdc",
+                        arguments: []
+                    )
+
+                    Test.assertDeployFails(
+                        name: "FooContract",
+                        path: "./contracts/FooContract.cdc",
+                        arguments: [],
+                        errorMessage: "invalid initializer arguments"
+                    )
+                }
+            `
+
+			inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+			require.NoError(t, err)
+
+			_, err = inter.Invoke("test")
+			require.ErrorContains(t, err, "the deployment error did not contain the given sub-string")
+		})
+	})
+
+	t.Run("getAccount", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let account = Test.getAccount(0x0000000000000009)
+                Test.assertEqual(0x0000000000000009 as Address, account.address)
+            }
+        `
+
+		getAccountInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					getAccount: func(address interpreter.AddressValue) (*Account, error) {
+						getAccountInvoked = true
+						assert.Equal(t, "0000000000000009", address.Hex())
+						addr := common.Address(address)
+
+						return &Account{
+							Address: addr,
+							PublicKey: &PublicKey{
+								PublicKey: []byte{1, 2, 3},
+								SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+							},
+						}, nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.True(t, getAccountInvoked)
+	})
+
+	t.Run("getAccount with failure", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let account = Test.getAccount(0x0000000000000009)
+            }
+        `
+
+		getAccountInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					getAccount: func(address interpreter.AddressValue) (*Account, error) {
+						getAccountInvoked = true
+						assert.Equal(t, "0000000000000009", address.Hex())
+
+						return nil, fmt.Errorf("failed to retrieve account with address: %s", address)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "account with address: 0x0000000000000009 was not found")
+
+		assert.True(t, getAccountInvoked)
+	})
+
+	t.Run("storagePathExists", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let account = Test.getAccount(0x0000000000000009)
+                Test.assert(Test.storagePathExists(account.address, /storage/foo))
+                Test.assert(!Test.storagePathExists(account.address, /storage/bar))
+            }
+        `
+
+		storagePathExistsInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				storedPaths := map[string]struct{}{}
+
+				return &mockedBlockchain{
+					getAccount: func(address interpreter.AddressValue) (*Account, error) {
+						// Store a value in the account during setup
+						storedPaths["foo"] = struct{}{}
+
+						return &Account{
+							Address: common.Address(address),
+							PublicKey: &PublicKey{
+								PublicKey: []byte{1, 2, 3},
+								SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+							},
+						}, nil
+					},
+					storagePathExists: func(address common.Address, path interpreter.PathValue) (bool, error) {
+						storagePathExistsInvoked = true
+						assert.Equal(t, "0000000000000009", address.Hex())
+						assert.Equal(t, common.PathDomainStorage, path.Domain)
+
+						_, ok := storedPaths[path.Identifier]
+						return ok, nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.True(t, storagePathExistsInvoked)
+	})
+
+	t.Run("storagePathExists with failure", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.storagePathExists(0x0000000000000009, /storage/foo)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					storagePathExists: func(address common.Address, path interpreter.PathValue) (bool, error) {
+						return false, fmt.Errorf("failed to read storage of account: %s", address)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to read storage of account: 0000000000000009")
+	})
+
+	t.Run("addTransaction with typed arguments", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let tx = Test.Transaction(
+                    code: "transaction(amount: UFix64, recipient: Address, memos: [String]) {}",
+                    authorizers: [],
+                    signers: [],
+                    arguments: [1.5, Address(0x01), ["hello"]]
+                )
+                Test.addTransaction(tx)
+            }
+        `
+
+		addTransactionInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					addTransaction: func(
+						inter *interpreter.Interpreter,
+						code string,
+						authorizers []common.Address,
+						signers []*Account,
+						arguments []interpreter.Value,
+					) error {
+						addTransactionInvoked = true
+						assert.Len(t, arguments, 3)
+						return nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.True(t, addTransactionInvoked)
+	})
+
+	t.Run("addTransaction with mismatched argument type", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let tx = Test.Transaction(
+                    code: "transaction(amount: UFix64, recipient: Address) {}",
+                    authorizers: [],
+                    signers: [],
+                    arguments: [1.5, "0x01"]
+                )
+                Test.addTransaction(tx)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(
+			t,
+			err,
+			"invalid transaction argument at index 1 (parameter `recipient`): expected type `Address`, got `String`",
+		)
+	})
+
+	t.Run("addTransaction with mismatched argument count", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let tx = Test.Transaction(
+                    code: "transaction(amount: UFix64) {}",
+                    authorizers: [],
+                    signers: [],
+                    arguments: []
+                )
+                Test.addTransaction(tx)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid transaction argument count: expected 1, got 0")
+	})
+
+	t.Run("addTransaction with imported argument type", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let tx = Test.Transaction(
+                    code: "import Foo from 0x01\n transaction(foo: Foo.Bar, count: Int) {}",
+                    authorizers: [],
+                    signers: [],
+                    arguments: ["not checked", 2]
+                )
+                Test.addTransaction(tx)
+            }
+        `
+
+		addTransactionInvoked := false
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					addTransaction: func(
+						inter *interpreter.Interpreter,
+						code string,
+						authorizers []common.Address,
+						signers []*Account,
+						arguments []interpreter.Value,
+					) error {
+						addTransactionInvoked = true
+						return nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.True(t, addTransactionInvoked)
+	})
+
+	t.Run("executeNextTransaction with accessed accounts", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let tx = Test.Transaction(
+                    code: "transaction { prepare(acct: &Account) {} }",
+                    authorizers: [0x01],
+                    signers: [],
+                    arguments: []
+                )
+                Test.addTransaction(tx)
+
+                let result = Test.executeNextTransaction()!
+                Test.assertEqual([Address(0x01), Address(0x02)], result.accessedAccounts)
+                Test.assert(!result.accessedAccounts.contains(0x03))
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					addTransaction: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
+					) error {
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						return &TransactionResult{
+							AccessedAccounts: []common.Address{
+								common.MustBytesToAddress([]byte{0x1}),
+								common.MustBytesToAddress([]byte{0x2}),
+							},
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("executeNextTransaction without accessed accounts", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let result = Test.executeNextTransaction()!
+                Test.assertEqual(0, result.accessedAccounts.length)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					executeTransaction: func() *TransactionResult {
+						return &TransactionResult{}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("executeNextTransaction with computation breakdown", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let result = Test.executeNextTransaction()!
+
+                let breakdown = result.computationBreakdown
+                Test.assertEqual(UInt64(3), breakdown["FunctionInvocation"]!)
+                Test.assertEqual(UInt64(10), breakdown["Loop"]!)
+                Test.assertEqual(UInt64(5), breakdown["Statement"]!)
+
+                var total: UInt64 = 0
+                for kind in breakdown.keys {
+                    total = total + breakdown[kind]!
+                }
+                Test.assertEqual(result.computationUsed, total)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					executeTransaction: func() *TransactionResult {
+						return &TransactionResult{
+							ComputationUsed: 18,
+							ComputationBreakdown: map[string]uint64{
+								common.ComputationKindFunctionInvocation.String(): 3,
+								common.ComputationKindLoop.String():               10,
+								common.ComputationKindStatement.String():          5,
+							},
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("executeNextTransaction without computation breakdown", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let result = Test.executeNextTransaction()!
+                Test.assertEqual(UInt64(0), result.computationUsed)
+                Test.assertEqual(0, result.computationBreakdown.length)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					executeTransaction: func() *TransactionResult {
+						return &TransactionResult{}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("assertMigrationIdempotent", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertMigrationIdempotent("EntitlementsMigration", snapshot: "before")
+            }
+        `
+
+		applications := 0
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					applyMigration: func(migration string, snapshot string) ([]string, error) {
+						assert.Equal(t, "EntitlementsMigration", migration)
+						assert.Equal(t, "before", snapshot)
+
+						applications++
+						if applications == 1 {
+							return []string{"0000000000000001./storage/foo"}, nil
+						}
+						return nil, nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, applications)
+	})
+
+	t.Run("assertMigrationIdempotent not idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertMigrationIdempotent("EntitlementsMigration", snapshot: "before")
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					applyMigration: func(migration string, snapshot string) ([]string, error) {
+						return []string{
+							"0000000000000001./storage/foo",
+							"0000000000000002./storage/bar",
+						}, nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+		assert.ErrorContains(
+			t,
+			err,
+			"migration is not idempotent: second application changed: "+
+				"0000000000000001./storage/foo, 0000000000000002./storage/bar",
+		)
+	})
+
+	t.Run("assertMigrationIdempotent with failure", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.assertMigrationIdempotent("UnknownMigration", snapshot: "before")
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					applyMigration: func(migration string, snapshot string) ([]string, error) {
+						return nil, fmt.Errorf("unknown migration: %s", migration)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "unknown migration: UnknownMigration")
+	})
+
+	t.Run("executeScript with failed pre-condition", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let code = "access(all) fun main(amount: Int): Int { pre { amount > 0: \"amount must be positive\" } return amount }"
+                let result = Test.executeScript(code, [0])
+
+                Test.expect(result, Test.beFailed())
+                Test.assertEqual(Test.ErrorCategory.preCondition, result.error!.category)
+                Test.assertEqual("amount must be positive", result.error!.conditionMessage!)
+                Test.assertEqual("0100000000000000000000000000000000000000000000000000000000000000:1:43", result.error!.conditionLocation!)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScript: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []interpreter.Value,
+					) *ScriptResult {
+						// Simulate the failed pre-condition, wrapped like the runtime does
+						return &ScriptResult{
+							Error: fmt.Errorf(
+								"execution failed: %w",
+								interpreter.ConditionError{
+									ConditionKind: ast.ConditionKindPre,
+									Message:       "amount must be positive",
+									LocationRange: interpreter.LocationRange{
+										Location: common.ScriptLocation{0x1},
+										HasPosition: ast.Range{
+											StartPos: ast.Position{Line: 1, Column: 43},
+											EndPos:   ast.Position{Line: 1, Column: 52},
+										},
+									},
+								},
+							),
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("executeScript with other error", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let result = Test.executeScript("access(all) fun main() { panic(\"boom\") }", [])
+
+                Test.expect(result, Test.beFailed())
+                Test.assertEqual(Test.ErrorCategory.other, result.error!.category)
+                Test.assertEqual(nil, result.error!.conditionMessage)
+                Test.assertEqual(nil, result.error!.conditionLocation)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScript: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []interpreter.Value,
+					) *ScriptResult {
+						return &ScriptResult{
+							Error: errors.New("panic: boom"),
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("executeScriptAtHeight", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let script = "access(all) fun main(): Int { return 1 }"
+
+                let first = Test.executeScriptAtHeight(script, [], height: 1)
+                Test.expect(first, Test.beSucceeded())
+                Test.assertEqual(1, first.returnValue! as! Int)
+
+                let second = Test.executeScriptAtHeight(script, [], height: 2)
+                Test.expect(second, Test.beSucceeded())
+                Test.assertEqual(2, second.returnValue! as! Int)
+            }
+        `
+
+		var heights []uint64
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScriptAtHeight: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []interpreter.Value,
+						height uint64,
+					) *ScriptResult {
+						heights = append(heights, height)
+
+						// Simulate a value which changed between the blocks
+						return &ScriptResult{
+							Value: interpreter.NewUnmeteredIntValueFromInt64(int64(height)),
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t, []uint64{1, 2}, heights)
+	})
+
+	t.Run("executeScriptAtHeight beyond latest block", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let result = Test.executeScriptAtHeight(
+                    "access(all) fun main(): Int { return 1 }",
+                    [],
+                    height: 10
+                )
+                Test.expect(result, Test.beFailed())
+                Test.assertEqual(nil, result.returnValue)
+                Test.assertEqual(
+                    "block height 10 is beyond the latest block height 3",
+                    result.error!.message
+                )
+            }
+        `
+
+		const latestHeight = 3
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScriptAtHeight: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []interpreter.Value,
+						height uint64,
+					) *ScriptResult {
+						if height > latestHeight {
+							return &ScriptResult{
+								Error: fmt.Errorf(
+									"block height %d is beyond the latest block height %d",
+									height,
+									latestHeight,
+								),
+							}
+						}
+
+						return &ScriptResult{
+							Value: interpreter.NewUnmeteredIntValueFromInt64(1),
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("executeScriptWithAccounts", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let account = Test.MockedAccount(
+                    address: 0x01,
+                    balance: 42.5,
+                    storageUsed: 1024,
+                    storage: {/storage/foo: "bar"}
+                )
+
+                let result = Test.executeScriptWithAccounts(
+                    "access(all) fun main(): UFix64 { return getAccount(0x01).balance }",
+                    [],
+                    accounts: [account]
+                )
+
+                Test.expect(result, Test.beSucceeded())
+                Test.assertEqual(42.5, result.returnValue! as! UFix64)
+            }
+        `
+
+		var mockedAccounts []*MockedAccount
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					runScriptWithAccounts: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []interpreter.Value,
+						accounts []*MockedAccount,
+					) *ScriptResult {
+						mockedAccounts = accounts
+
+						// Simulate the script reading the balance of the mocked account
+						return &ScriptResult{
+							Value: interpreter.UFix64Value(accounts[0].Balance),
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.Len(t, mockedAccounts, 1)
+
+		account := mockedAccounts[0]
+		assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), account.Address)
+		assert.Equal(t, uint64(42_50000000), account.Balance)
+		assert.Equal(t, uint64(1024), account.StorageUsed)
+
+		fooPath := interpreter.PathValue{
+			Domain:     common.PathDomainStorage,
+			Identifier: "foo",
+		}
+		require.Len(t, account.Storage, 1)
+		require.Contains(t, account.Storage, fooPath)
+		assert.Equal(t,
+			interpreter.NewUnmeteredStringValue("bar"),
+			account.Storage[fooPath],
+		)
+	})
+
+	t.Run("getContractInitCount", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let err = Test.deployContract(
+                    name: "FooContract",
+                    path: "./contracts/FooContract.cdc",
+                    arguments: []
+                )
+                Test.expect(err, Test.beNil())
+
+                let code = "import FooContract from 0x01 access(all) fun main(): Int { return 1 }"
+                Test.executeScript(code, [])
+                Test.executeScript(code, [])
+
+                Test.assertEqual(1 as UInt64, Test.getContractInitCount("FooContract"))
+                Test.assertEqual(0 as UInt64, Test.getContractInitCount("BarContract"))
+            }
+        `
+
+		initCounts := map[string]uint64{}
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					deployContract: func(
+						_ *interpreter.Interpreter,
+						name string,
+						_ string,
+						_ []interpreter.Value,
+					) error {
+						initCounts[name]++
+						return nil
+					},
+					runScript: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []interpreter.Value,
+					) *ScriptResult {
+						return &ScriptResult{
+							Value: interpreter.NewUnmeteredIntValueFromInt64(1),
+						}
+					},
+					contractInitCount: func(name string) (uint64, error) {
+						return initCounts[name], nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("getContractConstant", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun testConstant() {
+                let err = Test.deployContract(
+                    name: "Config",
+                    path: "./contracts/Config.cdc",
+                    arguments: ["1.0.0"]
+                )
+                Test.expect(err, Test.beNil())
+
+                let version = Test.getContractConstant("Config", "version")
+                Test.assertEqual("1.0.0", version as! String)
+            }
+
+            access(all)
+            fun testVariable() {
+                Test.getContractConstant("Config", "counter")
+            }
+        `
+
+		// Simulate the deployment of a contract like:
+		//
+		//   access(all) contract Config {
+		//       access(all) let version: String
+		//       access(all) var counter: Int
+		//
+		//       init(version: String) {
+		//           self.version = version
+		//           self.counter = 0
+		//       }
+		//   }
+
+		var version interpreter.Value
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					deployContract: func(
+						_ *interpreter.Interpreter,
+						name string,
+						_ string,
+						arguments []interpreter.Value,
+					) error {
+						assert.Equal(t, "Config", name)
+						require.Len(t, arguments, 1)
+						version = arguments[0]
+						return nil
+					},
+					contractConstant: func(
+						_ *interpreter.Interpreter,
+						name string,
+						fieldName string,
+					) (interpreter.Value, error) {
+						assert.Equal(t, "Config", name)
+
+						if fieldName != "version" {
+							return nil, fmt.Errorf(
+								"contract %s has no constant field %s",
+								name,
+								fieldName,
+							)
+						}
+						return version, nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testConstant")
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testVariable")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "contract Config has no constant field counter")
+	})
+
+	t.Run("getContractInitCount with failure", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                Test.getContractInitCount("FooContract")
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					contractInitCount: func(name string) (uint64, error) {
+						return 0, fmt.Errorf("cannot get init count of contract %s", name)
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "cannot get init count of contract FooContract")
+	})
+
+	t.Run("deployContractWithCost", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            access(all)
+            fun test() {
+                let result = Test.deployContractWithCost(
+                    name: "FooContract",
+                    path: "./contracts/FooContract.cdc",
+                    arguments: []
+                )
+
+                Test.expect(result, Test.beSucceeded())
+                Test.assertEqual(nil, result.error)
+                Test.assert(result.computationUsed > 0)
+                Test.assert(result.storageUsed > 0)
+                Test.assert(result.storageUsed <= 1024)
+            }
+        `
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					deployContractWithCost: func(
+						_ *interpreter.Interpreter,
+						name string,
+						path string,
+						_ []interpreter.Value,
+					) *ContractDeploymentResult {
+						assert.Equal(t, "FooContract", name)
+						assert.Equal(t, "./contracts/FooContract.cdc", path)
+
+						return &ContractDeploymentResult{
+							ComputationUsed: 12,
+							StorageUsed:     512,
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
 		require.NoError(t, err)
 	})
 
-	t.Run("expect failure with mismatching error message", func(t *testing.T) {
+	t.Run("deployContractWithCost with failure", func(t *testing.T) {
 		t.Parallel()
 
-		script := `
+		const script = `
             import Test
 
             access(all)
             fun test() {
-                let foo = Foo(answer: 42)
-                Test.expectFailure(fun(): Void {
-                    foo.correctAnswer(43)
-                }, errorMessageSubstring: "what is wrong?")
-            }
-
-            access(all)
-            struct Foo {
-                access(self) let answer: UInt8
-
-                init(answer: UInt8) {
-                    self.answer = answer
-                }
+                let result = Test.deployContractWithCost(
+                    name: "FooContract",
+                    path: "./contracts/FooContract.cdc",
+                    arguments: []
+                )
 
-                access(all)
-                fun correctAnswer(_ input: UInt8): Bool {
-                    if self.answer != input {
-                        panic("wrong answer!")
-                    }
-                    return true
-                }
+                Test.expect(result, Test.beFailed())
+                Test.assertEqual("failed to deploy contract", result.error!.message)
+                Test.assertEqual(0 as UInt64, result.storageUsed)
             }
         `
 
-		inter, err := newTestContractInterpreter(t, script)
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					deployContractWithCost: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ string,
+						_ []interpreter.Value,
+					) *ContractDeploymentResult {
+						return &ContractDeploymentResult{
+							Error:           fmt.Errorf("failed to deploy contract"),
+							ComputationUsed: 3,
+						}
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
-		require.Error(t, err)
-		assert.ErrorContains(
-			t,
-			err,
-			"Expected error message to include: \"what is wrong?\".",
-		)
+		require.NoError(t, err)
 	})
 
-	t.Run("expect failure with wrong function signature", func(t *testing.T) {
+	t.Run("deployContractWithCode", func(t *testing.T) {
 		t.Parallel()
 
-		script := `
+		const script = `
             import Test
 
             access(all)
-            fun test() {
-                let foo = Foo(answer: 42)
-                Test.expectFailure(fun(answer: UInt64): Foo {
-                    foo.correctAnswer(42)
-                    return foo
-                }, errorMessageSubstring: "wrong answer")
-            }
+            let code = "access(all) contract FooContract { init(x: Int) {} }"
 
             access(all)
-            struct Foo {
-                access(self) let answer: UInt8
+            fun test() {
+                let account = Test.getAccount(0x01)
 
-                init(answer: UInt8) {
-                    self.answer = answer
-                }
+                let err = Test.deployContractWithCode(
+                    name: "FooContract",
+                    code: code,
+                    account: account,
+                    arguments: [42]
+                )
+                Test.expect(err, Test.beNil())
 
-                access(all)
-                fun correctAnswer(_ input: UInt8): Bool {
-                    if self.answer != input {
-                        panic("wrong answer!")
-                    }
-                    return true
-                }
+                let duplicateErr = Test.deployContractWithCode(
+                    name: "FooContract",
+                    code: code,
+                    account: account,
+                    arguments: [42]
+                )
+                Test.assertEqual(
+                    "cannot overwrite existing contract with name \"FooContract\" in account 0x1",
+                    duplicateErr!.message
+                )
             }
         `
 
-		_, err := newTestContractInterpreter(t, script)
-		errs := checker.RequireCheckerErrors(t, err, 1)
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		// Simulate the contracts deployed to accounts
+		deployed := map[common.AddressLocation]string{}
+
+		testFramework := &mockedTestFramework{
+			emulatorBackend: func() Blockchain {
+				return &mockedBlockchain{
+					getAccount: func(address interpreter.AddressValue) (*Account, error) {
+						return &Account{
+							Address: common.Address(address),
+							PublicKey: &PublicKey{
+								PublicKey: []byte{1, 2, 3},
+								SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+							},
+						}, nil
+					},
+					deployContractWithCode: func(
+						_ *interpreter.Interpreter,
+						name string,
+						code string,
+						account *Account,
+						arguments []interpreter.Value,
+					) error {
+						require.Len(t, arguments, 1)
+						assert.Equal(t, interpreter.NewUnmeteredIntValueFromInt64(42), arguments[0])
+
+						location := common.NewAddressLocation(nil, account.Address, name)
+						if _, ok := deployed[location]; ok {
+							return fmt.Errorf(
+								"cannot overwrite existing contract with name %q in account %s",
+								name,
+								account.Address.ShortHexWithPrefix(),
+							)
+						}
+
+						deployed[location] = code
+						return nil
+					},
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			map[common.AddressLocation]string{
+				common.NewAddressLocation(nil, common.MustBytesToAddress([]byte{0x1}), "FooContract"): "access(all) contract FooContract { init(x: Int) {} }",
+			},
+			deployed,
+		)
 	})
 
-	t.Run("expect failure with wrong error message type", func(t *testing.T) {
+	t.Run("executeNextTransaction with authorizers", func(t *testing.T) {
 		t.Parallel()
 
-		script := `
+		const script = `
             import Test
 
             access(all)
             fun test() {
-                let foo = Foo(answer: 42)
-                Test.expectFailure(fun(): Void {
-                    foo.correctAnswer(42)
-                }, errorMessageSubstring: ["wrong answer"])
+                let tx = Test.Transaction(
+                    code: "transaction { prepare(a: &Account, b: &Account) {} }",
+                    authorizers: [0x01, 0x02],
+                    signers: [],
+                    arguments: []
+                )
+                Test.addTransaction(tx)
+
+                let result = Test.executeNextTransaction()!
+                Test.assertEqual([Address(0x01), Address(0x02)], result.authorizers)
+                Test.assertAuthorizers(result, [0x01, 0x02])
             }
 
             access(all)
-            struct Foo {
-                access(self) let answer: UInt8
-
-                init(answer: UInt8) {
-                    self.answer = answer
-                }
+            fun testMismatch() {
+                let tx = Test.Transaction(
+                    code: "transaction { prepare(a: &Account, b: &Account) {} }",
+                    authorizers: [0x01, 0x02],
+                    signers: [],
+                    arguments: []
+                )
+                Test.addTransaction(tx)
 
-                access(all)
-                fun correctAnswer(_ input: UInt8): Bool {
-                    if self.answer != input {
-                        panic("wrong answer!")
-                    }
-                    return true
-                }
+                let result = Test.executeNextTransaction()!
+                Test.assertAuthorizers(result, [0x01])
             }
         `
 
-		_, err := newTestContractInterpreter(t, script)
-		errs := checker.RequireCheckerErrors(t, err, 1)
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
-	})
-}
+		newTestFramework := func() *mockedTestFramework {
+			var authorizers []common.Address
+
+			return &mockedTestFramework{
+				emulatorBackend: func() Blockchain {
+					return &mockedBlockchain{
+						addTransaction: func(
+							_ *interpreter.Interpreter,
+							_ string,
+							txAuthorizers []common.Address,
+							_ []*Account,
+							_ []interpreter.Value,
+						) error {
+							authorizers = txAuthorizers
+							return nil
+						},
+						executeTransaction: func() *TransactionResult {
+							return &TransactionResult{
+								Authorizers: authorizers,
+							}
+						},
+					}
+				},
+			}
+		}
 
-func TestBlockchain(t *testing.T) {
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
 
-	t.Parallel()
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
 
-	t.Run("all events, empty", func(t *testing.T) {
+		inter, err = newTestContractInterpreterWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("testMismatch")
+		require.Error(t, err)
+		assert.ErrorContains(
+			t,
+			err,
+			"authorizers mismatch: expected: [0x0000000000000001], "+
+				"actual: [0x0000000000000001, 0x0000000000000002]",
+		)
+	})
+
+	t.Run("getCapabilityControllerCount", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
@@ -2230,26 +8612,85 @@ func TestBlockchain(t *testing.T) {
 
             access(all)
             fun test() {
-                let events = Test.events()
+                let account = Test.getAccount(0x01)
+                let path = /storage/foo
 
-                Test.expect(events, Test.beEmpty())
+                Test.assertEqual(0, Test.getCapabilityControllerCount(account.address, path))
+
+                let issue = Test.Transaction(
+                    code: "issue",
+                    authorizers: [account.address],
+                    signers: [],
+                    arguments: []
+                )
+                Test.expect(Test.executeTransaction(issue), Test.beSucceeded())
+                Test.expect(Test.executeTransaction(issue), Test.beSucceeded())
+
+                Test.assertEqual(2, Test.getCapabilityControllerCount(account.address, path))
+
+                let delete = Test.Transaction(
+                    code: "delete",
+                    authorizers: [account.address],
+                    signers: [],
+                    arguments: []
+                )
+                Test.expect(Test.executeTransaction(delete), Test.beSucceeded())
+
+                Test.assertEqual(1, Test.getCapabilityControllerCount(account.address, path))
+                Test.assertEqual(0, Test.getCapabilityControllerCount(account.address, /storage/bar))
             }
         `
 
-		eventsInvoked := false
+		// Simulate the capability controllers issued for /storage/foo
+		var pendingCode string
+		controllerCount := 0
+
+		fooPath := interpreter.PathValue{
+			Domain:     common.PathDomainStorage,
+			Identifier: "foo",
+		}
 
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					events: func(inter *interpreter.Interpreter, eventType interpreter.StaticType) interpreter.Value {
-						eventsInvoked = true
-						assert.Nil(t, eventType)
-						return interpreter.NewArrayValue(
-							inter,
-							interpreter.EmptyLocationRange,
-							interpreter.NewVariableSizedStaticType(inter, interpreter.PrimitiveStaticTypeAnyStruct),
-							common.Address{},
-						)
+					getAccount: func(address interpreter.AddressValue) (*Account, error) {
+						return &Account{
+							Address: common.Address(address),
+							PublicKey: &PublicKey{
+								PublicKey: []byte{1, 2, 3},
+								SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+							},
+						}, nil
+					},
+					addTransaction: func(
+						_ *interpreter.Interpreter,
+						code string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
+					) error {
+						pendingCode = code
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						switch pendingCode {
+						case "issue":
+							controllerCount++
+						case "delete":
+							controllerCount--
+						}
+						return &TransactionResult{}
+					},
+					commitBlock: func() error {
+						return nil
+					},
+					capabilityControllerCount: func(address common.Address, path interpreter.PathValue) (int, error) {
+						assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+
+						if path != fooPath {
+							return 0, nil
+						}
+						return controllerCount, nil
 					},
 				}
 			},
@@ -2260,50 +8701,96 @@ func TestBlockchain(t *testing.T) {
 
 		_, err = inter.Invoke("test")
 		require.NoError(t, err)
-
-		assert.True(t, eventsInvoked)
 	})
 
-	t.Run("typed events, empty", func(t *testing.T) {
+	t.Run("executeTransactionWithPayer", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
             import Test
 
             access(all)
-            struct Foo {}
+            fun balance(_ address: Address): UFix64 {
+                return Test.executeScript(
+                    "access(all) fun main(address: Address): UFix64 { return getAccount(address).balance }",
+                    [address]
+                ).returnValue! as! UFix64
+            }
 
             access(all)
             fun test() {
-                // 'Foo' is not an event-type.
-                // But we just need to test the API, so it doesn't really matter.
-                let typ = Type<Foo>()
+                let authorizer = Test.createAccount()
+                let payer = Test.createAccount()
+
+                let tx = Test.Transaction(
+                    code: "transaction { prepare(acct: &Account) {} }",
+                    authorizers: [authorizer.address],
+                    signers: [authorizer],
+                    arguments: []
+                )
 
-                let events = Test.eventsOfType(typ)
+                let result = Test.executeTransactionWithPayer(
+                    tx,
+                    proposer: authorizer,
+                    payer: payer
+                )
+                Test.expect(result, Test.beSucceeded())
 
-                Test.expect(events, Test.beEmpty())
+                Test.assertEqual(10.0, balance(authorizer.address))
+                Test.assertEqual(9.999, balance(payer.address))
             }
         `
 
-		eventsInvoked := false
+		const fees = 100000
+
+		balances := map[common.Address]uint64{}
+		var pendingPayer *Account
+		var proposer *Account
 
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					events: func(inter *interpreter.Interpreter, eventType interpreter.StaticType) interpreter.Value {
-						eventsInvoked = true
-						assert.NotNil(t, eventType)
-
-						require.IsType(t, &interpreter.CompositeStaticType{}, eventType)
-						compositeType := eventType.(*interpreter.CompositeStaticType)
-						assert.Equal(t, "Foo", compositeType.QualifiedIdentifier)
-
-						return interpreter.NewArrayValue(
-							inter,
-							interpreter.EmptyLocationRange,
-							interpreter.NewVariableSizedStaticType(inter, interpreter.PrimitiveStaticTypeAnyStruct),
-							common.Address{},
-						)
+					createAccount: func() (*Account, error) {
+						address := common.Address{byte(len(balances) + 1)}
+						balances[address] = 10_00000000
+						return &Account{
+							PublicKey: &PublicKey{
+								PublicKey: []byte{1, 2, 3},
+								SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+							},
+							Address: address,
+						}, nil
+					},
+					addTransactionWithPayer: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []common.Address,
+						_ []*Account,
+						transactionProposer *Account,
+						payer *Account,
+						_ []interpreter.Value,
+					) error {
+						proposer = transactionProposer
+						pendingPayer = payer
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						balances[pendingPayer.Address] -= fees
+						pendingPayer = nil
+						return &TransactionResult{}
+					},
+					commitBlock: func() error {
+						return nil
+					},
+					runScript: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						arguments []interpreter.Value,
+					) *ScriptResult {
+						address := common.Address(arguments[0].(interpreter.AddressValue))
+						return &ScriptResult{
+							Value: interpreter.NewUnmeteredUFix64Value(balances[address]),
+						}
 					},
 				}
 			},
@@ -2315,10 +8802,11 @@ func TestBlockchain(t *testing.T) {
 		_, err = inter.Invoke("test")
 		require.NoError(t, err)
 
-		assert.True(t, eventsInvoked)
+		require.NotNil(t, proposer)
+		assert.Equal(t, common.Address{1}, proposer.Address)
 	})
 
-	t.Run("reset", func(t *testing.T) {
+	t.Run("randomHistory", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
@@ -2326,18 +8814,52 @@ func TestBlockchain(t *testing.T) {
 
             access(all)
             fun test() {
-                Test.reset(to: 5)
+                let result = Test.executeScript(
+                    "access(all) fun main(): UInt16 { return UInt16(revertibleRandom<UInt8>()) + revertibleRandom<UInt16>() }",
+                    []
+                )
+                Test.expect(result, Test.beSucceeded())
+
+                let history = Test.randomHistory()
+                Test.assertEqual(2, history.length)
+                Test.assertEqual([1] as [UInt8], history[0])
+                Test.assertEqual([2, 3] as [UInt8], history[1])
             }
         `
 
-		resetInvoked := false
+		var next byte
+		recorder := &RandomHistoryRecorder{
+			Generator: testRandomGeneratorFunc(func(buffer []byte) error {
+				for i := range buffer {
+					next++
+					buffer[i] = next
+				}
+				return nil
+			}),
+		}
 
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					reset: func(height uint64) {
-						resetInvoked = true
-						assert.Equal(t, uint64(5), height)
+					runScript: func(
+						inter *interpreter.Interpreter,
+						_ string,
+						_ []interpreter.Value,
+					) *ScriptResult {
+						// Simulate the two draws of the script
+						recorder.Reset()
+						first := RevertibleRandom(recorder, inter, sema.UInt8Type, nil)
+						second := RevertibleRandom(recorder, inter, sema.UInt16Type, nil)
+
+						return &ScriptResult{
+							Value: interpreter.NewUnmeteredUInt16Value(
+								uint16(first.(interpreter.UInt8Value)) +
+									uint16(second.(interpreter.UInt16Value)),
+							),
+						}
+					},
+					randomHistory: func() [][]byte {
+						return recorder.History
 					},
 				}
 			},
@@ -2348,11 +8870,9 @@ func TestBlockchain(t *testing.T) {
 
 		_, err = inter.Invoke("test")
 		require.NoError(t, err)
-
-		assert.True(t, resetInvoked)
 	})
 
-	t.Run("reset with type mismatch for height", func(t *testing.T) {
+	t.Run("executeTransactions with indices", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
@@ -2360,51 +8880,109 @@ func TestBlockchain(t *testing.T) {
 
             access(all)
             fun test() {
-                Test.reset(to: 5.5)
+                let transactions: [Test.Transaction] = []
+                var i = 0
+                while i < 3 {
+                    transactions.append(
+                        Test.Transaction(
+                            code: "transaction { execute { log(".concat(i.toString()).concat(") } }"),
+                            authorizers: [],
+                            signers: [],
+                            arguments: []
+                        )
+                    )
+                    i = i + 1
+                }
+
+                let results = Test.executeTransactions(transactions)
+
+                Test.assertEqual(3, results.length)
+                for index, result in results {
+                    Test.assertEqual(index, result.index)
+                }
             }
         `
 
-		resetInvoked := false
+		var queue []string
+		var executed []string
+		index := 0
 
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					reset: func(height uint64) {
-						resetInvoked = true
+					addTransaction: func(
+						inter *interpreter.Interpreter,
+						code string,
+						authorizers []common.Address,
+						signers []*Account,
+						arguments []interpreter.Value,
+					) error {
+						queue = append(queue, code)
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						if len(queue) == 0 {
+							return nil
+						}
+						executed = append(executed, queue[0])
+						queue = queue[1:]
+
+						result := &TransactionResult{
+							Index: index,
+						}
+						index++
+						return result
+					},
+					commitBlock: func() error {
+						if len(queue) > 0 {
+							return errors.New("block has un-executed transactions")
+						}
+						index = 0
+						return nil
 					},
 				}
 			},
 		}
 
-		_, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
-		errs := checker.RequireCheckerErrors(t, err, 1)
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
-		assert.False(t, resetInvoked)
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]string{
+				"transaction { execute { log(0) } }",
+				"transaction { execute { log(1) } }",
+				"transaction { execute { log(2) } }",
+			},
+			executed,
+		)
 	})
 
-	t.Run("moveTime forward", func(t *testing.T) {
+	t.Run("executeNextTransaction with storage reads and writes", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
             import Test
 
             access(all)
-            fun testMoveForward() {
-                // timeDelta is the representation of 35 days,
-                // in the form of seconds.
-                let timeDelta = Fix64(35 * 24 * 60 * 60)
-                Test.moveTime(by: timeDelta)
+            fun test() {
+                let result = Test.executeNextTransaction()!
+                Test.assert(result.storageReads <= 3, message: "too many storage reads")
+                Test.assertEqual(1, result.storageWrites)
             }
         `
 
-		moveTimeInvoked := false
-
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					moveTime: func(timeDelta int64) {
-						moveTimeInvoked = true
-						assert.Equal(t, int64(3024000), timeDelta)
+					executeTransaction: func() *TransactionResult {
+						return &TransactionResult{
+							StorageReads:  2,
+							StorageWrites: 1,
+						}
 					},
 				}
 			},
@@ -2413,35 +8991,29 @@ func TestBlockchain(t *testing.T) {
 		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
 		require.NoError(t, err)
 
-		_, err = inter.Invoke("testMoveForward")
+		_, err = inter.Invoke("test")
 		require.NoError(t, err)
-
-		assert.True(t, moveTimeInvoked)
 	})
 
-	t.Run("moveTime backward", func(t *testing.T) {
+	t.Run("executeNextTransaction without storage reads and writes", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
             import Test
 
             access(all)
-            fun testMoveBackward() {
-                // timeDelta is the representation of 35 days,
-                // in the form of seconds.
-                let timeDelta = Fix64(35 * 24 * 60 * 60) * -1.0
-                Test.moveTime(by: timeDelta)
+            fun test() {
+                let result = Test.executeNextTransaction()!
+                Test.assertEqual(0, result.storageReads)
+                Test.assertEqual(0, result.storageWrites)
             }
         `
 
-		moveTimeInvoked := false
-
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					moveTime: func(timeDelta int64) {
-						moveTimeInvoked = true
-						assert.Equal(t, int64(-3024000), timeDelta)
+					executeTransaction: func() *TransactionResult {
+						return &TransactionResult{}
 					},
 				}
 			},
@@ -2450,43 +9022,114 @@ func TestBlockchain(t *testing.T) {
 		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
 		require.NoError(t, err)
 
-		_, err = inter.Invoke("testMoveBackward")
+		_, err = inter.Invoke("test")
 		require.NoError(t, err)
-
-		assert.True(t, moveTimeInvoked)
 	})
 
-	t.Run("moveTime with invalid time delta", func(t *testing.T) {
+	t.Run("forEachCapabilityController", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
             import Test
 
             access(all)
-            fun testMoveTime() {
-                Test.moveTime(by: 3000)
+            fun test() {
+                let issue = Test.Transaction(
+                    code: "issue",
+                    authorizers: [0x01],
+                    signers: [],
+                    arguments: []
+                )
+                Test.expect(Test.executeTransaction(issue), Test.beSucceeded())
+                Test.expect(Test.executeTransaction(issue), Test.beSucceeded())
+                Test.expect(Test.executeTransaction(issue), Test.beSucceeded())
+
+                let ids: [UInt64] = []
+                Test.forEachCapabilityController(
+                    0x01,
+                    /storage/foo,
+                    fun (controller: Test.CapabilityController) {
+                        Test.assertEqual(Type<&Int>(), controller.borrowType)
+                        ids.append(controller.capabilityID)
+                    }
+                )
+                let expectedIDs: [UInt64] = [1, 2, 3]
+                Test.assertEqual(expectedIDs, ids)
+
+                Test.forEachCapabilityController(
+                    0x01,
+                    /storage/bar,
+                    fun (controller: Test.CapabilityController) {
+                        panic("unexpected controller")
+                    }
+                )
             }
         `
 
-		moveTimeInvoked := false
+		var pendingCode string
+		var controllers []CapabilityController
+
+		fooPath := interpreter.PathValue{
+			Domain:     common.PathDomainStorage,
+			Identifier: "foo",
+		}
+
+		borrowType := interpreter.NewReferenceStaticType(
+			nil,
+			interpreter.UnauthorizedAccess,
+			interpreter.PrimitiveStaticTypeInt,
+		)
 
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					moveTime: func(timeDelta int64) {
-						moveTimeInvoked = true
+					addTransaction: func(
+						_ *interpreter.Interpreter,
+						code string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
+					) error {
+						pendingCode = code
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						if pendingCode == "issue" {
+							controllers = append(controllers,
+								CapabilityController{
+									CapabilityID: uint64(len(controllers) + 1),
+									BorrowType:   borrowType,
+								},
+							)
+						}
+						return &TransactionResult{}
+					},
+					commitBlock: func() error {
+						return nil
+					},
+					capabilityControllers: func(
+						address common.Address,
+						path interpreter.PathValue,
+					) ([]CapabilityController, error) {
+						assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+
+						if path != fooPath {
+							return nil, nil
+						}
+						return controllers, nil
 					},
 				}
 			},
 		}
 
-		_, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
-		errs := checker.RequireCheckerErrors(t, err, 1)
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
-		assert.False(t, moveTimeInvoked)
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
 	})
 
-	t.Run("createSnapshot", func(t *testing.T) {
+	t.Run("getCollectionSize", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
@@ -2494,21 +9137,66 @@ func TestBlockchain(t *testing.T) {
 
             access(all)
             fun test() {
-                Test.createSnapshot(name: "adminCreated")
+                Test.assertEqual(0, Test.getCollectionSize(0x01, /storage/collection))
+
+                let mint = Test.Transaction(
+                    code: "mint",
+                    authorizers: [0x01],
+                    signers: [],
+                    arguments: []
+                )
+                Test.expect(Test.executeTransaction(mint), Test.beSucceeded())
+                Test.expect(Test.executeTransaction(mint), Test.beSucceeded())
+                Test.expect(Test.executeTransaction(mint), Test.beSucceeded())
+
+                Test.assertEqual(3, Test.getCollectionSize(0x01, /storage/collection))
+            }
+
+            access(all)
+            fun testNotCollection() {
+                Test.getCollectionSize(0x01, /storage/vault)
             }
         `
 
-		createSnapshotInvoked := false
+		// Simulate the NFTs minted into the collection stored at /storage/collection
+		var pendingCode string
+		nftCount := 0
+
+		collectionPath := interpreter.PathValue{
+			Domain:     common.PathDomainStorage,
+			Identifier: "collection",
+		}
 
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					createSnapshot: func(name string) error {
-						createSnapshotInvoked = true
-						assert.Equal(t, "adminCreated", name)
-
+					addTransaction: func(
+						_ *interpreter.Interpreter,
+						code string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
+					) error {
+						pendingCode = code
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						if pendingCode == "mint" {
+							nftCount++
+						}
+						return &TransactionResult{}
+					},
+					commitBlock: func() error {
 						return nil
 					},
+					collectionSize: func(address common.Address, path interpreter.PathValue) (int, error) {
+						assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+
+						if path != collectionPath {
+							return 0, errors.New("value stored at /storage/vault is not a collection")
+						}
+						return nftCount, nil
+					},
 				}
 			},
 		}
@@ -2519,10 +9207,11 @@ func TestBlockchain(t *testing.T) {
 		_, err = inter.Invoke("test")
 		require.NoError(t, err)
 
-		assert.True(t, createSnapshotInvoked)
+		_, err = inter.Invoke("testNotCollection")
+		require.ErrorContains(t, err, "value stored at /storage/vault is not a collection")
 	})
 
-	t.Run("createSnapshot failure", func(t *testing.T) {
+	t.Run("findDeprecatedValues", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
@@ -2530,20 +9219,70 @@ func TestBlockchain(t *testing.T) {
 
             access(all)
             fun test() {
-                Test.createSnapshot(name: "adminCreated")
+                let deprecatedValues = Test.findDeprecatedValues(0x01)
+                Test.assertEqual(4, deprecatedValues.length)
+
+                Test.assertEqual("/storage/capability", deprecatedValues[0].path)
+                Test.assertEqual("un-migrated path capability", deprecatedValues[0].reason)
+
+                Test.assertEqual("/storage/link", deprecatedValues[1].path)
+                Test.assertEqual("un-migrated link", deprecatedValues[1].reason)
+
+                Test.assertEqual("/storage/nested", deprecatedValues[2].path)
+                Test.assertEqual("deprecated type: AuthAccount", deprecatedValues[2].reason)
+
+                Test.assertEqual("/storage/restricted", deprecatedValues[3].path)
+                Test.assertEqual("legacy restricted type: AnyResource{}", deprecatedValues[3].reason)
             }
         `
 
-		createSnapshotInvoked := false
-
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					createSnapshot: func(name string) error {
-						createSnapshotInvoked = true
-						assert.Equal(t, "adminCreated", name)
+					storedValues: func(
+						inter *interpreter.Interpreter,
+						address common.Address,
+					) (map[string]interpreter.Value, error) {
+						assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+
+						borrowType := interpreter.NewReferenceStaticType(
+							nil,
+							interpreter.UnauthorizedAccess,
+							interpreter.PrimitiveStaticTypeInt,
+						)
 
-						return fmt.Errorf("failed to create snapshot: %s", name)
+						targetPath := interpreter.PathValue{
+							Domain:     common.PathDomainStorage,
+							Identifier: "int",
+						}
+
+						return map[string]interpreter.Value{
+							"/storage/int": interpreter.NewUnmeteredIntValueFromInt64(42),
+							"/storage/capability": interpreter.NewUnmeteredPathCapabilityValue( //nolint:staticcheck
+								borrowType,
+								interpreter.AddressValue(address),
+								targetPath,
+							),
+							"/storage/link": interpreter.PathLinkValue{ //nolint:staticcheck
+								Type:       borrowType,
+								TargetPath: targetPath,
+							},
+							"/storage/nested": interpreter.NewArrayValue(
+								inter,
+								interpreter.EmptyLocationRange,
+								&interpreter.VariableSizedStaticType{
+									Type: interpreter.PrimitiveStaticTypeMetaType,
+								},
+								common.ZeroAddress,
+								interpreter.NewUnmeteredTypeValue(interpreter.PrimitiveStaticTypeInt),
+								interpreter.NewUnmeteredTypeValue(interpreter.PrimitiveStaticTypeAuthAccount), //nolint:staticcheck
+							),
+							"/storage/restricted": interpreter.NewUnmeteredTypeValue(
+								&interpreter.IntersectionStaticType{
+									LegacyType: interpreter.PrimitiveStaticTypeAnyResource,
+								},
+							),
+						}, nil
 					},
 				}
 			},
@@ -2553,12 +9292,10 @@ func TestBlockchain(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
-		require.ErrorContains(t, err, "panic: failed to create snapshot: adminCreated")
-
-		assert.True(t, createSnapshotInvoked)
+		require.NoError(t, err)
 	})
 
-	t.Run("loadSnapshot", func(t *testing.T) {
+	t.Run("assertNoDanglingCapabilities", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
@@ -2566,27 +9303,96 @@ func TestBlockchain(t *testing.T) {
 
             access(all)
             fun test() {
-                Test.createSnapshot(name: "adminCreated")
-                Test.loadSnapshot(name: "adminCreated")
+                Test.assertNoDanglingCapabilities(0x01)
+
+                let migrate = Test.Transaction(
+                    code: "migrate",
+                    authorizers: [0x01],
+                    signers: [],
+                    arguments: []
+                )
+                Test.expect(Test.executeTransaction(migrate), Test.beSucceeded())
+
+                Test.assertNoDanglingCapabilities(0x01)
             }
         `
 
-		loadSnapshotInvoked := false
+		// Simulate a migration which moves the value stored at /storage/bar
+		// to /storage/baz, without retargeting the capability controllers
+		var pendingCode string
+
+		storedPaths := map[string]struct{}{
+			"foo": {},
+			"bar": {},
+		}
+
+		borrowType := interpreter.NewReferenceStaticType(
+			nil,
+			interpreter.UnauthorizedAccess,
+			interpreter.PrimitiveStaticTypeInt,
+		)
+
+		controllers := []CapabilityController{
+			{
+				CapabilityID: 1,
+				BorrowType:   borrowType,
+				TargetPath: interpreter.PathValue{
+					Domain:     common.PathDomainStorage,
+					Identifier: "foo",
+				},
+			},
+			{
+				CapabilityID: 2,
+				BorrowType:   borrowType,
+				TargetPath: interpreter.PathValue{
+					Domain:     common.PathDomainStorage,
+					Identifier: "bar",
+				},
+			},
+			{
+				CapabilityID: 3,
+				BorrowType:   borrowType,
+				TargetPath: interpreter.PathValue{
+					Domain:     common.PathDomainStorage,
+					Identifier: "bar",
+				},
+			},
+		}
 
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					createSnapshot: func(name string) error {
-						assert.Equal(t, "adminCreated", name)
-
+					addTransaction: func(
+						_ *interpreter.Interpreter,
+						code string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
+					) error {
+						pendingCode = code
 						return nil
 					},
-					loadSnapshot: func(name string) error {
-						loadSnapshotInvoked = true
-						assert.Equal(t, "adminCreated", name)
-
+					executeTransaction: func() *TransactionResult {
+						if pendingCode == "migrate" {
+							delete(storedPaths, "bar")
+							storedPaths["baz"] = struct{}{}
+						}
+						return &TransactionResult{}
+					},
+					commitBlock: func() error {
 						return nil
 					},
+					allCapabilityControllers: func(address common.Address) ([]CapabilityController, error) {
+						assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+
+						return controllers, nil
+					},
+					storagePathExists: func(address common.Address, path interpreter.PathValue) (bool, error) {
+						assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+
+						_, ok := storedPaths[path.Identifier]
+						return ok, nil
+					},
 				}
 			},
 		}
@@ -2595,39 +9401,86 @@ func TestBlockchain(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
-		require.NoError(t, err)
-
-		assert.True(t, loadSnapshotInvoked)
+		require.ErrorContains(t, err, "dangling capabilities: 2 (/storage/bar), 3 (/storage/bar)")
 	})
 
-	t.Run("loadSnapshot failure", func(t *testing.T) {
+	t.Run("transaction result events", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
             import Test
 
+            access(all)
+            event Withdrawn(amount: UFix64)
+
+            access(all)
+            event Deposited(amount: UFix64)
+
             access(all)
             fun test() {
-                Test.createSnapshot(name: "adminCreated")
-                Test.loadSnapshot(name: "contractDeployed")
+                let tx = Test.Transaction(
+                    code: "transfer",
+                    authorizers: [],
+                    signers: [],
+                    arguments: []
+                )
+                let result = Test.executeTransaction(tx)
+                Test.expect(result, Test.beSucceeded())
+
+                Test.assertEqual(2, result.events.length)
+
+                let withdrawn = result.events[0] as! Withdrawn
+                Test.assertEqual(10.0, withdrawn.amount)
+
+                let deposited = result.events[1] as! Deposited
+                Test.assertEqual(10.0, deposited.amount)
             }
         `
 
-		loadSnapshotInvoked := false
+		var pendingInter *interpreter.Interpreter
+
+		newEvent := func(inter *interpreter.Interpreter, identifier string) interpreter.Value {
+			return interpreter.NewCompositeValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				utils.TestLocation,
+				identifier,
+				common.CompositeKindEvent,
+				[]interpreter.CompositeField{
+					interpreter.NewUnmeteredCompositeField(
+						"amount",
+						interpreter.NewUnmeteredUFix64Value(10*sema.Fix64Factor),
+					),
+				},
+				common.ZeroAddress,
+			)
+		}
 
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					createSnapshot: func(name string) error {
-						assert.Equal(t, "adminCreated", name)
-
+					addTransaction: func(
+						inter *interpreter.Interpreter,
+						_ string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
+					) error {
+						pendingInter = inter
 						return nil
 					},
-					loadSnapshot: func(name string) error {
-						loadSnapshotInvoked = true
-						assert.Equal(t, "contractDeployed", name)
-
-						return fmt.Errorf("failed to create snapshot: %s", name)
+					executeTransaction: func() *TransactionResult {
+						// Simulate an event emitted by a contract called by the transaction,
+						// followed by an event emitted by the transaction body
+						return &TransactionResult{
+							Events: []interpreter.Value{
+								newEvent(pendingInter, "Withdrawn"),
+								newEvent(pendingInter, "Deposited"),
+							},
+						}
+					},
+					commitBlock: func() error {
+						return nil
 					},
 				}
 			},
@@ -2637,12 +9490,10 @@ func TestBlockchain(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
-		require.ErrorContains(t, err, "panic: failed to create snapshot: contractDeployed")
-
-		assert.True(t, loadSnapshotInvoked)
+		require.NoError(t, err)
 	})
 
-	t.Run("deployContract", func(t *testing.T) {
+	t.Run("createAccountWithKeys", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
@@ -2650,34 +9501,87 @@ func TestBlockchain(t *testing.T) {
 
             access(all)
             fun test() {
-                let err = Test.deployContract(
-                    name: "FooContract",
-                    path: "./contracts/FooContract.cdc",
-                    arguments: ["Hey, there!"]
+                let multiSig = Test.createAccountWithKeys(weights: [500.0, 500.0])
+                let partial = Test.createAccountWithKeys(weights: [500.0])
+
+                let code = "transaction { prepare(acct: &Account) {} }"
+
+                let result = Test.executeTransaction(
+                    Test.Transaction(
+                        code: code,
+                        authorizers: [multiSig.address],
+                        signers: [multiSig],
+                        arguments: []
+                    )
+                )
+                Test.expect(result, Test.beSucceeded())
+
+                let failedResult = Test.executeTransaction(
+                    Test.Transaction(
+                        code: code,
+                        authorizers: [partial.address],
+                        signers: [partial],
+                        arguments: []
+                    )
+                )
+                Test.expect(failedResult, Test.beFailed())
+                Test.assertError(
+                    failedResult,
+                    errorMessage: "insufficient key weight: 500"
                 )
-
-                Test.expect(err, Test.beNil())
             }
         `
 
-		deployContractInvoked := false
+		keyWeights := map[common.Address][]int{}
+		var pendingSigners []*Account
 
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					deployContract: func(
-						inter *interpreter.Interpreter,
-						name string,
-						path string,
-						arguments []interpreter.Value,
+					createAccountWithKeys: func(weights []int) (*Account, error) {
+						address := common.Address{byte(len(keyWeights) + 1)}
+						keyWeights[address] = weights
+						return &Account{
+							PublicKey: &PublicKey{
+								PublicKey: []byte{1, 2, 3},
+								SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+							},
+							Address: address,
+						}, nil
+					},
+					addTransaction: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []common.Address,
+						signers []*Account,
+						_ []interpreter.Value,
 					) error {
-						deployContractInvoked = true
-						assert.Equal(t, "FooContract", name)
-						assert.Equal(t, "./contracts/FooContract.cdc", path)
-						assert.Equal(t, 1, len(arguments))
-						argument := arguments[0].(*interpreter.StringValue)
-						assert.Equal(t, "Hey, there!", argument.Str)
-
+						pendingSigners = signers
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						signers := pendingSigners
+						pendingSigners = nil
+
+						for _, signer := range signers {
+							totalWeight := 0
+							for _, weight := range keyWeights[signer.Address] {
+								totalWeight += weight
+							}
+							if totalWeight < 1000 {
+								return &TransactionResult{
+									Error: fmt.Errorf(
+										"authorization failed for account %s: insufficient key weight: %d",
+										signer.Address,
+										totalWeight,
+									),
+								}
+							}
+						}
+
+						return &TransactionResult{}
+					},
+					commitBlock: func() error {
 						return nil
 					},
 				}
@@ -2690,10 +9594,17 @@ func TestBlockchain(t *testing.T) {
 		_, err = inter.Invoke("test")
 		require.NoError(t, err)
 
-		assert.True(t, deployContractInvoked)
+		assert.Equal(
+			t,
+			map[common.Address][]int{
+				{1}: {500, 500},
+				{2}: {500},
+			},
+			keyWeights,
+		)
 	})
 
-	t.Run("deployContract with failure", func(t *testing.T) {
+	t.Run("computation used and limit", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
@@ -2701,33 +9612,77 @@ func TestBlockchain(t *testing.T) {
 
             access(all)
             fun test() {
-                let err = Test.deployContract(
-                    name: "FooContract",
-                    path: "./contracts/FooContract.cdc",
-                    arguments: ["Hey, there!"]
+                let scriptResult = Test.executeScript("access(all) fun main() {}", [])
+                Test.expect(scriptResult, Test.beSucceeded())
+                Test.assertEqual(42 as UInt64, scriptResult.computationUsed)
+
+                let tx = Test.Transaction(
+                    code: "transaction { execute { var i = 0; while i < 500 { i = i + 1 } } }",
+                    authorizers: [],
+                    signers: [],
+                    arguments: []
                 )
 
-                Test.assertEqual(
-                    "failed to deploy contract: FooContract",
-                    err!.message
+                let result = Test.executeTransaction(tx)
+                Test.expect(result, Test.beSucceeded())
+                Test.assertEqual(500 as UInt64, result.computationUsed)
+
+                Test.setComputationLimit(100)
+
+                let limitedResult = Test.executeTransaction(tx)
+                Test.expect(limitedResult, Test.beFailed())
+                Test.assertError(
+                    limitedResult,
+                    errorMessage: "computation exceeds limit (100)"
                 )
             }
         `
 
-		deployContractInvoked := false
+		const transactionComputation = 500
+
+		var computationLimit uint64 = 9999
 
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					deployContract: func(
-						inter *interpreter.Interpreter,
-						name string,
-						path string,
-						arguments []interpreter.Value,
+					runScript: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []interpreter.Value,
+					) *ScriptResult {
+						return &ScriptResult{
+							Value:           interpreter.Void,
+							ComputationUsed: 42,
+						}
+					},
+					addTransaction: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
 					) error {
-						deployContractInvoked = true
-
-						return fmt.Errorf("failed to deploy contract: %s", name)
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						if transactionComputation > computationLimit {
+							return &TransactionResult{
+								Error: fmt.Errorf(
+									"computation exceeds limit (%d)",
+									computationLimit,
+								),
+								ComputationUsed: computationLimit,
+							}
+						}
+						return &TransactionResult{
+							ComputationUsed: transactionComputation,
+						}
+					},
+					commitBlock: func() error {
+						return nil
+					},
+					setComputationLimit: func(limit uint64) {
+						computationLimit = limit
 					},
 				}
 			},
@@ -2739,54 +9694,127 @@ func TestBlockchain(t *testing.T) {
 		_, err = inter.Invoke("test")
 		require.NoError(t, err)
 
-		assert.True(t, deployContractInvoked)
+		assert.Equal(t, uint64(100), computationLimit)
 	})
 
-	t.Run("getAccount", func(t *testing.T) {
+	t.Run("assertCheaperThan", func(t *testing.T) {
 		t.Parallel()
 
-		const script = `
-            import Test
-
+		const naiveScript = `
             access(all)
-            fun test() {
-                let account = Test.getAccount(0x0000000000000009)
-                Test.assertEqual(0x0000000000000009 as Address, account.address)
+            fun main(): Int {
+                var sum = 0
+                var i = 1
+                while i <= 100 {
+                    sum = sum + i
+                    i = i + 1
+                }
+                return sum
             }
         `
 
-		getAccountInvoked := false
-
-		testFramework := &mockedTestFramework{
-			emulatorBackend: func() Blockchain {
-				return &mockedBlockchain{
-					getAccount: func(address interpreter.AddressValue) (*Account, error) {
-						getAccountInvoked = true
-						assert.Equal(t, "0000000000000009", address.Hex())
-						addr := common.Address(address)
+		const optimizedScript = `
+            access(all)
+            fun main(): Int {
+                return 100 * 101 / 2
+            }
+        `
 
-						return &Account{
-							Address: addr,
-							PublicKey: &PublicKey{
-								PublicKey: []byte{1, 2, 3},
-								SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
-							},
-						}, nil
-					},
-				}
-			},
+		newTestFramework := func() *mockedTestFramework {
+			return &mockedTestFramework{
+				emulatorBackend: func() Blockchain {
+					return &mockedBlockchain{
+						runScript: func(
+							_ *interpreter.Interpreter,
+							code string,
+							_ []interpreter.Value,
+						) *ScriptResult {
+							// Simulate metering: the naive script iterates 100 times
+							var computationUsed uint64
+							switch code {
+							case naiveScript:
+								computationUsed = 105
+							case optimizedScript:
+								computationUsed = 3
+							default:
+								return &ScriptResult{
+									Error: errors.New("unknown script"),
+								}
+							}
+
+							return &ScriptResult{
+								Value:           interpreter.NewUnmeteredIntValueFromInt64(5050),
+								ComputationUsed: computationUsed,
+							}
+						},
+					}
+				},
+			}
 		}
 
-		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
-		require.NoError(t, err)
-
-		_, err = inter.Invoke("test")
-		require.NoError(t, err)
+		newScript := func(script, otherScript string) string {
+			return fmt.Sprintf(
+				`
+                  import Test
+
+                  access(all)
+                  fun test() {
+                      Test.assertCheaperThan(%q, %q)
+                  }
+                `,
+				script,
+				otherScript,
+			)
+		}
 
-		assert.True(t, getAccountInvoked)
+		t.Run("cheaper", func(t *testing.T) {
+			t.Parallel()
+
+			inter, err := newTestContractInterpreterWithTestFramework(
+				t,
+				newScript(optimizedScript, naiveScript),
+				newTestFramework(),
+			)
+			require.NoError(t, err)
+
+			_, err = inter.Invoke("test")
+			require.NoError(t, err)
+		})
+
+		t.Run("not cheaper", func(t *testing.T) {
+			t.Parallel()
+
+			inter, err := newTestContractInterpreterWithTestFramework(
+				t,
+				newScript(naiveScript, optimizedScript),
+				newTestFramework(),
+			)
+			require.NoError(t, err)
+
+			_, err = inter.Invoke("test")
+			require.ErrorContains(
+				t,
+				err,
+				"script is not cheaper: computation used: 105, computation used by other script: 3",
+			)
+		})
+
+		t.Run("failed script", func(t *testing.T) {
+			t.Parallel()
+
+			inter, err := newTestContractInterpreterWithTestFramework(
+				t,
+				newScript(optimizedScript, "invalid"),
+				newTestFramework(),
+			)
+			require.NoError(t, err)
+
+			_, err = inter.Invoke("test")
+			require.ErrorContains(t, err, "other script failed: unknown script")
+		})
 	})
 
-	t.Run("getAccount with failure", func(t *testing.T) {
+	t.Run("logs", func(t *testing.T) {
 		t.Parallel()
 
 		const script = `
@@ -2794,20 +9822,49 @@ func TestBlockchain(t *testing.T) {
 
             access(all)
             fun test() {
-                let account = Test.getAccount(0x0000000000000009)
+                let tx = Test.Transaction(
+                    code: "import FooContract from 0x01; transaction { execute { log(\"transaction\"); FooContract.hello() } }",
+                    authorizers: [],
+                    signers: [],
+                    arguments: []
+                )
+
+                let result = Test.executeTransaction(tx)
+                Test.expect(result, Test.beSucceeded())
+
+                Test.assertEqual(["\"transaction\"", "\"hello from FooContract\""], Test.logs())
             }
         `
 
-		getAccountInvoked := false
+		var logs []string
 
 		testFramework := &mockedTestFramework{
 			emulatorBackend: func() Blockchain {
 				return &mockedBlockchain{
-					getAccount: func(address interpreter.AddressValue) (*Account, error) {
-						getAccountInvoked = true
-						assert.Equal(t, "0000000000000009", address.Hex())
-
-						return nil, fmt.Errorf("failed to retrieve account with address: %s", address)
+					addTransaction: func(
+						_ *interpreter.Interpreter,
+						_ string,
+						_ []common.Address,
+						_ []*Account,
+						_ []interpreter.Value,
+					) error {
+						return nil
+					},
+					executeTransaction: func() *TransactionResult {
+						// The backend captures the logs of the transaction,
+						// including the logs of imported contracts
+						logs = append(
+							logs,
+							`"transaction"`,
+							`"hello from FooContract"`,
+						)
+						return &TransactionResult{}
+					},
+					commitBlock: func() error {
+						return nil
+					},
+					logs: func() []string {
+						return logs
 					},
 				}
 			},
@@ -2817,10 +9874,7 @@ func TestBlockchain(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = inter.Invoke("test")
-		require.Error(t, err)
-		assert.ErrorContains(t, err, "account with address: 0x0000000000000009 was not found")
-
-		assert.True(t, getAccountInvoked)
+		require.NoError(t, err)
 	})
 
 	// TODO: Add more tests for the remaining functions.
@@ -2867,9 +9921,202 @@ func TestBlockchainAccount(t *testing.T) {
 	})
 }
 
+func TestBlockchainConcurrentUse(t *testing.T) {
+
+	t.Parallel()
+
+	// Each test gets its own interpreter and blockchain,
+	// so tests can run concurrently, e.g. when run with -race
+
+	const script = `
+        import Test
+
+        access(all)
+        fun test(expectedAddress: Address) {
+            let account = Test.createAccount()
+            Test.assertEqual(expectedAddress, account.address)
+            Test.assertEqual([1, 2, 3] as [UInt8], account.publicKey.publicKey)
+
+            let snapshot = Test.snapshot()
+
+            let tx = Test.Transaction(
+                code: "transfer",
+                authorizers: [account.address],
+                signers: [account],
+                arguments: []
+            )
+            Test.expect(Test.executeTransaction(tx), Test.beSucceeded())
+
+            Test.rollback(to: snapshot)
+        }
+    `
+
+	const concurrency = 8
+
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrency)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		address := common.MustBytesToAddress([]byte{byte(i + 1)})
+
+		go func() {
+			defer wg.Done()
+
+			snapshots := map[string]struct{}{}
+
+			testFramework := &mockedTestFramework{
+				emulatorBackend: func() Blockchain {
+					return &mockedBlockchain{
+						createAccount: func() (*Account, error) {
+							return &Account{
+								PublicKey: &PublicKey{
+									PublicKey: []byte{1, 2, 3},
+									SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+								},
+								Address: address,
+							}, nil
+						},
+						addTransaction: func(
+							_ *interpreter.Interpreter,
+							_ string,
+							authorizers []common.Address,
+							signers []*Account,
+							_ []interpreter.Value,
+						) error {
+							assert.Equal(t, []common.Address{address}, authorizers)
+							assert.Len(t, signers, 1)
+							return nil
+						},
+						executeTransaction: func() *TransactionResult {
+							return &TransactionResult{}
+						},
+						commitBlock: func() error {
+							return nil
+						},
+						createSnapshot: func(name string) error {
+							snapshots[name] = struct{}{}
+							return nil
+						},
+						loadSnapshot: func(name string) error {
+							assert.Contains(t, snapshots, name)
+							return nil
+						},
+					}
+				},
+			}
+
+			inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			_, err = inter.Invoke("test", interpreter.AddressValue(address))
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func TestResourceLeakWarning(t *testing.T) {
+
+	t.Parallel()
+
+	// The checker rejects the leaking branch, but test runners may still run the program,
+	// so the leak is detected at runtime and reported as a warning
+
+	const code = `
+      access(all)
+      resource R {}
+
+      access(all)
+      fun test(_ consume: Bool) {
+          let r <- create R()
+          if consume {
+              destroy r
+          }
+      }
+    `
+
+	program, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+	require.NoError(t, err)
+
+	checker, err := sema.NewChecker(
+		program,
+		utils.TestLocation,
+		nil,
+		&sema.Config{
+			AccessCheckMode: sema.AccessCheckModeStrict,
+		},
+	)
+	require.NoError(t, err)
+
+	err = checker.Check()
+	var checkerErr *sema.CheckerError
+	require.ErrorAs(t, err, &checkerErr)
+	require.Len(t, checkerErr.Errors, 1)
+	require.IsType(t, &sema.ResourceLossError{}, checkerErr.Errors[0])
+
+	var warnings []error
+
+	testFramework := &mockedTestFramework{
+		reportWarning: func(warning error) {
+			warnings = append(warnings, warning)
+		},
+	}
+
+	inter, err := interpreter.NewInterpreter(
+		interpreter.ProgramFromChecker(checker),
+		checker.Location,
+		&interpreter.Config{
+			Storage:        interpreter.NewInMemoryStorage(nil),
+			OnResourceLeak: NewTestInterpreterResourceLeakHandler(testFramework),
+			UUIDHandler: func() (uint64, error) {
+				return 0, nil
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	err = inter.Interpret()
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test", interpreter.TrueValue)
+	require.NoError(t, err)
+
+	assert.Empty(t, warnings)
+
+	_, err = inter.Invoke("test", interpreter.FalseValue)
+	require.NoError(t, err)
+
+	require.Len(t, warnings, 1)
+
+	var warning ResourceLeakWarning
+	require.ErrorAs(t, warnings[0], &warning)
+	assert.Equal(t, "r", warning.Identifier)
+	assert.EqualError(
+		t,
+		warning,
+		"resource `r` of type `R` was neither moved nor destroyed",
+	)
+}
+
 type mockedTestFramework struct {
 	emulatorBackend func() Blockchain
 	readFile        func(s string) (string, error)
+	writeFile       func(path string, content string) error
+	updateGolden    bool
+	decodeJSONValue func(inter *interpreter.Interpreter, data []byte) (interpreter.Value, error)
+	encodeJSONValue func(inter *interpreter.Interpreter, value interpreter.Value) ([]byte, error)
+	reportWarning   func(warning error)
 }
 
 var _ TestFramework = &mockedTestFramework{}
@@ -2890,22 +10137,79 @@ func (m mockedTestFramework) ReadFile(fileName string) (string, error) {
 	return m.readFile(fileName)
 }
 
+func (m mockedTestFramework) WriteFile(path string, content string) error {
+	if m.writeFile == nil {
+		panic("'WriteFile' is not implemented")
+	}
+
+	return m.writeFile(path, content)
+}
+
+func (m mockedTestFramework) UpdateGoldenFiles() bool {
+	return m.updateGolden
+}
+
+func (m mockedTestFramework) DecodeJSONValue(inter *interpreter.Interpreter, data []byte) (interpreter.Value, error) {
+	if m.decodeJSONValue == nil {
+		panic("'DecodeJSONValue' is not implemented")
+	}
+
+	return m.decodeJSONValue(inter, data)
+}
+
+func (m mockedTestFramework) EncodeJSONValue(inter *interpreter.Interpreter, value interpreter.Value) ([]byte, error) {
+	if m.encodeJSONValue == nil {
+		panic("'EncodeJSONValue' is not implemented")
+	}
+
+	return m.encodeJSONValue(inter, value)
+}
+
+func (m mockedTestFramework) ReportWarning(warning error) {
+	if m.reportWarning == nil {
+		panic("'ReportWarning' is not implemented")
+	}
+
+	m.reportWarning(warning)
+}
+
 // mockedBlockchain is the implementation of `Blockchain` for testing purposes.
 type mockedBlockchain struct {
-	runScript          func(inter *interpreter.Interpreter, code string, arguments []interpreter.Value)
-	createAccount      func() (*Account, error)
-	getAccount         func(interpreter.AddressValue) (*Account, error)
-	addTransaction     func(inter *interpreter.Interpreter, code string, authorizers []common.Address, signers []*Account, arguments []interpreter.Value) error
-	executeTransaction func() *TransactionResult
-	commitBlock        func() error
-	deployContract     func(inter *interpreter.Interpreter, name string, path string, arguments []interpreter.Value) error
-	logs               func() []string
-	serviceAccount     func() (*Account, error)
-	events             func(inter *interpreter.Interpreter, eventType interpreter.StaticType) interpreter.Value
-	reset              func(uint64)
-	moveTime           func(int64)
-	createSnapshot     func(string) error
-	loadSnapshot       func(string) error
+	runScript                 func(inter *interpreter.Interpreter, code string, arguments []interpreter.Value) *ScriptResult
+	runScriptAtHeight         func(inter *interpreter.Interpreter, code string, arguments []interpreter.Value, height uint64) *ScriptResult
+	createAccount             func() (*Account, error)
+	getAccount                func(interpreter.AddressValue) (*Account, error)
+	addTransaction            func(inter *interpreter.Interpreter, code string, authorizers []common.Address, signers []*Account, arguments []interpreter.Value) error
+	executeTransaction        func() *TransactionResult
+	commitBlock               func() error
+	deployContract            func(inter *interpreter.Interpreter, name string, path string, arguments []interpreter.Value) error
+	logs                      func() []string
+	serviceAccount            func() (*Account, error)
+	events                    func(inter *interpreter.Interpreter, eventType interpreter.StaticType) interpreter.Value
+	reset                     func(uint64)
+	moveTime                  func(int64)
+	createSnapshot            func(string) error
+	loadSnapshot              func(string) error
+	storagePathExists         func(common.Address, interpreter.PathValue) (bool, error)
+	applyMigration            func(migration string, snapshot string) ([]string, error)
+	diffSnapshots             func(inter *interpreter.Interpreter, before string, after string) (map[string]interpreter.Value, error)
+	getContractType           func(name string) (interpreter.StaticType, error)
+	contractInitCount         func(name string) (uint64, error)
+	contractConstant          func(inter *interpreter.Interpreter, name string, fieldName string) (interpreter.Value, error)
+	getAccountContractNames   func(address common.Address) ([]string, error)
+	deployContractWithCost    func(inter *interpreter.Interpreter, name string, path string, arguments []interpreter.Value) *ContractDeploymentResult
+	deployContractWithCode    func(inter *interpreter.Interpreter, name string, code string, account *Account, arguments []interpreter.Value) error
+	runScriptWithAccounts     func(inter *interpreter.Interpreter, code string, arguments []interpreter.Value, accounts []*MockedAccount) *ScriptResult
+	capabilityControllerCount func(address common.Address, path interpreter.PathValue) (int, error)
+	capabilityControllers     func(address common.Address, path interpreter.PathValue) ([]CapabilityController, error)
+	collectionSize            func(address common.Address, path interpreter.PathValue) (int, error)
+	addTransactionWithPayer   func(inter *interpreter.Interpreter, code string, authorizers []common.Address, signers []*Account, proposer *Account, payer *Account, arguments []interpreter.Value) error
+	randomHistory             func() [][]byte
+	currentBlock              func() (Block, error)
+	createAccountWithKeys     func(keyWeights []int) (*Account, error)
+	setComputationLimit       func(limit uint64)
+	allCapabilityControllers  func(address common.Address) ([]CapabilityController, error)
+	storedValues              func(inter *interpreter.Interpreter, address common.Address) (map[string]interpreter.Value, error)
 }
 
 var _ Blockchain = &mockedBlockchain{}
@@ -2919,7 +10223,20 @@ func (m mockedBlockchain) RunScript(
 		panic("'RunScript' is not implemented")
 	}
 
-	return m.RunScript(inter, code, arguments)
+	return m.runScript(inter, code, arguments)
+}
+
+func (m mockedBlockchain) RunScriptAtHeight(
+	inter *interpreter.Interpreter,
+	code string,
+	arguments []interpreter.Value,
+	height uint64,
+) *ScriptResult {
+	if m.runScriptAtHeight == nil {
+		panic("'RunScriptAtHeight' is not implemented")
+	}
+
+	return m.runScriptAtHeight(inter, code, arguments, height)
 }
 
 func (m mockedBlockchain) CreateAccount() (*Account, error) {
@@ -2930,6 +10247,22 @@ func (m mockedBlockchain) CreateAccount() (*Account, error) {
 	return m.createAccount()
 }
 
+func (m mockedBlockchain) SetComputationLimit(limit uint64) {
+	if m.setComputationLimit == nil {
+		panic("'SetComputationLimit' is not implemented")
+	}
+
+	m.setComputationLimit(limit)
+}
+
+func (m mockedBlockchain) CreateAccountWithKeys(keyWeights []int) (*Account, error) {
+	if m.createAccountWithKeys == nil {
+		panic("'CreateAccountWithKeys' is not implemented")
+	}
+
+	return m.createAccountWithKeys(keyWeights)
+}
+
 func (m mockedBlockchain) GetAccount(address interpreter.AddressValue) (*Account, error) {
 	if m.getAccount == nil {
 		panic("'getAccount' is not implemented")
@@ -3024,6 +10357,14 @@ func (m mockedBlockchain) MoveTime(timeDelta int64) {
 	m.moveTime(timeDelta)
 }
 
+func (m mockedBlockchain) CurrentBlock() (Block, error) {
+	if m.currentBlock == nil {
+		panic("'CurrentBlock' is not implemented")
+	}
+
+	return m.currentBlock()
+}
+
 func (m mockedBlockchain) CreateSnapshot(name string) error {
 	if m.createSnapshot == nil {
 		panic("'CreateSnapshot' is not implemented")
@@ -3039,3 +10380,185 @@ func (m mockedBlockchain) LoadSnapshot(name string) error {
 
 	return m.loadSnapshot(name)
 }
+
+func (m mockedBlockchain) StoragePathExists(address common.Address, path interpreter.PathValue) (bool, error) {
+	if m.storagePathExists == nil {
+		panic("'StoragePathExists' is not implemented")
+	}
+
+	return m.storagePathExists(address, path)
+}
+
+func (m mockedBlockchain) ApplyMigration(migration string, snapshot string) ([]string, error) {
+	if m.applyMigration == nil {
+		panic("'ApplyMigration' is not implemented")
+	}
+
+	return m.applyMigration(migration, snapshot)
+}
+
+func (m mockedBlockchain) DiffSnapshots(
+	inter *interpreter.Interpreter,
+	before string,
+	after string,
+) (map[string]interpreter.Value, error) {
+	if m.diffSnapshots == nil {
+		panic("'DiffSnapshots' is not implemented")
+	}
+
+	return m.diffSnapshots(inter, before, after)
+}
+
+func (m mockedBlockchain) GetContractType(name string) (interpreter.StaticType, error) {
+	if m.getContractType == nil {
+		panic("'GetContractType' is not implemented")
+	}
+
+	return m.getContractType(name)
+}
+
+func (m mockedBlockchain) ContractInitCount(name string) (uint64, error) {
+	if m.contractInitCount == nil {
+		panic("'ContractInitCount' is not implemented")
+	}
+
+	return m.contractInitCount(name)
+}
+
+func (m mockedBlockchain) ContractConstant(
+	inter *interpreter.Interpreter,
+	name string,
+	fieldName string,
+) (interpreter.Value, error) {
+	if m.contractConstant == nil {
+		panic("'ContractConstant' is not implemented")
+	}
+
+	return m.contractConstant(inter, name, fieldName)
+}
+
+func (m mockedBlockchain) GetAccountContractNames(address common.Address) ([]string, error) {
+	if m.getAccountContractNames == nil {
+		panic("'GetAccountContractNames' is not implemented")
+	}
+
+	return m.getAccountContractNames(address)
+}
+
+func (m mockedBlockchain) DeployContractWithCost(
+	inter *interpreter.Interpreter,
+	name string,
+	path string,
+	arguments []interpreter.Value,
+) *ContractDeploymentResult {
+	if m.deployContractWithCost == nil {
+		panic("'DeployContractWithCost' is not implemented")
+	}
+
+	return m.deployContractWithCost(inter, name, path, arguments)
+}
+
+func (m mockedBlockchain) DeployContractWithCode(
+	inter *interpreter.Interpreter,
+	name string,
+	code string,
+	account *Account,
+	arguments []interpreter.Value,
+) error {
+	if m.deployContractWithCode == nil {
+		panic("'DeployContractWithCode' is not implemented")
+	}
+
+	return m.deployContractWithCode(inter, name, code, account, arguments)
+}
+
+func (m mockedBlockchain) RunScriptWithAccounts(
+	inter *interpreter.Interpreter,
+	code string,
+	arguments []interpreter.Value,
+	accounts []*MockedAccount,
+) *ScriptResult {
+	if m.runScriptWithAccounts == nil {
+		panic("'RunScriptWithAccounts' is not implemented")
+	}
+
+	return m.runScriptWithAccounts(inter, code, arguments, accounts)
+}
+
+func (m mockedBlockchain) CapabilityControllerCount(address common.Address, path interpreter.PathValue) (int, error) {
+	if m.capabilityControllerCount == nil {
+		panic("'CapabilityControllerCount' is not implemented")
+	}
+
+	return m.capabilityControllerCount(address, path)
+}
+
+func (m mockedBlockchain) CapabilityControllers(
+	address common.Address,
+	path interpreter.PathValue,
+) ([]CapabilityController, error) {
+	if m.capabilityControllers == nil {
+		panic("'CapabilityControllers' is not implemented")
+	}
+
+	return m.capabilityControllers(address, path)
+}
+
+func (m mockedBlockchain) AllCapabilityControllers(address common.Address) ([]CapabilityController, error) {
+	if m.allCapabilityControllers == nil {
+		panic("'AllCapabilityControllers' is not implemented")
+	}
+
+	return m.allCapabilityControllers(address)
+}
+
+func (m mockedBlockchain) StoredValues(
+	inter *interpreter.Interpreter,
+	address common.Address,
+) (map[string]interpreter.Value, error) {
+	if m.storedValues == nil {
+		panic("'StoredValues' is not implemented")
+	}
+
+	return m.storedValues(inter, address)
+}
+
+func (m mockedBlockchain) CollectionSize(address common.Address, path interpreter.PathValue) (int, error) {
+	if m.collectionSize == nil {
+		panic("'CollectionSize' is not implemented")
+	}
+
+	return m.collectionSize(address, path)
+}
+
+func (m mockedBlockchain) AddTransactionWithPayer(
+	inter *interpreter.Interpreter,
+	code string,
+	authorizers []common.Address,
+	signers []*Account,
+	proposer *Account,
+	payer *Account,
+	arguments []interpreter.Value,
+) error {
+	if m.addTransactionWithPayer == nil {
+		panic("'AddTransactionWithPayer' is not implemented")
+	}
+
+	return m.addTransactionWithPayer(inter, code, authorizers, signers, proposer, payer, arguments)
+}
+
+func (m mockedBlockchain) RandomHistory() [][]byte {
+	if m.randomHistory == nil {
+		panic("'RandomHistory' is not implemented")
+	}
+
+	return m.randomHistory()
+}
+
+type testRandomGeneratorFunc func(buffer []byte) error
+
+var _ RandomGenerator = testRandomGeneratorFunc(nil)
+
+func (f testRandomGeneratorFunc) ReadRandom(buffer []byte) error {
+	return f(buffer)
+}
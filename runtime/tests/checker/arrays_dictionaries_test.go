@@ -320,6 +320,106 @@ func TestCheckDictionaryValues(t *testing.T) {
 	)
 }
 
+func TestCheckDictionarySortedKeys(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+        let keys = {"abc": 1, "def": 2}.sortedKeys(
+            by: view fun (key: String, value: Int, otherKey: String, otherValue: Int): Bool {
+                return value < otherValue
+            }
+        )
+    `)
+
+	require.NoError(t, err)
+
+	keysType := RequireGlobalValue(t, checker.Elaboration, "keys")
+
+	assert.Equal(t,
+		&sema.VariableSizedType{Type: sema.StringType},
+		keysType,
+	)
+}
+
+func TestCheckInvalidDictionarySortedKeysComparator(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+        let keys = {"abc": 1, "def": 2}.sortedKeys(
+            by: view fun (key: String, otherKey: String): Bool {
+                return key < otherKey
+            }
+        )
+    `)
+
+	errs := RequireCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
+
+func TestCheckDictionaryGetOrDefault(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+        let value = {"abc": 1, "def": 2}.getOrDefault("ghi", 3)
+    `)
+
+	require.NoError(t, err)
+
+	valueType := RequireGlobalValue(t, checker.Elaboration, "value")
+
+	assert.Equal(t, sema.IntType, valueType)
+}
+
+func TestCheckDictionaryGetOrInsert(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            let xs = {"abc": 1, "def": 2}
+            let value = xs.getOrInsert("ghi", 3)
+        `)
+
+		require.NoError(t, err)
+
+		valueType := RequireGlobalValue(t, checker.Elaboration, "value")
+
+		assert.Equal(t, sema.IntType, valueType)
+	})
+
+	t.Run("non-auth reference", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+            let xs = {"abc": 1, "def": 2}
+            let ref = &xs as &{String: Int}
+            let value = ref.getOrInsert("ghi", 3)
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAccessError{}, errs[0])
+	})
+
+	t.Run("non-auth reference, getOrDefault", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+            let xs = {"abc": 1, "def": 2}
+            let ref = &xs as &{String: Int}
+            let value = ref.getOrDefault("ghi", 3)
+        `)
+
+		require.NoError(t, err)
+	})
+}
+
 func TestCheckDictionaryEqual(t *testing.T) {
 	t.Parallel()
 
@@ -839,6 +939,39 @@ func TestCheckInvalidResourceArraySlice(t *testing.T) {
 	assert.IsType(t, &sema.ResourceLossError{}, errs[1])
 }
 
+func TestCheckArraySliced(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      fun test(): [Int] {
+          let a = [1, 2, 3, 4]
+          return a.sliced(from: -2, to: -1)
+      }
+    `)
+
+	require.NoError(t, err)
+}
+
+func TestCheckInvalidResourceArraySliced(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      resource X {}
+
+      fun test(): @[X] {
+          let xs <- [<-create X()]
+          return <-xs.sliced(from: 0, to: 1)
+      }
+    `)
+
+	errs := RequireCheckerErrors(t, err, 2)
+
+	assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+	assert.IsType(t, &sema.ResourceLossError{}, errs[1])
+}
+
 func TestCheckArrayInsert(t *testing.T) {
 
 	t.Parallel()
@@ -1131,6 +1264,112 @@ func TestCheckResourceArrayReverseInvalid(t *testing.T) {
 	assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
 }
 
+func TestCheckArrayShuffled(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      fun test() {
+          let x = [1, 2, 3]
+          let y: [Int] = x.shuffled()
+
+          let z: [Int; 3] = [1, 2, 3]
+          let w: [Int; 3] = z.shuffled()
+      }
+    `)
+
+	require.NoError(t, err)
+}
+
+func TestCheckArrayShuffledInvalidInView(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      view fun test(): [Int] {
+          let x = [1, 2, 3]
+          return x.shuffled()
+      }
+    `)
+
+	errs := RequireCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.PurityError{}, errs[0])
+}
+
+func TestCheckResourceArrayShuffledInvalid(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+		resource X {}
+
+		fun test(): @[X] {
+			let xs <- [<-create X()]
+			let shuffled <-xs.shuffled()
+			destroy xs
+			return <- shuffled
+		}
+    `)
+
+	errs := RequireCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+}
+
+func TestCheckArrayRotated(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      view fun test() {
+          let x = [1, 2, 3]
+          let y: [Int] = x.rotated(by: 1)
+
+          let z: [Int; 3] = [1, 2, 3]
+          let w: [Int; 3] = z.rotated(by: -1)
+      }
+    `)
+
+	require.NoError(t, err)
+}
+
+func TestCheckArrayRotatedInvalidArgs(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      fun test() {
+          let x = [1, 2, 3]
+          let y = x.rotated(by: UInt8(1))
+      }
+    `)
+
+	errs := RequireCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
+
+func TestCheckResourceArrayRotatedInvalid(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+		resource X {}
+
+		fun test(): @[X] {
+			let xs <- [<-create X()]
+			let rotated <-xs.rotated(by: 1)
+			destroy xs
+			return <- rotated
+		}
+    `)
+
+	errs := RequireCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+}
+
 func TestCheckArrayFilter(t *testing.T) {
 
 	t.Parallel()
@@ -1229,6 +1468,303 @@ func TestCheckResourceArrayFilterInvalid(t *testing.T) {
 	assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
 }
 
+func TestCheckArrayMinAndMax(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let xs: [Int8] = [1, -2, 3]
+          let a = xs.min()
+          let b = xs.max()
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: sema.Int8Type,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "a"),
+		)
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: sema.Int8Type,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "b"),
+		)
+	})
+
+	t.Run("not comparable", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs: [[Int]] = [[1], [2]]
+          let a = xs.min()
+          let b = xs.max()
+        `)
+
+		errs := RequireCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.NotComparableTypeError{}, errs[0])
+		assert.IsType(t, &sema.NotComparableTypeError{}, errs[1])
+	})
+
+	t.Run("composite", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          let xs: [S] = [S()]
+          let a = xs.max()
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotComparableTypeError{}, errs[0])
+	})
+}
+
+func TestCheckArrayPartition(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+		fun test() {
+			let x = [1, 2, 3]
+			let onlyEven =
+				view fun (_ x: Int): Bool {
+					return x % 2 == 0
+				}
+
+			let y: [[Int]; 2] = x.partition(onlyEven)
+		}
+
+		fun testFixedSize() {
+			let x : [Int; 5] = [1, 2, 3, 21, 30]
+			let onlyEvenInt =
+				view fun (_ x: Int): Bool {
+					return x % 2 == 0
+				}
+
+			let y: [[Int]; 2] = x.partition(onlyEvenInt)
+		}
+    `)
+
+	require.NoError(t, err)
+}
+
+func TestCheckArrayPartitionInvalidArgs(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+		fun test() {
+			let x = [1, 2, 3]
+			let onlyEvenInt16 =
+				view fun (_ x: Int16): Bool {
+					return x % 2 == 0
+				}
+
+			let y = x.partition(onlyEvenInt16)
+		}
+	`)
+
+	errs := RequireCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
+
+func TestCheckResourceArrayPartitionInvalid(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+		resource X {}
+
+		fun test(): @[[X]; 2] {
+			let xs <- [<-create X()]
+			let allResources =
+				fun (_ x: @X): Bool {
+					destroy x
+					return true
+				}
+
+			let partitions <- xs.partition(allResources)
+			destroy xs
+			return <- partitions
+		}
+    `)
+
+	errs := RequireCheckerErrors(t, err, 2)
+
+	assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+}
+
+func TestCheckArrayForEachIndexed(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+		fun test() {
+			let x = ["a", "b", "c"]
+			var indexSum = 0
+			x.forEachIndexed(fun (index: Int, element: String) {
+				indexSum = indexSum + index
+			})
+		}
+
+		fun testFixedSize() {
+			let x: [Int; 3] = [1, 2, 3]
+			x.forEachIndexed(fun (_ index: Int, _ element: Int) {})
+		}
+	`)
+
+	require.NoError(t, err)
+}
+
+func TestCheckArrayForEachIndexedInvalidArgs(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+		fun test() {
+			let x = [1, 2, 3]
+			x.forEachIndexed(fun (index: Int, element: String) {})
+		}
+	`)
+
+	errs := RequireCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
+
+func TestCheckResourceArrayForEachIndexedInvalid(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+		resource X {}
+
+		fun test() {
+			let xs <- [<-create X()]
+			xs.forEachIndexed(fun (index: Int, element: @X) {
+				destroy element
+			})
+			destroy xs
+		}
+	`)
+
+	errs := RequireCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+}
+
+func TestCheckArrayZip(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+		struct Pair {
+			let first: String
+			let second: Int
+
+			init(first: String, second: Int) {
+				self.first = first
+				self.second = second
+			}
+		}
+
+		fun test(): [Pair] {
+			let xs = ["a", "b"]
+			return xs.zip([1, 2], fun (element: String, otherElement: Int): Pair {
+				return Pair(first: element, second: otherElement)
+			})
+		}
+
+		fun testFixedSize(): [Int] {
+			let xs: [Int; 2] = [1, 2]
+			return xs.zip([3, 4], fun (element: Int, otherElement: Int): Int {
+				return element + otherElement
+			})
+		}
+	`)
+
+	require.NoError(t, err)
+}
+
+func TestCheckArrayZipInvalidArgs(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+		fun test() {
+			let xs = [1, 2]
+			let ys = ["a", "b"]
+			let zs: [Int] = xs.zip(ys, fun (element: Int, otherElement: Int): Int {
+				return element + otherElement
+			})
+		}
+	`)
+
+	errs := RequireCheckerErrors(t, err, 2)
+
+	assert.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+}
+
+func TestCheckResourceArrayZipInvalid(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("resource array", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+			resource X {}
+
+			fun test() {
+				let xs <- [<-create X()]
+				let ys = xs.zip([1], fun (element: @X, otherElement: Int): Int {
+					destroy element
+					return otherElement
+				})
+				destroy xs
+			}
+		`)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+	})
+
+	t.Run("resource argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+			resource X {}
+
+			fun test() {
+				let xs = [1]
+				let ys <- [<-create X()]
+				let zs = xs.zip(<-ys, fun (element: Int, otherElement: @X): Int {
+					destroy otherElement
+					return element
+				})
+			}
+		`)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
 func TestCheckArrayMap(t *testing.T) {
 
 	t.Parallel()
@@ -186,6 +186,63 @@ func TestCheckAddressFromString(t *testing.T) {
 	runInvalidCase(t, "typo: \"0x1\"", &sema.IncorrectArgumentLabelError{})
 }
 
+func TestCheckIntFromStringWithRadix(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("with radix", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let x = Int.fromString("ff", radix: 16)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: sema.IntType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("missing label", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let x = Int.fromString("ff", 16)
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingArgumentLabelError{}, errs[0])
+	})
+
+	t.Run("invalid radix type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let x = Int.fromString("ff", radix: UInt8(16))
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("other number type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let x = UInt8.fromString("ff", radix: 16)
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ExcessiveArgumentsError{}, errs[0])
+	})
+}
+
 func TestCheckToBigEndianBytes(t *testing.T) {
 
 	t.Parallel()
@@ -213,6 +270,85 @@ func TestCheckToBigEndianBytes(t *testing.T) {
 	}
 }
 
+func TestCheckBitCountingFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllNumberTypes {
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			checker, err := parseAndCheckWithTestValue(t,
+				`
+                  let a = test.popcount()
+                  let b = test.leadingZeros()
+                  let c = test.trailingZeros()
+                `,
+				ty,
+			)
+
+			if !sema.IsSubType(ty, sema.FixedSizeUnsignedIntegerType) {
+				errs := RequireCheckerErrors(t, err, 3)
+
+				for _, err := range errs {
+					assert.IsType(t, &sema.NotDeclaredMemberError{}, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			for _, name := range []string{"a", "b", "c"} {
+				assert.Equal(t,
+					sema.IntType,
+					RequireGlobalValue(t, checker.Elaboration, name),
+				)
+			}
+		})
+	}
+}
+
+func TestCheckAbsAndSignum(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllNumberTypes {
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			checker, err := parseAndCheckWithTestValue(t,
+				`
+                  let a = test.abs()
+                  let b = test.signum()
+                `,
+				ty,
+			)
+
+			if !sema.IsSubType(ty, sema.SignedNumberType) {
+				errs := RequireCheckerErrors(t, err, 2)
+
+				for _, err := range errs {
+					assert.IsType(t, &sema.NotDeclaredMemberError{}, err)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t,
+				ty,
+				RequireGlobalValue(t, checker.Elaboration, "a"),
+			)
+			assert.Equal(t,
+				sema.Int8Type,
+				RequireGlobalValue(t, checker.Elaboration, "b"),
+			)
+		})
+	}
+}
+
 func TestCheckFromBigEndianBytes(t *testing.T) {
 
 	t.Parallel()
@@ -444,3 +580,178 @@ func TestCheckRevertibleRandom(t *testing.T) {
 		)
 	})
 }
+
+func TestCheckGCDAndLCM(t *testing.T) {
+
+	t.Parallel()
+
+	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+	baseValueActivation.DeclareValue(stdlib.GCDFunction)
+	baseValueActivation.DeclareValue(stdlib.LCMFunction)
+
+	options := ParseAndCheckOptions{
+		Config: &sema.Config{
+			BaseValueActivationHandler: func(_ common.Location) *sema.VariableActivation {
+				return baseValueActivation
+			},
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheckWithOptions(t,
+			`
+              let a = gcd(UInt256(12), 18)
+              let b = lcm(Int8(4), 6)
+            `,
+			options,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.UInt256Type,
+			RequireGlobalValue(t, checker.Elaboration, "a"),
+		)
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: sema.Int8Type,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "b"),
+		)
+	})
+
+	t.Run("mismatched types", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			`
+              let a = gcd(UInt8(1), UInt16(2))
+            `,
+			options,
+		)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("non-leaf integer type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			`
+              let a = gcd<Integer>(1, 2)
+            `,
+			options,
+		)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidTypeArgumentError{}, errs[0])
+	})
+
+	t.Run("non-integer type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			`
+              let a = lcm(1.5, 2.0)
+            `,
+			options,
+		)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+func TestCheckMinAndMax(t *testing.T) {
+
+	t.Parallel()
+
+	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+	baseValueActivation.DeclareValue(stdlib.MinFunction)
+	baseValueActivation.DeclareValue(stdlib.MaxFunction)
+
+	options := ParseAndCheckOptions{
+		Config: &sema.Config{
+			BaseValueActivationHandler: func(_ common.Location) *sema.VariableActivation {
+				return baseValueActivation
+			},
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheckWithOptions(t,
+			`
+              let a = min(UInt8(1), 2)
+              let b = max(-1.5, 2.0)
+              let c = min("a", "b")
+            `,
+			options,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.UInt8Type,
+			RequireGlobalValue(t, checker.Elaboration, "a"),
+		)
+		assert.Equal(t,
+			sema.Fix64Type,
+			RequireGlobalValue(t, checker.Elaboration, "b"),
+		)
+		assert.Equal(t,
+			sema.StringType,
+			RequireGlobalValue(t, checker.Elaboration, "c"),
+		)
+	})
+
+	t.Run("mismatched types", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			`
+              let a = min(UInt8(1), UInt16(2))
+            `,
+			options,
+		)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("non-leaf number type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			`
+              let a = max<Number>(1, 2.5)
+            `,
+			options,
+		)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidTypeArgumentError{}, errs[0])
+	})
+
+	t.Run("non-comparable type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			`
+              let a = max([1], [2])
+            `,
+			options,
+		)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidTypeArgumentError{}, errs[0])
+	})
+}
@@ -74,3 +74,54 @@ func TestCheckCharacterUtf8Field(t *testing.T) {
 		RequireGlobalValue(t, checker.Elaboration, "x"),
 	)
 }
+
+func TestCheckCharacterUnicodeScalarsField(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+		let a: Character = "a"
+        let x = a.unicodeScalars
+	`)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.VariableSizedType{
+			Type: sema.UInt32Type,
+		},
+		RequireGlobalValue(t, checker.Elaboration, "x"),
+	)
+}
+
+func TestCheckCharacterFunction(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            let x = Character(0x61)
+        `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.CharacterType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("invalid argument type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+            let x = Character("a")
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
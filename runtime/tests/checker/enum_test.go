@@ -246,3 +246,46 @@ func TestCheckEnumInContract(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestCheckEnumCaseName(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      enum E: UInt8 {
+          case a
+          case b
+      }
+
+      let name: String = E.a.caseName
+      let e: E? = E.fromCaseName(name)
+    `)
+
+	require.NoError(t, err)
+
+	enumType := RequireGlobalType(t, checker.Elaboration, "E").(*sema.CompositeType)
+	assert.Equal(t, []string{"a", "b"}, enumType.EnumCases)
+
+	// The case name is not a stored field
+	assert.Equal(t, []string{sema.EnumRawValueFieldName}, enumType.Fields)
+}
+
+func TestCheckEnumCaseNameNotAssignable(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      enum E: UInt8 {
+          case a
+      }
+
+      fun test() {
+          E.a.caseName = "b"
+      }
+    `)
+
+	errs := RequireCheckerErrors(t, err, 2)
+
+	assert.IsType(t, &sema.InvalidAssignmentAccessError{}, errs[0])
+	assert.IsType(t, &sema.AssignmentToConstantMemberError{}, errs[1])
+}
@@ -661,6 +661,100 @@ func TestCheckInvalidImportCycleTwoLocations(t *testing.T) {
 	require.IsType(t, &sema.NotDeclaredError{}, errs[1])
 }
 
+func TestCheckNestedImportDiamond(t *testing.T) {
+
+	t.Parallel()
+
+	// "a" imports "b" and "c", which both import "d".
+	// The import handler caches checked programs,
+	// so "d" is only checked once
+
+	codes := map[common.Location]string{
+		common.StringLocation("b"): `
+          import d from "d"
+
+          access(all) fun b(): Int {
+              return d() + 1
+          }
+        `,
+		common.StringLocation("c"): `
+          import d from "d"
+
+          access(all) fun c(): Int {
+              return d() + 2
+          }
+        `,
+		common.StringLocation("d"): `
+          access(all) fun d(): Int {
+              return 1
+          }
+        `,
+	}
+
+	elaborations := map[common.Location]*sema.Elaboration{}
+	checks := map[common.Location]int{}
+
+	importHandler := func(checker *sema.Checker, importedLocation common.Location, _ ast.Range) (sema.Import, error) {
+		elaboration, ok := elaborations[importedLocation]
+		if !ok {
+			code, ok := codes[importedLocation]
+			if !ok {
+				t.Fatalf("invalid import: %#+v", importedLocation)
+			}
+
+			importedProgram, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+			require.NoError(t, err)
+
+			subChecker, err := checker.SubChecker(importedProgram, importedLocation)
+			if err != nil {
+				return nil, err
+			}
+
+			checks[importedLocation]++
+
+			err = subChecker.Check()
+			if err != nil {
+				return nil, err
+			}
+
+			elaboration = subChecker.Elaboration
+			elaborations[importedLocation] = elaboration
+		}
+
+		return sema.ElaborationImport{
+			Elaboration: elaboration,
+		}, nil
+	}
+
+	_, err := ParseAndCheckWithOptions(t,
+		`
+          import b from "b"
+          import c from "c"
+
+          access(all) fun a(): Int {
+              return b() + c()
+          }
+        `,
+		ParseAndCheckOptions{
+			Location: common.StringLocation("a"),
+			Config: &sema.Config{
+				ImportHandler: importHandler,
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		map[common.Location]int{
+			common.StringLocation("b"): 1,
+			common.StringLocation("c"): 1,
+			common.StringLocation("d"): 1,
+		},
+		checks,
+	)
+}
+
 func TestCheckImportVirtual(t *testing.T) {
 
 	t.Parallel()
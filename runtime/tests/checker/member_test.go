@@ -207,6 +207,35 @@ func TestCheckOptionalChainingFunctionCall(t *testing.T) {
 	)
 }
 
+func TestCheckOptionalChainingOptionalFunctionCall(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      struct Test {
+          fun x(): Int? {
+              return 42
+          }
+      }
+
+      let test: Test? = Test()
+      let x = test?.x()
+    `)
+
+	require.NoError(t, err)
+
+	// Unlike field reads, the result of the function call is not flattened
+
+	assert.Equal(t,
+		&sema.OptionalType{
+			Type: &sema.OptionalType{
+				Type: sema.IntType,
+			},
+		},
+		RequireGlobalValue(t, checker.Elaboration, "x"),
+	)
+}
+
 func TestCheckInvalidOptionalChainingNonOptional(t *testing.T) {
 
 	t.Parallel()
@@ -469,3 +469,121 @@ func TestCheckOptionalMap(t *testing.T) {
 		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
 	})
 }
+
+func TestCheckOptionalOrElse(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		_, err := ParseAndCheckWithPanic(t, `
+          fun test(): Int {
+              let x: Int? = 1
+              return x.orElse(fun (): Int {
+                  return 0
+              })
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid return type", func(t *testing.T) {
+
+		_, err := ParseAndCheckWithPanic(t, `
+          fun test(): Int {
+              let x: Int? = 1
+              return x.orElse(fun (): String {
+                  return ""
+              })
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		_, err := ParseAndCheckWithPanic(t, `
+          resource R {}
+
+          fun test() {
+              let x: @R? <- create R()
+              let y <- x.orElse(fun (): @R {
+                  return <- create R()
+              })
+              destroy x
+              destroy y
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidResourceOptionalMemberError{}, errs[0])
+	})
+}
+
+func TestCheckOptionalUnwrap(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		_, err := ParseAndCheckWithPanic(t, `
+          fun test(): Int {
+              let x: Int? = 1
+              return x.unwrap(orFail: "x is nil")
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing label", func(t *testing.T) {
+
+		_, err := ParseAndCheckWithPanic(t, `
+          fun test(): Int {
+              let x: Int? = 1
+              return x.unwrap("x is nil")
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingArgumentLabelError{}, errs[0])
+	})
+
+	t.Run("invalid message type", func(t *testing.T) {
+
+		_, err := ParseAndCheckWithPanic(t, `
+          fun test(): Int {
+              let x: Int? = 1
+              return x.unwrap(orFail: 1)
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		_, err := ParseAndCheckWithPanic(t, `
+          resource R {}
+
+          fun test() {
+              let x: @R? <- create R()
+              let y <- x.unwrap(orFail: "x is nil")
+              destroy x
+              destroy y
+          }
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidResourceOptionalMemberError{}, errs[0])
+	})
+}
@@ -3955,6 +3955,68 @@ func TestCheckInvalidResourceDictionaryKeysForeach(t *testing.T) {
 	assert.IsType(t, &sema.InvalidResourceDictionaryMemberError{}, errs[1])
 }
 
+func TestCheckInvalidResourceDictionarySortedKeys(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+        resource X {}
+
+        fun test() {
+            let xs <- {"x1": <-create X()}
+            let keys = xs.sortedKeys(
+                by: view fun (key: String, value: @X, otherKey: String, otherValue: @X): Bool {
+                    return key < otherKey
+                }
+            )
+            destroy xs
+        }
+    `)
+
+	errs := RequireCheckerErrors(t, err, 3)
+
+	assert.IsType(t, &sema.InvalidResourceDictionaryMemberError{}, errs[0])
+	assert.IsType(t, &sema.ResourceLossError{}, errs[1])
+	assert.IsType(t, &sema.ResourceLossError{}, errs[2])
+}
+
+func TestCheckInvalidResourceDictionaryGetOrDefault(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+        resource X {}
+
+        fun test() {
+            let xs <- {"x1": <-create X()}
+            let x <- xs.getOrDefault("x2", <-create X())
+            destroy x
+            destroy xs
+        }
+    `)
+
+	errs := RequireCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.InvalidResourceDictionaryMemberError{}, errs[0])
+}
+
+func TestCheckInvalidResourceDictionaryGetOrInsert(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+        resource X {}
+
+        fun test() {
+            let xs <- {"x1": <-create X()}
+            let x <- xs.getOrInsert("x2", <-create X())
+            destroy x
+            destroy xs
+        }
+    `)
+
+	errs := RequireCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.InvalidResourceDictionaryMemberError{}, errs[0])
+}
+
 func TestCheckInvalidResourceLossAfterMoveThroughDictionaryIndexing(t *testing.T) {
 
 	t.Parallel()
@@ -355,6 +355,56 @@ func TestCheckStringToLower(t *testing.T) {
 	)
 }
 
+func TestCheckStringToUpper(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+        let x = "Abc".toUpper()
+	`)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		sema.StringType,
+		RequireGlobalValue(t, checker.Elaboration, "x"),
+	)
+}
+
+func TestCheckStringTrim(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+        let x = " Abc ".trim()
+	`)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		sema.StringType,
+		RequireGlobalValue(t, checker.Elaboration, "x"),
+	)
+}
+
+func TestCheckStringToCharacters(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+        let x = "👪❤️".toCharacters()
+	`)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.VariableSizedType{
+			Type: sema.CharacterType,
+		},
+		RequireGlobalValue(t, checker.Elaboration, "x"),
+	)
+}
+
 func TestCheckStringJoin(t *testing.T) {
 
 	t.Parallel()
@@ -409,6 +459,34 @@ func TestCheckStringJoinTypeMissingArgumentLabelSeparator(t *testing.T) {
 	assert.IsType(t, &sema.MissingArgumentLabelError{}, errs[0])
 }
 
+func TestCheckStringFormat(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+		let s = String.format("{} and {}", [1, "two"])
+	`)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		sema.StringType,
+		RequireGlobalValue(t, checker.Elaboration, "s"),
+	)
+}
+
+func TestCheckStringFormatTypeMismatchTemplate(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+		let s = String.format(1, [1])
+	`)
+
+	errs := RequireCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
+
 func TestCheckStringSplit(t *testing.T) {
 
 	t.Parallel()
@@ -237,6 +237,68 @@ func TestInterpretAddressFromBytes(t *testing.T) {
 	runInvalidCase(t, "[12, 34, 56, 11, 22, 33, 44, 55, 66, 77, 88, 99, 111]")
 }
 
+func TestInterpretAddressBytesRoundTrip(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, literal string, expected []byte) {
+		t.Run(literal, func(t *testing.T) {
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      let address: Address = %s
+                      let bytes = address.toBytes()
+                      let roundTripped = Address.fromBytes(bytes) == address
+                    `,
+					literal,
+				),
+			)
+
+			expectedValues := make([]interpreter.Value, 0, len(expected))
+			for _, b := range expected {
+				expectedValues = append(expectedValues, interpreter.NewUnmeteredUInt8Value(b))
+			}
+
+			AssertValuesEqual(
+				t,
+				inter,
+				interpreter.NewArrayValue(
+					inter,
+					interpreter.EmptyLocationRange,
+					&interpreter.VariableSizedStaticType{
+						Type: interpreter.PrimitiveStaticTypeUInt8,
+					},
+					common.ZeroAddress,
+					expectedValues...,
+				),
+				inter.Globals.Get("bytes").GetValue(inter),
+			)
+
+			AssertValuesEqual(
+				t,
+				inter,
+				interpreter.TrueValue,
+				inter.Globals.Get("roundTripped").GetValue(inter),
+			)
+		})
+	}
+
+	test(t,
+		"0x0",
+		[]byte{0, 0, 0, 0, 0, 0, 0, 0},
+	)
+	test(t,
+		"0xf8d6e0586b0a20c7",
+		[]byte{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7},
+	)
+	test(t,
+		"0xffffffffffffffff",
+		[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	)
+}
+
 func TestInterpretAddressFromString(t *testing.T) {
 
 	t.Parallel()
@@ -75,3 +75,129 @@ func TestInterpretCharacterUtf8Field(t *testing.T) {
 		interpreter.NewUnmeteredUInt8Value(170),
 	)
 }
+
+func TestInterpretCharacterUnicodeScalarsField(t *testing.T) {
+
+	t.Parallel()
+
+	runTest := func(t *testing.T, code string, expectedValues ...interpreter.Value) {
+		inter := parseCheckAndInterpret(t, fmt.Sprintf(`
+		fun test(): [UInt32] {
+			let c: Character = "%s"
+			return c.unicodeScalars
+		}
+	  `, code))
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		RequireValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeUInt32,
+				},
+				common.ZeroAddress,
+				expectedValues...,
+			),
+			result,
+		)
+	}
+
+	// ASCII
+	runTest(t, `a`, interpreter.NewUnmeteredUInt32Value(0x61))
+	// Basic Multilingual Plane
+	runTest(t, `\u{20AC}`, interpreter.NewUnmeteredUInt32Value(0x20AC))
+	// Supplementary plane
+	runTest(t, `\u{1F490}`, interpreter.NewUnmeteredUInt32Value(0x1F490))
+	// Multiple scalars in one grapheme cluster
+	runTest(t, `\u{1F1E9}\u{1F1EA}`,
+		interpreter.NewUnmeteredUInt32Value(0x1F1E9),
+		interpreter.NewUnmeteredUInt32Value(0x1F1EA),
+	)
+}
+
+func TestInterpretCharacterFunction(t *testing.T) {
+
+	t.Parallel()
+
+	runTest := func(t *testing.T, scalar uint32, expected string) {
+		inter := parseCheckAndInterpret(t, `
+		fun test(_ scalar: UInt32): Character {
+			return Character(scalar)
+		}
+
+		fun roundTrip(_ scalar: UInt32): [UInt32] {
+			return Character(scalar).unicodeScalars
+		}
+	  `)
+
+		result, err := inter.Invoke("test", interpreter.NewUnmeteredUInt32Value(scalar))
+		require.NoError(t, err)
+
+		RequireValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredCharacterValue(expected),
+			result,
+		)
+
+		result, err = inter.Invoke("roundTrip", interpreter.NewUnmeteredUInt32Value(scalar))
+		require.NoError(t, err)
+
+		RequireValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeUInt32,
+				},
+				common.ZeroAddress,
+				interpreter.NewUnmeteredUInt32Value(scalar),
+			),
+			result,
+		)
+	}
+
+	t.Run("ASCII", func(t *testing.T) {
+		t.Parallel()
+
+		runTest(t, 0x61, "a")
+	})
+
+	t.Run("Basic Multilingual Plane", func(t *testing.T) {
+		t.Parallel()
+
+		runTest(t, 0x20AC, "€")
+	})
+
+	t.Run("supplementary plane", func(t *testing.T) {
+		t.Parallel()
+
+		runTest(t, 0x1F490, "\U0001F490")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+		fun test(_ scalar: UInt32): Character {
+			return Character(scalar)
+		}
+	  `)
+
+		for _, scalar := range []uint32{0xD800, 0xDFFF, 0x110000} {
+			_, err := inter.Invoke("test", interpreter.NewUnmeteredUInt32Value(scalar))
+			RequireError(t, err)
+
+			var invalidScalarErr interpreter.InvalidUnicodeScalarError
+			require.ErrorAs(t, err, &invalidScalarErr)
+			require.Equal(t, scalar, invalidScalarErr.Scalar)
+		}
+	})
+}
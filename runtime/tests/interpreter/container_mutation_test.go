@@ -1240,3 +1240,112 @@ func TestInterpretInnerContainerMutationWhileIteratingOuter(t *testing.T) {
 		assert.Equal(t, interpreter.NewUnmeteredStringValue("foo"), val)
 	})
 }
+
+func TestInterpretContainerSizeLimit(t *testing.T) {
+
+	t.Parallel()
+
+	const limit = 3
+
+	test := func(t *testing.T, code string) error {
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			code,
+			ParseCheckAndInterpretOptions{
+				Config: &interpreter.Config{
+					ContainerSizeLimit: limit,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		return err
+	}
+
+	requireContainerSizeLimitError := func(t *testing.T, err error, size uint64) {
+		RequireError(t, err)
+
+		var limitErr interpreter.ContainerSizeLimitError
+		require.ErrorAs(t, err, &limitErr)
+
+		assert.Equal(t, uint64(limit), limitErr.Limit)
+		assert.Equal(t, size, limitErr.Size)
+	}
+
+	t.Run("array append within limit", func(t *testing.T) {
+		t.Parallel()
+
+		err := test(t, `
+            fun test() {
+                let xs = [1, 2]
+                xs.append(3)
+            }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("array append", func(t *testing.T) {
+		t.Parallel()
+
+		err := test(t, `
+            fun test() {
+                let xs: [Int] = []
+                var i = 0
+                while true {
+                    xs.append(i)
+                    i = i + 1
+                }
+            }
+        `)
+		requireContainerSizeLimitError(t, err, 4)
+	})
+
+	t.Run("array insert", func(t *testing.T) {
+		t.Parallel()
+
+		err := test(t, `
+            fun test() {
+                let xs = [1, 2, 3]
+                xs.insert(at: 0, 0)
+            }
+        `)
+		requireContainerSizeLimitError(t, err, 4)
+	})
+
+	t.Run("array concat", func(t *testing.T) {
+		t.Parallel()
+
+		err := test(t, `
+            fun test() {
+                let xs = [1, 2]
+                let ys = xs.concat([3, 4, 5])
+            }
+        `)
+		requireContainerSizeLimitError(t, err, 5)
+	})
+
+	t.Run("array literal", func(t *testing.T) {
+		t.Parallel()
+
+		err := test(t, `
+            fun test() {
+                let xs = [1, 2, 3, 4]
+            }
+        `)
+		requireContainerSizeLimitError(t, err, 4)
+	})
+
+	t.Run("dictionary insert", func(t *testing.T) {
+		t.Parallel()
+
+		err := test(t, `
+            fun test() {
+                let xs = {1: "a", 2: "b", 3: "c"}
+                // Updating an existing key does not grow the dictionary
+                xs[3] = "d"
+                xs[4] = "e"
+            }
+        `)
+		requireContainerSizeLimitError(t, err, 4)
+	})
+}
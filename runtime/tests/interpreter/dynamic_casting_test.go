@@ -4276,3 +4276,88 @@ func TestInterpretDynamicCastingOptionalUnwrapping(t *testing.T) {
 		)
 	})
 }
+
+func TestInterpretDynamicCastingArrayElementMismatch(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("mixed array", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [Int] {
+              let values: [AnyStruct] = [1, 2, "three", 4, true]
+              return values as! [Int]
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		var castErr interpreter.ForceCastTypeMismatchError
+		require.ErrorAs(t, err, &castErr)
+
+		assert.Equal(t,
+			&interpreter.ArrayElementTypeMismatch{
+				Index: 2,
+				Type:  sema.StringType,
+			},
+			castErr.MismatchedElement,
+		)
+
+		assert.ErrorContains(t,
+			err,
+			"failed to force-cast value: expected type `[Int]`, got `[AnyStruct]`: "+
+				"element at index 2 has type `String`",
+		)
+	})
+
+	t.Run("conforming elements", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [Int] {
+              let values: [AnyStruct] = [1, 2, 3]
+              return values as! [Int]
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		var castErr interpreter.ForceCastTypeMismatchError
+		require.ErrorAs(t, err, &castErr)
+
+		assert.Nil(t, castErr.MismatchedElement)
+	})
+
+	t.Run("optional target", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [Int]? {
+              let values: [AnyStruct] = [1, nil]
+              return values as! [Int]?
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		var castErr interpreter.ForceCastTypeMismatchError
+		require.ErrorAs(t, err, &castErr)
+
+		assert.Equal(t,
+			&interpreter.ArrayElementTypeMismatch{
+				Index: 1,
+				Type: &sema.OptionalType{
+					Type: sema.NeverType,
+				},
+			},
+			castErr.MismatchedElement,
+		)
+	})
+}
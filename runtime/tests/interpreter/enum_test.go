@@ -19,6 +19,8 @@
 package interpreter_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -185,6 +187,122 @@ func TestInterpretEnumConstructor(t *testing.T) {
 	)
 }
 
+func TestInterpretEnumConstructorRawValueValidation(t *testing.T) {
+
+	t.Parallel()
+
+	// Enum cases are assigned the raw values 0, 1, 2, ... in declaration order,
+	// so any other raw value, even one which shares its low bytes with a case's raw value,
+	// must not produce a case.
+
+	type testCase struct {
+		rawType       string
+		invalidValues []string
+	}
+
+	testCases := []testCase{
+		{
+			rawType:       "UInt8",
+			invalidValues: []string{"3", "4", "UInt8.max"},
+		},
+		{
+			rawType:       "Int8",
+			invalidValues: []string{"-1", "3", "Int8.min", "Int8.max"},
+		},
+		{
+			rawType:       "UInt16",
+			invalidValues: []string{"3", "256", "257", "UInt16.max"},
+		},
+		{
+			rawType:       "Int64",
+			invalidValues: []string{"-1", "3", "256", "Int64.min", "Int64.max"},
+		},
+		{
+			rawType:       "UInt64",
+			invalidValues: []string{"3", "256", "257", "UInt64.max"},
+		},
+		{
+			rawType:       "Int",
+			invalidValues: []string{"-1", "-256", "3", "255", "256", "257", "18446744073709551616"},
+		},
+		{
+			rawType:       "UInt",
+			invalidValues: []string{"3", "255", "256", "257", "18446744073709551617"},
+		},
+		{
+			rawType:       "UInt256",
+			invalidValues: []string{"3", "256", "257", "UInt256.max"},
+		},
+		{
+			rawType:       "Int256",
+			invalidValues: []string{"-1", "3", "256", "Int256.min", "Int256.max"},
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		testCase := testCase
+
+		t.Run(testCase.rawType, func(t *testing.T) {
+			t.Parallel()
+
+			var checks []string
+
+			for i, caseName := range []string{"a", "b", "c"} {
+				checks = append(
+					checks,
+					fmt.Sprintf("E(rawValue: %d)! == E.%s", i, caseName),
+				)
+			}
+
+			for _, invalidValue := range testCase.invalidValues {
+				checks = append(
+					checks,
+					fmt.Sprintf("E(rawValue: %s) == nil", invalidValue),
+				)
+			}
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      enum E: %s {
+                          case a
+                          case b
+                          case c
+                      }
+
+                      let res = [
+                          %s
+                      ]
+                    `,
+					testCase.rawType,
+					strings.Join(checks, ",\n"),
+				),
+			)
+
+			expected := make([]interpreter.Value, len(checks))
+			for i := range checks {
+				expected[i] = interpreter.TrueValue
+			}
+
+			RequireValuesEqual(
+				t,
+				inter,
+				interpreter.NewArrayValue(
+					inter,
+					interpreter.EmptyLocationRange,
+					&interpreter.VariableSizedStaticType{
+						Type: interpreter.PrimitiveStaticTypeBool,
+					},
+					common.ZeroAddress,
+					expected...,
+				),
+				inter.Globals.Get("res").GetValue(inter),
+			)
+		})
+	}
+}
+
 func TestInterpretEnumInstance(t *testing.T) {
 
 	t.Parallel()
@@ -268,3 +386,69 @@ func TestInterpretEnumInContract(t *testing.T) {
 		rawValue,
 	)
 }
+
+func TestInterpretEnumCaseName(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      enum E: UInt8 {
+          case a
+          case b
+      }
+
+      let res = [
+          E.a.caseName == "a",
+          E.b.caseName == "b",
+          E(rawValue: 1)!.caseName == "b",
+          E.fromCaseName("a")! == E.a,
+          E.fromCaseName("b")! == E.b,
+          E.fromCaseName(E.b.caseName)!.rawValue == 1,
+          E.fromCaseName("c") == nil,
+          E.fromCaseName("A") == nil
+      ]
+    `)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			&interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeBool,
+			},
+			common.ZeroAddress,
+			interpreter.TrueValue,
+			interpreter.TrueValue,
+			interpreter.TrueValue,
+			interpreter.TrueValue,
+			interpreter.TrueValue,
+			interpreter.TrueValue,
+			interpreter.TrueValue,
+			interpreter.TrueValue,
+		),
+		inter.Globals.Get("res").GetValue(inter),
+	)
+}
+
+func TestInterpretEnumFromCaseNameShadowedByCase(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      enum E: UInt8 {
+          case a
+          case fromCaseName
+      }
+
+      let res = E.fromCaseName.caseName
+    `)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewUnmeteredStringValue("fromCaseName"),
+		inter.Globals.Get("res").GetValue(inter),
+	)
+}
@@ -24,9 +24,11 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/fixedpoint"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
 	. "github.com/onflow/cadence/runtime/tests/utils"
@@ -718,3 +720,184 @@ func TestInterpretStringFixedPointConversion(t *testing.T) {
 	}
 
 }
+
+func TestInterpretFixedPointToString(t *testing.T) {
+
+	t.Parallel()
+
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		// whole numbers
+		{"UFix64(0)", "0.00000000"},
+		{"UFix64(1)", "1.00000000"},
+		{"UFix64(42)", "42.00000000"},
+		{"Fix64(-42)", "-42.00000000"},
+		// fewer than 8 decimals
+		{"UFix64(1.5)", "1.50000000"},
+		{"UFix64(0.1)", "0.10000000"},
+		{"UFix64(123.000001)", "123.00000100"},
+		{"Fix64(-1.5)", "-1.50000000"},
+		{"Fix64(-0.5)", "-0.50000000"},
+		// all 8 decimals
+		{"UFix64(0.00000001)", "0.00000001"},
+		{"Fix64(-0.00000001)", "-0.00000001"},
+		// bounds
+		{"UFix64.max", "184467440737.09551615"},
+		{"UFix64.min", "0.00000000"},
+		{"Fix64.max", "92233720368.54775807"},
+		{"Fix64.min", "-92233720368.54775808"},
+	}
+
+	for _, test := range tests {
+
+		test := test
+
+		t.Run(test.expression, func(t *testing.T) {
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      let x = %s.toString()
+                    `,
+					test.expression,
+				),
+			)
+
+			require.Equal(t,
+				interpreter.NewUnmeteredStringValue(test.expected),
+				inter.Globals.Get("x").GetValue(inter),
+			)
+		})
+	}
+}
+
+func TestInterpretFixedPointSaturatingArithmetic(t *testing.T) {
+
+	t.Parallel()
+
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		// UFix64, add
+		{"UFix64.max.saturatingAdd(0.00000001)", "UFix64.max"},
+		{"UFix64.max.saturatingAdd(UFix64.max)", "UFix64.max"},
+		{"(UFix64.max - 1.0).saturatingAdd(1.0)", "UFix64.max"},
+		{"UFix64(1.5).saturatingAdd(2.25)", "UFix64(3.75)"},
+		// UFix64, subtract
+		{"UFix64.min.saturatingSubtract(0.00000001)", "UFix64.min"},
+		{"UFix64(1.0).saturatingSubtract(UFix64.max)", "UFix64.min"},
+		{"UFix64(1.0).saturatingSubtract(1.0)", "UFix64.min"},
+		{"UFix64(3.75).saturatingSubtract(2.25)", "UFix64(1.5)"},
+		// UFix64, multiply
+		{"UFix64.max.saturatingMultiply(1.00000001)", "UFix64.max"},
+		{"UFix64.max.saturatingMultiply(UFix64.max)", "UFix64.max"},
+		{"UFix64.max.saturatingMultiply(1.0)", "UFix64.max"},
+		{"UFix64(1.5).saturatingMultiply(2.0)", "UFix64(3.0)"},
+		// Fix64, add
+		{"Fix64.max.saturatingAdd(0.00000001)", "Fix64.max"},
+		{"(Fix64.max - 1.0).saturatingAdd(1.0)", "Fix64.max"},
+		{"Fix64.min.saturatingAdd(-0.00000001)", "Fix64.min"},
+		{"(Fix64.min + 1.0).saturatingAdd(-1.0)", "Fix64.min"},
+		{"Fix64(-1.5).saturatingAdd(2.25)", "Fix64(0.75)"},
+		// Fix64, subtract
+		{"Fix64.max.saturatingSubtract(-0.00000001)", "Fix64.max"},
+		{"Fix64.min.saturatingSubtract(0.00000001)", "Fix64.min"},
+		{"Fix64.min.saturatingSubtract(Fix64.max)", "Fix64.min"},
+		{"Fix64(0.75).saturatingSubtract(2.25)", "Fix64(-1.5)"},
+		// Fix64, multiply
+		{"Fix64.max.saturatingMultiply(2.0)", "Fix64.max"},
+		{"Fix64.min.saturatingMultiply(-2.0)", "Fix64.max"},
+		{"Fix64.min.saturatingMultiply(2.0)", "Fix64.min"},
+		{"Fix64.max.saturatingMultiply(-2.0)", "Fix64.min"},
+		{"Fix64.max.saturatingMultiply(1.0)", "Fix64.max"},
+		{"Fix64(-1.5).saturatingMultiply(2.0)", "Fix64(-3.0)"},
+	}
+
+	for _, test := range tests {
+
+		test := test
+
+		t.Run(test.expression, func(t *testing.T) {
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      let x = %s
+                      let y = %s
+                      let equal = x == y
+                    `,
+					test.expression,
+					test.expected,
+				),
+			)
+
+			require.Equal(t,
+				interpreter.TrueValue,
+				inter.Globals.Get("equal").GetValue(inter),
+				"%s != %s",
+				inter.Globals.Get("x").GetValue(inter),
+				inter.Globals.Get("y").GetValue(inter),
+			)
+		})
+	}
+}
+
+func TestInterpretFixedPointAbsAndSignum(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let positive: Fix64 = 1.5
+      let negative: Fix64 = -1.5
+      let zero: Fix64 = 0.0
+      let smallest: Fix64 = -0.00000001
+
+      let positiveAbs = positive.abs() == positive
+      let negativeAbs = negative.abs() == positive
+      let zeroAbs = zero.abs() == zero
+      let smallestAbs = smallest.abs() == 0.00000001
+
+      let signs = [positive.signum(), negative.signum(), zero.signum(), smallest.signum()]
+
+      fun minAbs(): Fix64 {
+          return Fix64.min.abs()
+      }
+    `)
+
+	for _, name := range []string{"positiveAbs", "negativeAbs", "zeroAbs", "smallestAbs"} {
+		assert.Equal(t,
+			interpreter.TrueValue,
+			inter.Globals.Get(name).GetValue(inter),
+			name,
+		)
+	}
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			&interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeInt8,
+			},
+			common.ZeroAddress,
+			interpreter.NewUnmeteredInt8Value(1),
+			interpreter.NewUnmeteredInt8Value(-1),
+			interpreter.NewUnmeteredInt8Value(0),
+			interpreter.NewUnmeteredInt8Value(-1),
+		),
+		inter.Globals.Get("signs").GetValue(inter),
+	)
+
+	// The absolute value of the minimum value cannot be represented, so it overflows
+
+	_, err := inter.Invoke("minAbs")
+	RequireError(t, err)
+	require.ErrorAs(t, err, &interpreter.OverflowError{})
+}
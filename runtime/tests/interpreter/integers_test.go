@@ -30,8 +30,11 @@ import (
 
 	. "github.com/onflow/cadence/runtime/tests/utils"
 
+	"github.com/onflow/cadence/runtime/activations"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
 )
 
 var testIntegerTypesAndValues = map[string]interpreter.Value{
@@ -963,3 +966,527 @@ func TestInterpretStringIntegerConversion(t *testing.T) {
 		t.Run(typ.String(), func(t *testing.T) { test(t, typ) })
 	}
 }
+
+func TestInterpretIntFromStringWithRadix(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, input string, radix int, expected interpreter.Value) {
+		t.Run(fmt.Sprintf("%s, radix %d", input, radix), func(t *testing.T) {
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      let x = Int.fromString("%s", radix: %d)
+                    `,
+					input,
+					radix,
+				),
+			)
+
+			AssertValuesEqual(
+				t,
+				inter,
+				expected,
+				inter.Globals.Get("x").GetValue(inter),
+			)
+		})
+	}
+
+	some := func(value int64) interpreter.Value {
+		return interpreter.NewUnmeteredSomeValueNonCopying(
+			interpreter.NewUnmeteredIntValueFromInt64(value),
+		)
+	}
+
+	test(t, "101010", 2, some(42))
+	test(t, "-1111", 2, some(-15))
+	test(t, "ff", 16, some(255))
+	test(t, "FF", 16, some(255))
+	test(t, "7fffffffffffffff", 16, some(math.MaxInt64))
+	test(t, "zz", 36, some(1295))
+	test(t, "Cadence", 36, some(26748568238))
+	test(t, "42", 10, some(42))
+
+	// invalid digits for the radix
+	test(t, "102", 2, interpreter.Nil)
+	test(t, "fg", 16, interpreter.Nil)
+	test(t, "0xff", 16, interpreter.Nil)
+	test(t, "1_000", 10, interpreter.Nil)
+	test(t, "", 16, interpreter.Nil)
+
+	t.Run("default radix", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let x = Int.fromString("123")
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			some(123),
+			inter.Globals.Get("x").GetValue(inter),
+		)
+	})
+
+	t.Run("radix out of range", func(t *testing.T) {
+		t.Parallel()
+
+		for _, radix := range []string{"0", "1", "37", "-16", "18446744073709551616"} {
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      fun test(): Int? {
+                          return Int.fromString("10", radix: %s)
+                      }
+                    `,
+					radix,
+				),
+			)
+
+			_, err := inter.Invoke("test")
+			RequireError(t, err)
+
+			var invalidRadixErr interpreter.InvalidRadixError
+			require.ErrorAs(t, err, &invalidRadixErr)
+			assert.Equal(t, radix, invalidRadixErr.Radix)
+		}
+	})
+}
+
+func TestInterpretIntegerSaturatingConversion(t *testing.T) {
+
+	t.Parallel()
+
+	type testCase struct {
+		value    string
+		expected interpreter.Value
+	}
+
+	tests := map[sema.Type][]testCase{
+		sema.UInt8Type: {
+			{"-1 as Int", interpreter.NewUnmeteredUInt8Value(0)},
+			{"42 as Int", interpreter.NewUnmeteredUInt8Value(42)},
+			{"256 as Int", interpreter.NewUnmeteredUInt8Value(math.MaxUint8)},
+		},
+		sema.UInt64Type: {
+			{"-128 as Int8", interpreter.NewUnmeteredUInt64Value(0)},
+			{"42 as Int64", interpreter.NewUnmeteredUInt64Value(42)},
+			{"18446744073709551616 as UInt128", interpreter.NewUnmeteredUInt64Value(math.MaxUint64)},
+		},
+		sema.UIntType: {
+			{"-5 as Int", interpreter.NewUnmeteredUIntValueFromUint64(0)},
+			{"42 as Int32", interpreter.NewUnmeteredUIntValueFromUint64(42)},
+		},
+		sema.Int8Type: {
+			{"-129 as Int16", interpreter.NewUnmeteredInt8Value(math.MinInt8)},
+			{"-42 as Int", interpreter.NewUnmeteredInt8Value(-42)},
+			{"200 as UInt8", interpreter.NewUnmeteredInt8Value(math.MaxInt8)},
+		},
+		sema.Int64Type: {
+			{"-9223372036854775809 as Int", interpreter.NewUnmeteredInt64Value(math.MinInt64)},
+			{"42 as Word8", interpreter.NewUnmeteredInt64Value(42)},
+			{"18446744073709551615 as UInt64", interpreter.NewUnmeteredInt64Value(math.MaxInt64)},
+		},
+	}
+
+	for targetType, testCases := range tests {
+		for _, testCase := range testCases {
+
+			functionName := sema.SaturatingConversionFunctionName(targetType)
+
+			t.Run(fmt.Sprintf("(%s).%s", testCase.value, functionName), func(t *testing.T) {
+
+				t.Parallel()
+
+				inter := parseCheckAndInterpret(t,
+					fmt.Sprintf(
+						`
+                          let x = (%s).%s()
+                        `,
+						testCase.value,
+						functionName,
+					),
+				)
+
+				AssertValuesEqual(
+					t,
+					inter,
+					testCase.expected,
+					inter.Globals.Get("x").GetValue(inter),
+				)
+			})
+		}
+	}
+}
+
+func TestInterpretFixedSizeUnsignedIntegerBitCounting(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllFixedSizeUnsignedIntegerTypes {
+
+		ty := ty
+		bitSize := int64(ty.(*sema.NumericType).ByteSize() * 8)
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      let zero: %[1]s = 0
+                      let allOnes: %[1]s = %[1]s.max
+                      let lowestBit: %[1]s = 1
+                      let highestBit: %[1]s = %[1]s(1) << %[2]d
+                      let someBits: %[1]s = 0b1011000
+
+                      let zeroCounts = [zero.popcount(), zero.leadingZeros(), zero.trailingZeros()]
+                      let allOnesCounts = [allOnes.popcount(), allOnes.leadingZeros(), allOnes.trailingZeros()]
+                      let lowestBitCounts = [lowestBit.popcount(), lowestBit.leadingZeros(), lowestBit.trailingZeros()]
+                      let highestBitCounts = [highestBit.popcount(), highestBit.leadingZeros(), highestBit.trailingZeros()]
+                      let someBitsCounts = [someBits.popcount(), someBits.leadingZeros(), someBits.trailingZeros()]
+                    `,
+					ty,
+					bitSize-1,
+				),
+			)
+
+			test := func(name string, popCount, leadingZeros, trailingZeros int64) {
+				AssertValuesEqual(
+					t,
+					inter,
+					interpreter.NewArrayValue(
+						inter,
+						interpreter.EmptyLocationRange,
+						&interpreter.VariableSizedStaticType{
+							Type: interpreter.PrimitiveStaticTypeInt,
+						},
+						common.ZeroAddress,
+						interpreter.NewUnmeteredIntValueFromInt64(popCount),
+						interpreter.NewUnmeteredIntValueFromInt64(leadingZeros),
+						interpreter.NewUnmeteredIntValueFromInt64(trailingZeros),
+					),
+					inter.Globals.Get(name).GetValue(inter),
+				)
+			}
+
+			test("zeroCounts", 0, bitSize, bitSize)
+			test("allOnesCounts", bitSize, 0, 0)
+			test("lowestBitCounts", 1, bitSize-1, 0)
+			test("highestBitCounts", 1, 0, bitSize-1)
+			test("someBitsCounts", 3, bitSize-7, 3)
+		})
+	}
+}
+
+func TestInterpretSignedIntegerAbsAndSignum(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllSignedIntegerTypes {
+
+		ty := ty
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      let positive: %[1]s = 42
+                      let negative: %[1]s = -42
+                      let zero: %[1]s = 0
+
+                      let positiveAbs = positive.abs() == positive
+                      let negativeAbs = negative.abs() == positive
+                      let zeroAbs = zero.abs() == zero
+
+                      let signs = [positive.signum(), negative.signum(), zero.signum()]
+                    `,
+					ty,
+				),
+			)
+
+			for _, name := range []string{"positiveAbs", "negativeAbs", "zeroAbs"} {
+				assert.Equal(t,
+					interpreter.TrueValue,
+					inter.Globals.Get(name).GetValue(inter),
+					name,
+				)
+			}
+
+			AssertValuesEqual(
+				t,
+				inter,
+				interpreter.NewArrayValue(
+					inter,
+					interpreter.EmptyLocationRange,
+					&interpreter.VariableSizedStaticType{
+						Type: interpreter.PrimitiveStaticTypeInt8,
+					},
+					common.ZeroAddress,
+					interpreter.NewUnmeteredInt8Value(1),
+					interpreter.NewUnmeteredInt8Value(-1),
+					interpreter.NewUnmeteredInt8Value(0),
+				),
+				inter.Globals.Get("signs").GetValue(inter),
+			)
+
+		})
+	}
+
+	// The absolute value of the minimum value of a fixed-size type
+	// cannot be represented, so it overflows
+
+	for _, ty := range sema.AllSignedIntegerTypes {
+
+		if ty == sema.IntType {
+			continue
+		}
+
+		ty := ty
+
+		t.Run(fmt.Sprintf("%s, min", ty), func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      fun test(): %[1]s {
+                          return %[1]s.min.abs()
+                      }
+                    `,
+					ty,
+				),
+			)
+
+			_, err := inter.Invoke("test")
+			RequireError(t, err)
+			require.ErrorAs(t, err, &interpreter.OverflowError{})
+		})
+	}
+
+	t.Run("Int, large", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let x = -100000000000000000000000000000000000000000000000000000000000000000000000000000000
+          let abs = x.abs()
+          let sign = x.signum()
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromBigInt(
+				new(big.Int).Exp(big.NewInt(10), big.NewInt(80), nil),
+			),
+			inter.Globals.Get("abs").GetValue(inter),
+		)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredInt8Value(-1),
+			inter.Globals.Get("sign").GetValue(inter),
+		)
+	})
+}
+
+func TestInterpretIntegerGCDAndLCM(t *testing.T) {
+
+	t.Parallel()
+
+	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+	baseValueActivation.DeclareValue(stdlib.GCDFunction)
+	baseValueActivation.DeclareValue(stdlib.LCMFunction)
+
+	baseActivation := activations.NewActivation(nil, interpreter.BaseActivation)
+	interpreter.Declare(baseActivation, stdlib.GCDFunction)
+	interpreter.Declare(baseActivation, stdlib.LCMFunction)
+
+	options := ParseCheckAndInterpretOptions{
+		CheckerConfig: &sema.Config{
+			BaseValueActivationHandler: func(common.Location) *sema.VariableActivation {
+				return baseValueActivation
+			},
+		},
+		Config: &interpreter.Config{
+			BaseActivationHandler: func(common.Location) *interpreter.VariableActivation {
+				return baseActivation
+			},
+		},
+	}
+
+	test := func(t *testing.T, expression string, expected interpreter.Value) {
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			fmt.Sprintf(
+				`
+                  let x = %s
+                `,
+				expression,
+			),
+			options,
+		)
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			expected,
+			inter.Globals.Get("x").GetValue(inter),
+		)
+	}
+
+	uint256Max, ok := new(big.Int).SetString(
+		"115792089237316195423570985008687907853269984665640564039457584007913129639935",
+		10,
+	)
+	require.True(t, ok)
+
+	tests := []struct {
+		expression string
+		expected   interpreter.Value
+	}{
+		// coprime
+		{"gcd(8, 15)", interpreter.NewUnmeteredIntValueFromInt64(1)},
+		{"lcm(8, 15)", interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredIntValueFromInt64(120))},
+		// common factors
+		{"gcd(12, 18)", interpreter.NewUnmeteredIntValueFromInt64(6)},
+		{"lcm(12, 18)", interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredIntValueFromInt64(36))},
+		{"gcd(UInt8(100), 75)", interpreter.NewUnmeteredUInt8Value(25)},
+		{"lcm(UInt8(4), 6)", interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredUInt8Value(12))},
+		// negative values
+		{"gcd(-12, 18)", interpreter.NewUnmeteredIntValueFromInt64(6)},
+		{"lcm(Int8(-4), 6)", interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredInt8Value(12))},
+		// explicit type argument
+		{"gcd<UInt8>(12, 18)", interpreter.NewUnmeteredUInt8Value(6)},
+		{"lcm<Int16>(12, 18)", interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredInt16Value(36))},
+		// zero
+		{"gcd(0, 7)", interpreter.NewUnmeteredIntValueFromInt64(7)},
+		{"gcd(0, 0)", interpreter.NewUnmeteredIntValueFromInt64(0)},
+		{"lcm(0, 7)", interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredIntValueFromInt64(0))},
+		// large values
+		{
+			"gcd(UInt256.max, UInt256.max)",
+			interpreter.NewUnmeteredUInt256ValueFromBigInt(uint256Max),
+		},
+		{
+			"lcm(UInt256.max, 1)",
+			interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredUInt256ValueFromBigInt(uint256Max)),
+		},
+		// lcm overflow
+		{"lcm(UInt8(16), 17)", interpreter.Nil},
+		{"lcm(UInt256.max, 2)", interpreter.Nil},
+		{"lcm(Int8(-128), 3)", interpreter.Nil},
+	}
+
+	for _, testCase := range tests {
+
+		testCase := testCase
+
+		t.Run(testCase.expression, func(t *testing.T) {
+			t.Parallel()
+
+			test(t, testCase.expression, testCase.expected)
+		})
+	}
+
+	t.Run("gcd overflow", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parseCheckAndInterpretWithOptions(t,
+			`
+              let x = gcd(Int8.min, 0)
+            `,
+			options,
+		)
+		RequireError(t, err)
+
+		require.ErrorAs(t, err, &interpreter.OverflowError{})
+	})
+
+}
+
+func TestInterpretMinAndMax(t *testing.T) {
+
+	t.Parallel()
+
+	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+	baseValueActivation.DeclareValue(stdlib.MinFunction)
+	baseValueActivation.DeclareValue(stdlib.MaxFunction)
+
+	baseActivation := activations.NewActivation(nil, interpreter.BaseActivation)
+	interpreter.Declare(baseActivation, stdlib.MinFunction)
+	interpreter.Declare(baseActivation, stdlib.MaxFunction)
+
+	options := ParseCheckAndInterpretOptions{
+		CheckerConfig: &sema.Config{
+			BaseValueActivationHandler: func(common.Location) *sema.VariableActivation {
+				return baseValueActivation
+			},
+		},
+		Config: &interpreter.Config{
+			BaseActivationHandler: func(common.Location) *interpreter.VariableActivation {
+				return baseActivation
+			},
+		},
+	}
+
+	tests := []struct {
+		expression string
+		expected   interpreter.Value
+	}{
+		{"min(1, 2)", interpreter.NewUnmeteredIntValueFromInt64(1)},
+		{"max(1, 2)", interpreter.NewUnmeteredIntValueFromInt64(2)},
+		{"min(2, 1)", interpreter.NewUnmeteredIntValueFromInt64(1)},
+		{"max(2, 1)", interpreter.NewUnmeteredIntValueFromInt64(2)},
+		// equal arguments
+		{"min(3, 3)", interpreter.NewUnmeteredIntValueFromInt64(3)},
+		{"max(3, 3)", interpreter.NewUnmeteredIntValueFromInt64(3)},
+		// mixed signs
+		{"min(Int8(-5), 3)", interpreter.NewUnmeteredInt8Value(-5)},
+		{"max(Int8(-5), 3)", interpreter.NewUnmeteredInt8Value(3)},
+		{"min(Int64.min, Int64.max)", interpreter.NewUnmeteredInt64Value(math.MinInt64)},
+		{"max(Int64.min, Int64.max)", interpreter.NewUnmeteredInt64Value(math.MaxInt64)},
+		// fixed-point
+		{"min(-1.5, 0.5)", interpreter.NewUnmeteredFix64Value(-150000000)},
+		{"max(UFix64(1.5), 0.5)", interpreter.NewUnmeteredUFix64Value(150000000)},
+		// other comparable types
+		{`min("b", "a")`, interpreter.NewUnmeteredStringValue("a")},
+		{`max("b", "a")`, interpreter.NewUnmeteredStringValue("b")},
+	}
+
+	for _, testCase := range tests {
+
+		testCase := testCase
+
+		t.Run(testCase.expression, func(t *testing.T) {
+			t.Parallel()
+
+			inter, err := parseCheckAndInterpretWithOptions(t,
+				fmt.Sprintf(
+					`
+                      let x = %s
+                    `,
+					testCase.expression,
+				),
+				options,
+			)
+			require.NoError(t, err)
+
+			AssertValuesEqual(
+				t,
+				inter,
+				testCase.expected,
+				inter.Globals.Get("x").GetValue(inter),
+			)
+		})
+	}
+}
@@ -21,6 +21,8 @@ package interpreter_test
 import (
 	"fmt"
 	"math/big"
+	"math/rand"
+	"sort"
 	"strings"
 	"testing"
 
@@ -3930,6 +3932,145 @@ func TestInterpretOptionalMap(t *testing.T) {
 	})
 }
 
+func TestInterpretOptionalOrElse(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("some", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          var calls = 0
+
+          fun expensiveDefault(): Int {
+              calls = calls + 1
+              return 0
+          }
+
+          let one: Int? = 42
+          let result = one.orElse(expensiveDefault)
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(42),
+			inter.Globals.Get("result").GetValue(inter),
+		)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(0),
+			inter.Globals.Get("calls").GetValue(inter),
+		)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          var calls = 0
+
+          let none: Int? = nil
+          let result = none.orElse(fun (): Int {
+              calls = calls + 1
+              return 7
+          })
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(7),
+			inter.Globals.Get("result").GetValue(inter),
+		)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(1),
+			inter.Globals.Get("calls").GetValue(inter),
+		)
+	})
+
+	t.Run("nested optional", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): String? {
+              let none: String?? = nil
+              return none.orElse(fun (): String? {
+                  return "default"
+              })
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredSomeValueNonCopying(
+				interpreter.NewUnmeteredStringValue("default"),
+			),
+			value,
+		)
+	})
+}
+
+func TestInterpretOptionalUnwrap(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("some", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): Int {
+              let one: Int? = 42
+              return one.unwrap(orFail: "one is nil")
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(42),
+			value,
+		)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): Int {
+              let none: Int? = nil
+              return none.unwrap(orFail: "none is nil")
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		var unwrapNilErr interpreter.UnwrapNilError
+		require.ErrorAs(t, err, &unwrapNilErr)
+
+		assert.Equal(t, "none is nil", unwrapNilErr.Message)
+		assert.ErrorContains(t, err, "none is nil")
+	})
+}
+
 func TestInterpretCompositeNilEquality(t *testing.T) {
 
 	t.Parallel()
@@ -5785,8 +5926,9 @@ func TestInterpretInvalidArrayInsert(t *testing.T) {
 	t.Parallel()
 
 	for name, index := range map[string]int{
-		"negative":          -1,
-		"larger than count": 4,
+		"negative":               -1,
+		"larger than count":      4,
+		"much larger than count": 10,
 	} {
 
 		t.Run(name, func(t *testing.T) {
@@ -5808,6 +5950,15 @@ func TestInterpretInvalidArrayInsert(t *testing.T) {
 
 			assert.Equal(t, index, indexErr.Index)
 			assert.Equal(t, 3, indexErr.Size)
+			assert.True(t, indexErr.Insertion)
+			assert.EqualError(t,
+				indexErr,
+				fmt.Sprintf(
+					"array index out of bounds for insertion: %d, but length is 3: "+
+						"expected an index in the range 0 to 3",
+					index,
+				),
+			)
 			assert.Equal(t,
 				ast.Position{Offset: 94, Line: 5, Column: 19},
 				indexErr.HasPosition.StartPosition(),
@@ -6152,6 +6303,108 @@ func TestInterpretArraySlicing(t *testing.T) {
 	}
 }
 
+func TestInterpretArraySliced(t *testing.T) {
+
+	t.Parallel()
+
+	type test struct {
+		from       int
+		to         int
+		result     string
+		checkError func(t *testing.T, err error)
+	}
+
+	tests := []test{
+		{0, 6, "[1, 2, 3, 4, 5, 6]", nil},
+		{1, 3, "[2, 3]", nil},
+		{6, 6, "[]", nil},
+		// Negative from
+		{-2, 6, "[5, 6]", nil},
+		{-6, 2, "[1, 2]", nil},
+		// Negative to
+		{0, -1, "[1, 2, 3, 4, 5]", nil},
+		{1, -4, "[2]", nil},
+		// Negative from and to
+		{-3, -1, "[4, 5]", nil},
+		{-1, -1, "[]", nil},
+		// Out of range
+		{-7, 6, "", func(t *testing.T, err error) {
+			var sliceErr interpreter.ArraySliceIndicesError
+			require.ErrorAs(t, err, &sliceErr)
+
+			assert.Equal(t, -7, sliceErr.FromIndex)
+			assert.Equal(t, 6, sliceErr.UpToIndex)
+			assert.Equal(t, 6, sliceErr.Size)
+		}},
+		{0, 7, "", func(t *testing.T, err error) {
+			var sliceErr interpreter.ArraySliceIndicesError
+			require.ErrorAs(t, err, &sliceErr)
+
+			assert.Equal(t, 0, sliceErr.FromIndex)
+			assert.Equal(t, 7, sliceErr.UpToIndex)
+			assert.Equal(t, 6, sliceErr.Size)
+		}},
+		{-10, 10, "", func(t *testing.T, err error) {
+			var sliceErr interpreter.ArraySliceIndicesError
+			require.ErrorAs(t, err, &sliceErr)
+
+			assert.Equal(t, -10, sliceErr.FromIndex)
+			assert.Equal(t, 10, sliceErr.UpToIndex)
+			assert.Equal(t, 6, sliceErr.Size)
+		}},
+		// Start after end, after resolving negative indices
+		{-1, 2, "", func(t *testing.T, err error) {
+			var indexErr interpreter.InvalidSliceIndexError
+			require.ErrorAs(t, err, &indexErr)
+
+			assert.Equal(t, 5, indexErr.FromIndex)
+			assert.Equal(t, 2, indexErr.UpToIndex)
+		}},
+	}
+
+	runTest := func(test test) {
+		t.Run(fmt.Sprintf("%d, %d", test.from, test.to), func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      fun test(): [Int] {
+                        let s = [1, 2, 3, 4, 5, 6]
+                        return s.sliced(from: %d, to: %d)
+                      }
+                    `,
+					test.from,
+					test.to,
+				),
+			)
+
+			value, err := inter.Invoke("test")
+			if test.checkError == nil {
+				require.NoError(t, err)
+
+				assert.Equal(
+					t,
+					test.result,
+					fmt.Sprint(value),
+				)
+			} else {
+				require.IsType(t,
+					interpreter.Error{},
+					err,
+				)
+
+				test.checkError(t, err)
+			}
+		})
+	}
+
+	for _, test := range tests {
+		runTest(test)
+	}
+}
+
 func TestInterpretArrayContains(t *testing.T) {
 
 	t.Parallel()
@@ -6505,42 +6758,232 @@ func TestInterpretDictionaryForEachKey(t *testing.T) {
 
 }
 
-func TestInterpretDictionaryValues(t *testing.T) {
+func TestInterpretDictionarySortedKeys(t *testing.T) {
 
 	t.Parallel()
 
 	inter := parseCheckAndInterpret(t, `
-      fun test(): [Int] {
-          let dict = {"def": 2, "abc": 1}
-          dict.insert(key: "a", 3)
-          return dict.values
+      let dict = {"b": 1, "c": 3, "a": 2, "d": 1}
+
+      fun testByValue(): [String] {
+          return dict.sortedKeys(
+              by: view fun (key: String, value: Int, otherKey: String, otherValue: Int): Bool {
+                  if value == otherValue {
+                      return key < otherKey
+                  }
+                  return value < otherValue
+              }
+          )
+      }
+
+      fun testByKey(): [String] {
+          return dict.sortedKeys(
+              by: view fun (key: String, value: Int, otherKey: String, otherValue: Int): Bool {
+                  return key > otherKey
+              }
+          )
+      }
+
+      fun testEmpty(): [String] {
+          let empty: {String: Int} = {}
+          return empty.sortedKeys(
+              by: view fun (key: String, value: Int, otherKey: String, otherValue: Int): Bool {
+                  return key < otherKey
+              }
+          )
       }
     `)
 
-	value, err := inter.Invoke("test")
-	require.NoError(t, err)
+	test := func(t *testing.T, name string, expected ...string) {
+		t.Run(name, func(t *testing.T) {
+			value, err := inter.Invoke(name)
+			require.NoError(t, err)
 
-	arrayValue := value.(*interpreter.ArrayValue)
+			arrayValue := value.(*interpreter.ArrayValue)
+
+			expectedValues := make([]interpreter.Value, 0, len(expected))
+			for _, key := range expected {
+				expectedValues = append(expectedValues, interpreter.NewUnmeteredStringValue(key))
+			}
+
+			AssertValueSlicesEqual(
+				t,
+				inter,
+				expectedValues,
+				ArrayElements(inter, arrayValue),
+			)
+		})
+	}
+
+	test(t, "testByValue", "b", "d", "a", "c")
+	test(t, "testByKey", "d", "c", "b", "a")
+	test(t, "testEmpty")
+}
+
+func TestInterpretDictionaryGetOrDefault(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = {"abc": 1, "def": 2}
+      let present = xs.getOrDefault("abc", 3)
+      let absent = xs.getOrDefault("ghi", 3)
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewUnmeteredIntValueFromInt64(1),
+		inter.Globals.Get("present").GetValue(inter),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewUnmeteredIntValueFromInt64(3),
+		inter.Globals.Get("absent").GetValue(inter),
+	)
+
+	// The dictionary is not modified
+
+	actualValue := inter.Globals.Get("xs").GetValue(inter)
+
+	require.IsType(t, actualValue, &interpreter.DictionaryValue{})
+	actualDict := actualValue.(*interpreter.DictionaryValue)
 
 	AssertValueSlicesEqual(
 		t,
 		inter,
 		[]interpreter.Value{
+			interpreter.NewUnmeteredStringValue("abc"),
 			interpreter.NewUnmeteredIntValueFromInt64(1),
+			interpreter.NewUnmeteredStringValue("def"),
 			interpreter.NewUnmeteredIntValueFromInt64(2),
-			interpreter.NewUnmeteredIntValueFromInt64(3),
 		},
-		ArrayElements(inter, arrayValue),
+		DictionaryKeyValues(inter, actualDict),
 	)
 }
 
-func TestInterpretDictionaryKeyTypes(t *testing.T) {
+func TestInterpretDictionaryGetOrInsert(t *testing.T) {
 
 	t.Parallel()
 
-	tests := map[string]string{
-		"String":         `"abc"`,
-		"Character":      `"X"`,
+	inter := parseCheckAndInterpret(t, `
+      let xs = {"abc": 1, "def": 2}
+      let present = xs.getOrInsert("abc", 3)
+      let absent = xs.getOrInsert("ghi", 3)
+      let again = xs.getOrInsert("ghi", 4)
+      let inserted = xs["ghi"]
+      let length = xs.length
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewUnmeteredIntValueFromInt64(1),
+		inter.Globals.Get("present").GetValue(inter),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewUnmeteredIntValueFromInt64(3),
+		inter.Globals.Get("absent").GetValue(inter),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewUnmeteredIntValueFromInt64(3),
+		inter.Globals.Get("again").GetValue(inter),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewUnmeteredSomeValueNonCopying(
+			interpreter.NewUnmeteredIntValueFromInt64(3),
+		),
+		inter.Globals.Get("inserted").GetValue(inter),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewUnmeteredIntValueFromInt64(3),
+		inter.Globals.Get("length").GetValue(inter),
+	)
+}
+
+func TestInterpretDictionaryGetOrInsertNestedContainer(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): [Int] {
+          let xs: {String: [Int]} = {}
+          xs.getOrInsert("a", []).append(1)
+          xs.getOrInsert("a", [2]).append(3)
+          return xs["a"]!
+      }
+    `)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			&interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeInt,
+			},
+			common.ZeroAddress,
+			interpreter.NewUnmeteredIntValueFromInt64(1),
+			interpreter.NewUnmeteredIntValueFromInt64(3),
+		),
+		result,
+	)
+}
+
+func TestInterpretDictionaryValues(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): [Int] {
+          let dict = {"def": 2, "abc": 1}
+          dict.insert(key: "a", 3)
+          return dict.values
+      }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	arrayValue := value.(*interpreter.ArrayValue)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewUnmeteredIntValueFromInt64(1),
+			interpreter.NewUnmeteredIntValueFromInt64(2),
+			interpreter.NewUnmeteredIntValueFromInt64(3),
+		},
+		ArrayElements(inter, arrayValue),
+	)
+}
+
+func TestInterpretDictionaryKeyTypes(t *testing.T) {
+
+	t.Parallel()
+
+	tests := map[string]string{
+		"String":         `"abc"`,
+		"Character":      `"X"`,
 		"Address":        `0x1`,
 		"Bool":           `true`,
 		"Path":           `/storage/a`,
@@ -8300,6 +8743,68 @@ func TestInterpretOptionalChainingFunctionCall(t *testing.T) {
 	)
 }
 
+func TestInterpretOptionalChainingOptionalFunctionCall(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t,
+		`
+         struct Test {
+             let result: Int?
+
+             init(result: Int?) {
+                 self.result = result
+             }
+
+             fun x(): Int? {
+                 return self.result
+             }
+         }
+
+         let test1: Test? = nil
+         let x1 = test1?.x()
+
+         let test2: Test? = Test(result: nil)
+         let x2 = test2?.x()
+
+         let test3: Test? = Test(result: 42)
+         let x3 = test3?.x()
+       `,
+	)
+
+	// nil receiver
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.Nil,
+		inter.Globals.Get("x1").GetValue(inter),
+	)
+
+	// non-nil receiver, function returns nil.
+	// The nested nil is unboxed when the result is boxed to the declared type `Int??`
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.Nil,
+		inter.Globals.Get("x2").GetValue(inter),
+	)
+
+	// non-nil receiver, function returns non-nil
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewUnmeteredSomeValueNonCopying(
+			interpreter.NewUnmeteredSomeValueNonCopying(
+				interpreter.NewUnmeteredIntValueFromInt64(42),
+			),
+		),
+		inter.Globals.Get("x3").GetValue(inter),
+	)
+}
+
 func TestInterpretOptionalChainingFieldReadAndNilCoalescing(t *testing.T) {
 
 	t.Parallel()
@@ -10396,93 +10901,354 @@ func TestInterpretArrayReverse(t *testing.T) {
 	}
 }
 
-func TestInterpretArrayFilter(t *testing.T) {
+func TestInterpretArrayShuffled(t *testing.T) {
+	t.Parallel()
 
-	runValidCase := func(
-		t *testing.T,
-		inter *interpreter.Interpreter,
-		filterFuncName,
-		originalFuncName string,
-		filteredArray, originalArray *interpreter.ArrayValue,
-	) {
-		val, err := inter.Invoke(filterFuncName)
+	const code = `
+      let xs = [1, 2, 3, 4, 5, 6, 7, 8, 9, 10]
+      let xs_fixed: [Int; 10] = [1, 2, 3, 4, 5, 6, 7, 8, 9, 10]
+
+      fun shuffled(): [Int] {
+          return xs.shuffled()
+      }
+
+      fun shuffled_fixed(): [Int; 10] {
+          return xs_fixed.shuffled()
+      }
+
+      fun original(): [Int] {
+          return xs
+      }
+    `
+
+	newSeededRandomHandler := func(seed int64) interpreter.RandomHandlerFunc {
+		random := rand.New(rand.NewSource(seed))
+		return func(buffer []byte) error {
+			_, err := random.Read(buffer)
+			return err
+		}
+	}
+
+	invokeShuffled := func(t *testing.T, functionName string, seed int64) []int {
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			code,
+			ParseCheckAndInterpretOptions{
+				Config: &interpreter.Config{
+					RandomHandler: newSeededRandomHandler(seed),
+				},
+			},
+		)
 		require.NoError(t, err)
 
-		AssertValuesEqual(
-			t,
+		result, err := inter.Invoke(functionName)
+		require.NoError(t, err)
+
+		array, ok := result.(*interpreter.ArrayValue)
+		require.True(t, ok)
+
+		values := make([]int, 0, array.Count())
+		array.Iterate(
 			inter,
-			filteredArray,
-			val,
+			func(element interpreter.Value) (resume bool) {
+				values = append(values, element.(interpreter.IntValue).ToInt(interpreter.EmptyLocationRange))
+				return true
+			},
+			false,
+			interpreter.EmptyLocationRange,
 		)
 
-		origVal, err := inter.Invoke(originalFuncName)
+		// The original array must not be modified
+		original, err := inter.Invoke("original")
 		require.NoError(t, err)
 
-		// Original array remains unchanged
 		AssertValuesEqual(
 			t,
 			inter,
-			originalArray,
-			origVal,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt,
+				},
+				common.ZeroAddress,
+				interpreter.NewUnmeteredIntValueFromInt64(1),
+				interpreter.NewUnmeteredIntValueFromInt64(2),
+				interpreter.NewUnmeteredIntValueFromInt64(3),
+				interpreter.NewUnmeteredIntValueFromInt64(4),
+				interpreter.NewUnmeteredIntValueFromInt64(5),
+				interpreter.NewUnmeteredIntValueFromInt64(6),
+				interpreter.NewUnmeteredIntValueFromInt64(7),
+				interpreter.NewUnmeteredIntValueFromInt64(8),
+				interpreter.NewUnmeteredIntValueFromInt64(9),
+				interpreter.NewUnmeteredIntValueFromInt64(10),
+			),
+			original,
 		)
+
+		return values
 	}
 
-	t.Run("with variable sized empty array", func(t *testing.T) {
-		t.Parallel()
+	for _, functionName := range []string{"shuffled", "shuffled_fixed"} {
 
-		inter := parseCheckAndInterpret(t, `
-			let emptyVals: [Int] = []
+		functionName := functionName
 
-			let onlyEven =
-				view fun (_ x: Int): Bool {
-					return x % 2 == 0
-				}
+		t.Run(functionName, func(t *testing.T) {
+			t.Parallel()
 
-			fun filterempty(): [Int] {
-				return emptyVals.filter(onlyEven)
-			}
-			fun originalempty(): [Int] {
-				return emptyVals
-			}
-		`)
+			first := invokeShuffled(t, functionName, 42)
 
-		emptyVarSizedArray := interpreter.NewArrayValue(
-			inter,
-			interpreter.EmptyLocationRange,
-			&interpreter.VariableSizedStaticType{
-				Type: interpreter.PrimitiveStaticTypeInt,
-			},
-			common.ZeroAddress,
-		)
+			t.Run("same seed, same order", func(t *testing.T) {
+				second := invokeShuffled(t, functionName, 42)
+				assert.Equal(t, first, second)
+			})
 
-		runValidCase(
-			t,
-			inter,
-			"filterempty",
-			"originalempty",
-			emptyVarSizedArray,
-			emptyVarSizedArray,
-		)
-	})
+			t.Run("different seed, different order", func(t *testing.T) {
+				other := invokeShuffled(t, functionName, 1)
+				assert.NotEqual(t, first, other)
+			})
 
-	t.Run("with variable sized array of integer", func(t *testing.T) {
-		t.Parallel()
+			t.Run("elements preserved", func(t *testing.T) {
+				sorted := make([]int, len(first))
+				copy(sorted, first)
+				sort.Ints(sorted)
 
-		inter := parseCheckAndInterpret(t, `
-			let xs = [1, 2, 3, 100, 201]
+				assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, sorted)
+			})
+		})
+	}
 
-			let onlyEven =
-				view fun (_ x: Int): Bool {
-					return x % 2 == 0
-				}
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
 
-			fun filterxs(): [Int] {
-				return xs.filter(onlyEven)
-			}
-			fun originalxs(): [Int] {
-				return xs
-			}
-		`)
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun test(): [Int] {
+                  let xs: [Int] = []
+                  return xs.shuffled()
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Config: &interpreter.Config{
+					RandomHandler: newSeededRandomHandler(42),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt,
+				},
+				common.ZeroAddress,
+			),
+			result,
+		)
+	})
+
+	t.Run("randomness unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, code)
+
+		_, err := inter.Invoke("shuffled")
+		RequireError(t, err)
+
+		require.ErrorAs(t, err, &interpreter.RandomUnavailableError{})
+	})
+}
+
+func TestInterpretArrayRotated(t *testing.T) {
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [1, 2, 3, 4, 5]
+      let xs_fixed: [Int; 5] = [1, 2, 3, 4, 5]
+      let empty: [Int] = []
+
+      fun rotated(_ positions: Int): [Int] {
+          return xs.rotated(by: positions)
+      }
+
+      fun rotated_fixed(_ positions: Int): [Int; 5] {
+          return xs_fixed.rotated(by: positions)
+      }
+
+      fun rotated_empty(_ positions: Int): [Int] {
+          return empty.rotated(by: positions)
+      }
+
+      fun original(): [Int] {
+          return xs
+      }
+    `)
+
+	invokeRotated := func(t *testing.T, functionName string, positions int64) []int {
+		result, err := inter.Invoke(
+			functionName,
+			interpreter.NewUnmeteredIntValueFromInt64(positions),
+		)
+		require.NoError(t, err)
+
+		array, ok := result.(*interpreter.ArrayValue)
+		require.True(t, ok)
+
+		values := make([]int, 0, array.Count())
+		array.Iterate(
+			inter,
+			func(element interpreter.Value) (resume bool) {
+				values = append(values, element.(interpreter.IntValue).ToInt(interpreter.EmptyLocationRange))
+				return true
+			},
+			false,
+			interpreter.EmptyLocationRange,
+		)
+
+		return values
+	}
+
+	for _, functionName := range []string{"rotated", "rotated_fixed"} {
+		t.Run(functionName, func(t *testing.T) {
+			// Zero positions
+			assert.Equal(t, []int{1, 2, 3, 4, 5}, invokeRotated(t, functionName, 0))
+			// Positive positions rotate to the left
+			assert.Equal(t, []int{3, 4, 5, 1, 2}, invokeRotated(t, functionName, 2))
+			// Positions equal to the length
+			assert.Equal(t, []int{1, 2, 3, 4, 5}, invokeRotated(t, functionName, 5))
+			// Positions exceeding the length
+			assert.Equal(t, []int{3, 4, 5, 1, 2}, invokeRotated(t, functionName, 12))
+			// Negative positions rotate to the right
+			assert.Equal(t, []int{5, 1, 2, 3, 4}, invokeRotated(t, functionName, -1))
+			assert.Equal(t, []int{4, 5, 1, 2, 3}, invokeRotated(t, functionName, -7))
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, []int{}, invokeRotated(t, "rotated_empty", 3))
+		assert.Equal(t, []int{}, invokeRotated(t, "rotated_empty", -3))
+	})
+
+	t.Run("original unchanged", func(t *testing.T) {
+		invokeRotated(t, "rotated", 2)
+
+		original, err := inter.Invoke("original")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt,
+				},
+				common.ZeroAddress,
+				interpreter.NewUnmeteredIntValueFromInt64(1),
+				interpreter.NewUnmeteredIntValueFromInt64(2),
+				interpreter.NewUnmeteredIntValueFromInt64(3),
+				interpreter.NewUnmeteredIntValueFromInt64(4),
+				interpreter.NewUnmeteredIntValueFromInt64(5),
+			),
+			original,
+		)
+	})
+}
+
+func TestInterpretArrayFilter(t *testing.T) {
+
+	runValidCase := func(
+		t *testing.T,
+		inter *interpreter.Interpreter,
+		filterFuncName,
+		originalFuncName string,
+		filteredArray, originalArray *interpreter.ArrayValue,
+	) {
+		val, err := inter.Invoke(filterFuncName)
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			filteredArray,
+			val,
+		)
+
+		origVal, err := inter.Invoke(originalFuncName)
+		require.NoError(t, err)
+
+		// Original array remains unchanged
+		AssertValuesEqual(
+			t,
+			inter,
+			originalArray,
+			origVal,
+		)
+	}
+
+	t.Run("with variable sized empty array", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+			let emptyVals: [Int] = []
+
+			let onlyEven =
+				view fun (_ x: Int): Bool {
+					return x % 2 == 0
+				}
+
+			fun filterempty(): [Int] {
+				return emptyVals.filter(onlyEven)
+			}
+			fun originalempty(): [Int] {
+				return emptyVals
+			}
+		`)
+
+		emptyVarSizedArray := interpreter.NewArrayValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			&interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeInt,
+			},
+			common.ZeroAddress,
+		)
+
+		runValidCase(
+			t,
+			inter,
+			"filterempty",
+			"originalempty",
+			emptyVarSizedArray,
+			emptyVarSizedArray,
+		)
+	})
+
+	t.Run("with variable sized array of integer", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+			let xs = [1, 2, 3, 100, 201]
+
+			let onlyEven =
+				view fun (_ x: Int): Bool {
+					return x % 2 == 0
+				}
+
+			fun filterxs(): [Int] {
+				return xs.filter(onlyEven)
+			}
+			fun originalxs(): [Int] {
+				return xs
+			}
+		`)
 
 		varSizedArrayType := &interpreter.VariableSizedStaticType{
 			Type: interpreter.PrimitiveStaticTypeInt,
@@ -10783,6 +11549,470 @@ func TestInterpretArrayFilter(t *testing.T) {
 	})
 }
 
+func TestInterpretArrayMinAndMax(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, array string, elementType string, expectedMin, expectedMax interpreter.Value) {
+		inter := parseCheckAndInterpret(t, fmt.Sprintf(
+			`
+              let xs: [%[2]s] = %[1]s
+
+              fun testMin(): %[2]s? {
+                  return xs.min()
+              }
+
+              fun testMax(): %[2]s? {
+                  return xs.max()
+              }
+            `,
+			array,
+			elementType,
+		))
+
+		value, err := inter.Invoke("testMin")
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, expectedMin, value)
+
+		value, err = inter.Invoke("testMax")
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, expectedMax, value)
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "[]", "Int", interpreter.Nil, interpreter.Nil)
+	})
+
+	t.Run("single element", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			"[7]",
+			"Int",
+			interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredIntValueFromInt64(7)),
+			interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredIntValueFromInt64(7)),
+		)
+	})
+
+	t.Run("equal elements", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			"[2, 2, 2]",
+			"Int",
+			interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredIntValueFromInt64(2)),
+			interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredIntValueFromInt64(2)),
+		)
+	})
+
+	t.Run("mixed signs", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			"[3, -10, 0, 42, -1]",
+			"Int8",
+			interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredInt8Value(-10)),
+			interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredInt8Value(42)),
+		)
+	})
+
+	t.Run("fixed-point", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			"[0.5, -2.25, 1.0]",
+			"Fix64",
+			interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredFix64Value(-225000000)),
+			interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredFix64Value(100000000)),
+		)
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`["b", "c", "a"]`,
+			"String",
+			interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredStringValue("a")),
+			interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredStringValue("c")),
+		)
+	})
+}
+
+func TestInterpretArrayPartition(t *testing.T) {
+
+	t.Parallel()
+
+	newIntArray := func(inter *interpreter.Interpreter, values ...int64) *interpreter.ArrayValue {
+		elements := make([]interpreter.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, interpreter.NewUnmeteredIntValueFromInt64(value))
+		}
+
+		return interpreter.NewArrayValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			&interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeInt,
+			},
+			common.ZeroAddress,
+			elements...,
+		)
+	}
+
+	newPartitions := func(inter *interpreter.Interpreter, matching, notMatching []int64) *interpreter.ArrayValue {
+		return interpreter.NewArrayValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			&interpreter.ConstantSizedStaticType{
+				Type: &interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt,
+				},
+				Size: 2,
+			},
+			common.ZeroAddress,
+			newIntArray(inter, matching...),
+			newIntArray(inter, notMatching...),
+		)
+	}
+
+	test := func(t *testing.T, array string, matching, notMatching []int64) {
+		inter := parseCheckAndInterpret(t, fmt.Sprintf(
+			`
+              let xs: [Int] = %s
+
+              fun test(): [[Int]; 2] {
+                  return xs.partition(view fun (_ x: Int): Bool {
+                      return x %% 2 == 0
+                  })
+              }
+            `,
+			array,
+		))
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			newPartitions(inter, matching, notMatching),
+			value,
+		)
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "[]", nil, nil)
+	})
+
+	t.Run("all match", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "[2, 4, 6]", []int64{2, 4, 6}, nil)
+	})
+
+	t.Run("none match", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "[1, 3, 5]", nil, []int64{1, 3, 5})
+	})
+
+	t.Run("mixed, order preserved", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "[5, 2, 1, 8, 3, 4]", []int64{2, 8, 4}, []int64{5, 1, 3})
+	})
+
+	t.Run("constant sized array", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let xs: [Int; 4] = [1, 2, 3, 4]
+
+          fun test(): [[Int]; 2] {
+              return xs.partition(view fun (_ x: Int): Bool {
+                  return x > 2
+              })
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			newPartitions(inter, []int64{3, 4}, []int64{1, 2}),
+			value,
+		)
+	})
+
+	t.Run("original array unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let xs = [1, 2, 3]
+
+          fun test(): [Int] {
+              let partitions = xs.partition(view fun (_ x: Int): Bool {
+                  return x == 2
+              })
+              return xs
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			newIntArray(inter, 1, 2, 3),
+			value,
+		)
+	})
+}
+
+func TestInterpretArrayForEachIndexed(t *testing.T) {
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun collect(_ xs: [String]): [String] {
+          let result: [String] = []
+          xs.forEachIndexed(fun (index: Int, element: String) {
+              result.append(index.toString().concat(":").concat(element))
+          })
+          return result
+      }
+
+      fun empty(): [String] {
+          return collect([])
+      }
+
+      fun multiple(): [String] {
+          return collect(["a", "b", "c"])
+      }
+
+      fun fixedSize(): [Int] {
+          let xs: [Int; 3] = [10, 20, 30]
+          let indices: [Int] = []
+          xs.forEachIndexed(fun (index: Int, element: Int) {
+              indices.append(index)
+              indices.append(element)
+          })
+          return indices
+      }
+    `)
+
+	t.Run("empty", func(t *testing.T) {
+		result, err := inter.Invoke("empty")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeString,
+				},
+				common.ZeroAddress,
+			),
+			result,
+		)
+	})
+
+	t.Run("multiple", func(t *testing.T) {
+		result, err := inter.Invoke("multiple")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeString,
+				},
+				common.ZeroAddress,
+				interpreter.NewUnmeteredStringValue("0:a"),
+				interpreter.NewUnmeteredStringValue("1:b"),
+				interpreter.NewUnmeteredStringValue("2:c"),
+			),
+			result,
+		)
+	})
+
+	t.Run("fixed-size", func(t *testing.T) {
+		result, err := inter.Invoke("fixedSize")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt,
+				},
+				common.ZeroAddress,
+				interpreter.NewUnmeteredIntValueFromInt64(0),
+				interpreter.NewUnmeteredIntValueFromInt64(10),
+				interpreter.NewUnmeteredIntValueFromInt64(1),
+				interpreter.NewUnmeteredIntValueFromInt64(20),
+				interpreter.NewUnmeteredIntValueFromInt64(2),
+				interpreter.NewUnmeteredIntValueFromInt64(30),
+			),
+			result,
+		)
+	})
+
+	t.Run("mutation", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test() {
+              let xs = [1, 2, 3]
+              xs.forEachIndexed(fun (index: Int, element: Int) {
+                  xs.append(element)
+              })
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		require.ErrorAs(t, err, &interpreter.ContainerMutatedDuringIterationError{})
+	})
+}
+
+func TestInterpretArrayZip(t *testing.T) {
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      struct Pair {
+          let first: String
+          let second: Int
+
+          init(first: String, second: Int) {
+              self.first = first
+              self.second = second
+          }
+      }
+
+      fun zip(_ xs: [String], _ ys: [Int]): [String] {
+          let pairs: [Pair] = xs.zip(ys, fun (element: String, otherElement: Int): Pair {
+              return Pair(first: element, second: otherElement)
+          })
+
+          let result: [String] = []
+          for pair in pairs {
+              result.append(pair.first.concat(":").concat(pair.second.toString()))
+          }
+          return result
+      }
+
+      fun equalLength(): [String] {
+          return zip(["a", "b", "c"], [1, 2, 3])
+      }
+
+      fun shorterFirst(): [String] {
+          return zip(["a", "b"], [1, 2, 3])
+      }
+
+      fun shorterSecond(): [String] {
+          return zip(["a", "b", "c"], [1])
+      }
+
+      fun empty(): [String] {
+          return zip([], [1, 2, 3])
+      }
+
+      fun fixedSize(): [Int] {
+          let xs: [Int; 3] = [1, 2, 3]
+          return xs.zip([10, 20, 30], fun (element: Int, otherElement: Int): Int {
+              return element + otherElement
+          })
+      }
+    `)
+
+	stringArray := func(values ...string) interpreter.Value {
+		elements := make([]interpreter.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, interpreter.NewUnmeteredStringValue(value))
+		}
+
+		return interpreter.NewArrayValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			&interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeString,
+			},
+			common.ZeroAddress,
+			elements...,
+		)
+	}
+
+	t.Run("equal length", func(t *testing.T) {
+		result, err := inter.Invoke("equalLength")
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, stringArray("a:1", "b:2", "c:3"), result)
+	})
+
+	t.Run("shorter first", func(t *testing.T) {
+		result, err := inter.Invoke("shorterFirst")
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, stringArray("a:1", "b:2"), result)
+	})
+
+	t.Run("shorter second", func(t *testing.T) {
+		result, err := inter.Invoke("shorterSecond")
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, stringArray("a:1"), result)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		result, err := inter.Invoke("empty")
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, stringArray(), result)
+	})
+
+	t.Run("fixed-size", func(t *testing.T) {
+		result, err := inter.Invoke("fixedSize")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt,
+				},
+				common.ZeroAddress,
+				interpreter.NewUnmeteredIntValueFromInt64(11),
+				interpreter.NewUnmeteredIntValueFromInt64(22),
+				interpreter.NewUnmeteredIntValueFromInt64(33),
+			),
+			result,
+		)
+	})
+}
+
 func TestInterpretArrayMap(t *testing.T) {
 	t.Parallel()
 
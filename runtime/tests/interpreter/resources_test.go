@@ -3585,3 +3585,117 @@ func TestInterpretInvalidNilCoalescingResourceDuplication(t *testing.T) {
 	var destroyedResourceErr interpreter.DestroyedResourceError
 	require.ErrorAs(t, err, &destroyedResourceErr)
 }
+
+func TestInterpretResourceLeak(t *testing.T) {
+
+	t.Parallel()
+
+	newInterpreter := func(t *testing.T, code string, leaks *[]string) *interpreter.Interpreter {
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			code,
+			ParseCheckAndInterpretOptions{
+				Config: &interpreter.Config{
+					OnResourceLeak: func(
+						_ *interpreter.Interpreter,
+						_ interpreter.LocationRange,
+						_ interpreter.ResourceKindedValue,
+						identifier string,
+					) {
+						*leaks = append(*leaks, identifier)
+					},
+				},
+				HandleCheckerError: func(err error) {
+					errs := checker.RequireCheckerErrors(t, err, 1)
+					require.IsType(t, &sema.ResourceLossError{}, errs[0])
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		return inter
+	}
+
+	const leakingCode = `
+      resource R {}
+
+      fun test(_ consume: Bool) {
+          let r <- create R()
+          if consume {
+              destroy r
+          }
+      }
+    `
+
+	t.Run("leaking branch", func(t *testing.T) {
+
+		t.Parallel()
+
+		var leaks []string
+
+		inter := newInterpreter(t, leakingCode, &leaks)
+
+		_, err := inter.Invoke("test", interpreter.FalseValue)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"r"}, leaks)
+	})
+
+	t.Run("consuming branch", func(t *testing.T) {
+
+		t.Parallel()
+
+		var leaks []string
+
+		inter := newInterpreter(t, leakingCode, &leaks)
+
+		_, err := inter.Invoke("test", interpreter.TrueValue)
+		require.NoError(t, err)
+
+		assert.Empty(t, leaks)
+	})
+
+	t.Run("moved", func(t *testing.T) {
+
+		t.Parallel()
+
+		var leaks []string
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              resource R {}
+
+              fun makeR(): @R {
+                  let r <- create R()
+                  return <-r
+              }
+
+              fun consume(_ r: @R) {
+                  destroy r
+              }
+
+              fun test() {
+                  let r <- makeR()
+                  consume(<-r)
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Config: &interpreter.Config{
+					OnResourceLeak: func(
+						_ *interpreter.Interpreter,
+						_ interpreter.LocationRange,
+						_ interpreter.ResourceKindedValue,
+						identifier string,
+					) {
+						leaks = append(leaks, identifier)
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Empty(t, leaks)
+	})
+}
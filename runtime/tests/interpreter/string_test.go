@@ -250,6 +250,83 @@ func TestInterpretStringFromCharacters(t *testing.T) {
 	)
 }
 
+func TestInterpretStringToCharacters(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, value string, expected []string) {
+		inter := parseCheckAndInterpret(t, fmt.Sprintf(
+			`
+              fun test(): [Character] {
+                  return %s.toCharacters()
+              }
+
+              fun testRoundTrip(): Bool {
+                  let s = %[1]s
+                  return String.fromCharacters(s.toCharacters()) == s
+              }
+            `,
+			value,
+		))
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		expectedCharacters := make([]interpreter.Value, 0, len(expected))
+		for _, character := range expected {
+			expectedCharacters = append(
+				expectedCharacters,
+				interpreter.NewUnmeteredCharacterValue(character),
+			)
+		}
+
+		RequireValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeCharacter,
+				},
+				common.ZeroAddress,
+				expectedCharacters...,
+			),
+			result,
+		)
+
+		result, err = inter.Invoke("testRoundTrip")
+		require.NoError(t, err)
+
+		require.Equal(t, interpreter.TrueValue, result)
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `""`, []string{})
+	})
+
+	t.Run("ASCII", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `"Flow"`, []string{"F", "l", "o", "w"})
+	})
+
+	t.Run("multi-byte", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `"Flöw👪❤️"`, []string{"F", "l", "ö", "w", "👪", "❤️"})
+	})
+
+	t.Run("combining characters", func(t *testing.T) {
+		t.Parallel()
+
+		// "e" followed by a combining acute accent is a single character
+		test(t, `"cafe\u{301}s"`, []string{"c", "a", "f", "e\u0301", "s"})
+	})
+}
+
 func TestInterpretStringUtf8Field(t *testing.T) {
 
 	t.Parallel()
@@ -327,6 +404,128 @@ func TestInterpretStringToLower(t *testing.T) {
 	)
 }
 
+func TestInterpretStringToUpper(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, value string, expected string) {
+		inter := parseCheckAndInterpret(t, fmt.Sprintf(
+			`
+              fun test(): String {
+                  return %q.toUpper()
+              }
+            `,
+			value,
+		))
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.Equal(t,
+			interpreter.NewUnmeteredStringValue(expected),
+			result,
+		)
+	}
+
+	t.Run("ASCII", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "Flowers", "FLOWERS")
+	})
+
+	t.Run("multi-byte", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "éàü ɐ ÿ", "ÉÀÜ Ɐ Ÿ")
+	})
+
+	t.Run("no special casing", func(t *testing.T) {
+		t.Parallel()
+
+		// ß has no single-character uppercase mapping
+		test(t, "straße", "STRAßE")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "", "")
+	})
+}
+
+func TestInterpretStringToLowerMultiByte(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): String {
+          return "ÉÀÜ Ɐ Ÿ".toLower()
+      }
+    `)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.Equal(t,
+		interpreter.NewUnmeteredStringValue("éàü ɐ ÿ"),
+		result,
+	)
+}
+
+func TestInterpretStringTrim(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, value string, expected string) {
+		inter := parseCheckAndInterpret(t, fmt.Sprintf(
+			`
+              fun test(): String {
+                  return %s.trim()
+              }
+            `,
+			value,
+		))
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.Equal(t,
+			interpreter.NewUnmeteredStringValue(expected),
+			result,
+		)
+	}
+
+	t.Run("leading and trailing", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `"  Flow \t\n"`, "Flow")
+	})
+
+	t.Run("inner whitespace preserved", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `" hello  world "`, "hello  world")
+	})
+
+	t.Run("multi-byte whitespace", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `"\u{3000}\u{A0}Flöw\u{2003}"`, "Flöw")
+	})
+
+	t.Run("all whitespace", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `" \t\r\n\u{3000} "`, "")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `""`, "")
+	})
+}
+
 func TestInterpretStringAccess(t *testing.T) {
 
 	t.Parallel()
@@ -500,6 +699,72 @@ func TestInterpretStringJoin(t *testing.T) {
 	testCase(t, "testSingletonArray", interpreter.NewUnmeteredStringValue("pqrS"))
 }
 
+func TestInterpretStringFormat(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+		fun test(): String {
+			return String.format("{} + {} = {}", [1, 2.5, "three"])
+		}
+
+		fun testCharacter(): String {
+			let c: Character = "x"
+			return String.format("[{}]", [c])
+		}
+
+		fun testNoPlaceholders(): String {
+			return String.format("👪❤️", [])
+		}
+
+		fun testAdjacentPlaceholders(): String {
+			return String.format("{}{}", [true, nil])
+		}
+
+		fun testExtraArguments(): String {
+			return String.format("{}", [1, 2])
+		}
+
+		fun testTooFewArguments(): String {
+			return String.format("{} and {}", [1])
+		}
+	`)
+
+	testCase := func(t *testing.T, funcName string, expected *interpreter.StringValue) {
+		t.Run(funcName, func(t *testing.T) {
+			result, err := inter.Invoke(funcName)
+			require.NoError(t, err)
+
+			RequireValuesEqual(
+				t,
+				inter,
+				expected,
+				result,
+			)
+		})
+	}
+
+	testCase(t, "test", interpreter.NewUnmeteredStringValue("1 + 2.50000000 = three"))
+	testCase(t, "testCharacter", interpreter.NewUnmeteredStringValue("[x]"))
+	testCase(t, "testNoPlaceholders", interpreter.NewUnmeteredStringValue("👪❤️"))
+	testCase(t, "testAdjacentPlaceholders", interpreter.NewUnmeteredStringValue("truenil"))
+
+	errorTestCase := func(t *testing.T, funcName string, placeholderCount, argumentCount int) {
+		t.Run(funcName, func(t *testing.T) {
+			_, err := inter.Invoke(funcName)
+			RequireError(t, err)
+
+			var formatErr interpreter.StringFormatArgumentCountError
+			require.ErrorAs(t, err, &formatErr)
+			require.Equal(t, placeholderCount, formatErr.PlaceholderCount)
+			require.Equal(t, argumentCount, formatErr.ArgumentCount)
+		})
+	}
+
+	errorTestCase(t, "testExtraArguments", 1, 2)
+	errorTestCase(t, "testTooFewArguments", 2, 1)
+}
+
 func TestInterpretStringSplit(t *testing.T) {
 
 	t.Parallel()
@@ -390,6 +390,76 @@ func TestInterpretTransactions(t *testing.T) {
 			ArrayElements(inter, values.(*interpreter.ArrayValue)),
 		)
 	})
+
+	t.Run("EventPhases", func(t *testing.T) {
+
+		t.Parallel()
+
+		var phases []interpreter.TransactionPhase
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              event Foo(phase: String)
+
+              transaction {
+                prepare() {
+                  emit Foo(phase: "prepare")
+                }
+
+                pre {
+                  emit Foo(phase: "pre")
+                }
+
+                execute {
+                  emit Foo(phase: "execute")
+                }
+
+                post {
+                  emit Foo(phase: "post")
+                }
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Config: &interpreter.Config{
+					OnEventEmitted: func(
+						inter *interpreter.Interpreter,
+						_ interpreter.LocationRange,
+						event *interpreter.CompositeValue,
+						_ *sema.CompositeType,
+					) error {
+						phase := inter.TransactionPhase()
+
+						phaseField := event.GetField(inter, interpreter.EmptyLocationRange, "phase")
+						assert.Equal(t,
+							interpreter.NewUnmeteredStringValue(phase.String()),
+							phaseField,
+						)
+
+						phases = append(phases, phase)
+						return nil
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, interpreter.TransactionPhaseUnknown, inter.TransactionPhase())
+
+		err = inter.InvokeTransaction(0)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]interpreter.TransactionPhase{
+				interpreter.TransactionPhasePrepare,
+				interpreter.TransactionPhasePre,
+				interpreter.TransactionPhaseExecute,
+				interpreter.TransactionPhasePost,
+			},
+			phases,
+		)
+
+		assert.Equal(t, interpreter.TransactionPhaseUnknown, inter.TransactionPhase())
+	})
 }
 
 func TestRuntimeInvalidTransferInExecute(t *testing.T) {
@@ -19,7 +19,9 @@
 package interpreter_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -139,3 +141,33 @@ func TestInterpretWhileStatementWithBreak(t *testing.T) {
 		value,
 	)
 }
+
+func TestInterpretWhileStatementCancellation(t *testing.T) {
+
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		`
+          fun test() {
+              while true {}
+          }
+        `,
+		ParseCheckAndInterpretOptions{
+			Config: &interpreter.Config{
+				CancellationContext: ctx,
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	RequireError(t, err)
+
+	var cancelledErr interpreter.ExecutionCancelledError
+	require.ErrorAs(t, err, &cancelledErr)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -78,6 +78,10 @@ type TestRuntimeInterface struct {
 		oldAddress common.Address,
 		newAddress common.Address,
 	)
+	OnAccountAccessed func(
+		interpreter *interpreter.Interpreter,
+		address common.Address,
+	)
 	OnGenerateUUID       func() (uint64, error)
 	OnMeterComputation   func(compKind common.ComputationKind, intensity uint) error
 	OnDecodeArgument     func(b []byte, t cadence.Type) (cadence.Value, error)
@@ -549,6 +553,15 @@ func (i *TestRuntimeInterface) GetAccountContractNames(address runtime.Address)
 	return i.OnGetAccountContractNames(address)
 }
 
+func (i *TestRuntimeInterface) AccountAccessed(
+	interpreter *interpreter.Interpreter,
+	address common.Address,
+) {
+	if i.OnAccountAccessed != nil {
+		i.OnAccountAccessed(interpreter, address)
+	}
+}
+
 func (i *TestRuntimeInterface) GenerateAccountID(address common.Address) (uint64, error) {
 	if i.OnGenerateAccountID == nil {
 		if i.accountIDs == nil {